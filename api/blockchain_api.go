@@ -7,6 +7,7 @@ import (
 	"github.com/idena-network/idena-go/blockchain/types"
 	"github.com/idena-network/idena-go/common"
 	"github.com/idena-network/idena-go/common/hexutil"
+	state2 "github.com/idena-network/idena-go/core/state"
 	"github.com/idena-network/idena-go/core/mempool"
 	"github.com/idena-network/idena-go/ipfs"
 	"github.com/idena-network/idena-go/keywords"
@@ -106,23 +107,29 @@ type EstimateRawTxResponse struct {
 	TxFee   decimal.Decimal `json:"txFee"`
 }
 
-func (api *BlockchainApi) LastBlock() *Block {
+func (api *BlockchainApi) LastBlock() (*Block, error) {
 	return api.BlockAt(api.bc.Head.Height())
 }
 
-func (api *BlockchainApi) BlockAt(height uint64) *Block {
+func (api *BlockchainApi) BlockAt(height uint64) (*Block, error) {
 	block := api.bc.GetBlockByHeight(height)
-
-	return convertToBlock(block)
+	if block == nil && api.bc.IsPruned(height) && api.bc.GetBlockHeaderByHeight(height) != nil {
+		return nil, api.blockPrunedErr(height)
+	}
+	return convertToBlock(block), nil
 }
 
-func (api *BlockchainApi) Block(hash common.Hash) *Block {
+func (api *BlockchainApi) Block(hash common.Hash) (*Block, error) {
 	block := api.bc.GetBlock(hash)
-
-	return convertToBlock(block)
+	if block == nil {
+		if header := api.bc.GetBlockHeader(hash); header != nil && api.bc.IsPruned(header.Height()) {
+			return nil, api.blockPrunedErr(header.Height())
+		}
+	}
+	return convertToBlock(block), nil
 }
 
-func (api *BlockchainApi) Transaction(hash common.Hash) *Transaction {
+func (api *BlockchainApi) Transaction(hash common.Hash) (*Transaction, error) {
 	tx := api.pool.GetTx(hash)
 	var idx *types.TransactionIndex
 
@@ -131,7 +138,7 @@ func (api *BlockchainApi) Transaction(hash common.Hash) *Transaction {
 	}
 
 	if tx == nil {
-		return nil
+		return nil, api.txPrunedErr()
 	}
 
 	if idx == nil {
@@ -149,10 +156,10 @@ func (api *BlockchainApi) Transaction(hash common.Hash) *Transaction {
 			timestamp = block.Header.Time()
 		}
 	}
-	return convertToTransaction(tx, blockHash, feePerGas, timestamp)
+	return convertToTransaction(tx, blockHash, feePerGas, timestamp), nil
 }
 
-func (api *BlockchainApi) TxReceipt(hash common.Hash) *TxReceipt {
+func (api *BlockchainApi) TxReceipt(hash common.Hash) (*TxReceipt, error) {
 	tx := api.pool.GetTx(hash)
 	var idx *types.TransactionIndex
 
@@ -161,7 +168,7 @@ func (api *BlockchainApi) TxReceipt(hash common.Hash) *TxReceipt {
 	}
 
 	if tx == nil {
-		return nil
+		return nil, api.txPrunedErr()
 	}
 
 	if idx == nil {
@@ -180,7 +187,19 @@ func (api *BlockchainApi) TxReceipt(hash common.Hash) *TxReceipt {
 
 	receipt := api.bc.GetReceipt(hash)
 
-	return convertReceipt(tx, receipt, feePerGas)
+	return convertReceipt(tx, receipt, feePerGas), nil
+}
+
+func (api *BlockchainApi) blockPrunedErr(height uint64) error {
+	return errors.Errorf("block %v is pruned, the node keeps blocks since %v", height, api.bc.PrunedHorizon())
+}
+
+// txPrunedErr is returned for unknown transactions when the node is pruned, since their indexes may be dropped
+func (api *BlockchainApi) txPrunedErr() error {
+	if api.bc.PrunedHorizon() == 0 {
+		return nil
+	}
+	return errors.Errorf("transaction is not found, it may be pruned, the node keeps transactions since block %v", api.bc.PrunedHorizon())
 }
 
 func (api *BlockchainApi) Mempool() []common.Hash {
@@ -195,12 +214,13 @@ func (api *BlockchainApi) Mempool() []common.Hash {
 }
 
 type Syncing struct {
-	Syncing      bool   `json:"syncing"`
-	CurrentBlock uint64 `json:"currentBlock"`
-	HighestBlock uint64 `json:"highestBlock"`
-	WrongTime    bool   `json:"wrongTime"`
-	GenesisBlock uint64 `json:"genesisBlock"`
-	Message      string `json:"message"`
+	Syncing       bool   `json:"syncing"`
+	CurrentBlock  uint64 `json:"currentBlock"`
+	HighestBlock  uint64 `json:"highestBlock"`
+	WrongTime     bool   `json:"wrongTime"`
+	GenesisBlock  uint64 `json:"genesisBlock"`
+	Message       string `json:"message"`
+	PrunedHorizon uint64 `json:"prunedHorizon"`
 }
 
 func (api *BlockchainApi) Syncing() Syncing {
@@ -214,12 +234,13 @@ func (api *BlockchainApi) Syncing() Syncing {
 		highest = current
 	}
 	return Syncing{
-		Syncing:      isSyncing,
-		GenesisBlock: api.bc.GenesisInfo().Genesis.Height(),
-		CurrentBlock: current,
-		HighestBlock: highest,
-		WrongTime:    api.pm.WrongTime(),
-		Message:      api.nodeState.Info(),
+		Syncing:       isSyncing,
+		GenesisBlock:  api.bc.GenesisInfo().Genesis.Height(),
+		CurrentBlock:  current,
+		HighestBlock:  highest,
+		WrongTime:     api.pm.WrongTime(),
+		Message:       api.nodeState.Info(),
+		PrunedHorizon: api.bc.PrunedHorizon(),
 	}
 }
 
@@ -406,6 +427,147 @@ func (api *BlockchainApi) BurntCoins() []BurntCoins {
 	return res
 }
 
+type StateDiffArgs struct {
+	From  uint64 `json:"from"`
+	To    uint64 `json:"to"`
+	Limit int    `json:"limit"`
+}
+
+type StateDiff struct {
+	Accounts       []AccountDiff       `json:"accounts"`
+	Identities     []IdentityDiff      `json:"identities"`
+	ContractValues []ContractValueDiff `json:"contractValues"`
+	Truncated      bool                `json:"truncated"`
+}
+
+type AccountDiff struct {
+	Address common.Address `json:"address"`
+	Old     *AccountState  `json:"old"`
+	New     *AccountState  `json:"new"`
+}
+
+type AccountState struct {
+	Balance       decimal.Decimal  `json:"balance"`
+	Nonce         uint32           `json:"nonce"`
+	Epoch         uint16           `json:"epoch"`
+	CodeHash      *common.Hash     `json:"codeHash,omitempty"`
+	ContractStake *decimal.Decimal `json:"contractStake,omitempty"`
+}
+
+type IdentityDiff struct {
+	Address common.Address      `json:"address"`
+	Old     *IdentityStateValue `json:"old"`
+	New     *IdentityStateValue `json:"new"`
+}
+
+type IdentityStateValue struct {
+	State         string           `json:"state"`
+	Stake         decimal.Decimal  `json:"stake"`
+	Birthday      uint16           `json:"birthday"`
+	Invites       uint8            `json:"invites"`
+	Generation    uint32           `json:"generation"`
+	Penalty       decimal.Decimal  `json:"penalty"`
+	MadeFlips     int              `json:"madeFlips"`
+	RequiredFlips uint8            `json:"requiredFlips"`
+	Delegatee     *common.Address  `json:"delegatee"`
+	Inviter       *common.Address  `json:"inviter"`
+	Invitees      []common.Address `json:"invitees"`
+}
+
+type ContractValueDiff struct {
+	Contract common.Address `json:"contract"`
+	Key      hexutil.Bytes  `json:"key"`
+	Old      hexutil.Bytes  `json:"old"`
+	New      hexutil.Bytes  `json:"new"`
+}
+
+const maxStateDiffLimit = 10000
+
+// StateDiff returns accounts, identities and contract values which differ between two retained state versions,
+// changes above the limit are omitted and the result is marked as truncated
+func (api *BlockchainApi) StateDiff(args StateDiffArgs) (*StateDiff, error) {
+	limit := args.Limit
+	if limit <= 0 || limit > maxStateDiffLimit {
+		limit = maxStateDiffLimit
+	}
+	diff, err := api.baseApi.getReadonlyAppState().State.DiffVersions(int64(args.From), int64(args.To), limit)
+	if err != nil {
+		return nil, err
+	}
+	res := &StateDiff{
+		Accounts:       make([]AccountDiff, 0, len(diff.Accounts)),
+		Identities:     make([]IdentityDiff, 0, len(diff.Identities)),
+		ContractValues: make([]ContractValueDiff, 0, len(diff.ContractValues)),
+		Truncated:      diff.Truncated,
+	}
+	for _, item := range diff.Accounts {
+		res.Accounts = append(res.Accounts, AccountDiff{
+			Address: item.Address,
+			Old:     convertAccountState(item.Old),
+			New:     convertAccountState(item.New),
+		})
+	}
+	for _, item := range diff.Identities {
+		res.Identities = append(res.Identities, IdentityDiff{
+			Address: item.Address,
+			Old:     convertIdentityStateValue(item.Old),
+			New:     convertIdentityStateValue(item.New),
+		})
+	}
+	for _, item := range diff.ContractValues {
+		res.ContractValues = append(res.ContractValues, ContractValueDiff{
+			Contract: item.Contract,
+			Key:      item.Key,
+			Old:      item.Old,
+			New:      item.New,
+		})
+	}
+	return res, nil
+}
+
+func convertAccountState(account *state2.Account) *AccountState {
+	if account == nil {
+		return nil
+	}
+	res := &AccountState{
+		Balance: blockchain.ConvertToFloat(account.Balance),
+		Nonce:   account.Nonce,
+		Epoch:   account.Epoch,
+	}
+	if account.Contract != nil {
+		codeHash := account.Contract.CodeHash
+		stake := blockchain.ConvertToFloat(account.Contract.Stake)
+		res.CodeHash = &codeHash
+		res.ContractStake = &stake
+	}
+	return res
+}
+
+func convertIdentityStateValue(identity *state2.Identity) *IdentityStateValue {
+	if identity == nil {
+		return nil
+	}
+	res := &IdentityStateValue{
+		State:         identity.State.String(),
+		Stake:         blockchain.ConvertToFloat(identity.Stake),
+		Birthday:      identity.Birthday,
+		Invites:       identity.Invites,
+		Generation:    identity.Generation,
+		Penalty:       blockchain.ConvertToFloat(identity.Penalty),
+		MadeFlips:     len(identity.Flips),
+		RequiredFlips: identity.RequiredFlips,
+		Delegatee:     identity.Delegatee(),
+	}
+	if identity.Inviter != nil {
+		inviter := identity.Inviter.Address
+		res.Inviter = &inviter
+	}
+	for _, invitee := range identity.Invitees {
+		res.Invitees = append(res.Invitees, invitee.Address)
+	}
+	return res
+}
+
 func convertToTransaction(tx *types.Transaction, blockHash common.Hash, feePerGas *big.Int, timestamp int64) *Transaction {
 	sender, _ := types.Sender(tx)
 	return &Transaction{
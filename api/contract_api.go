@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"github.com/idena-network/idena-go/blockchain"
 	"github.com/idena-network/idena-go/blockchain/attachments"
@@ -9,14 +10,17 @@ import (
 	"github.com/idena-network/idena-go/blockchain/validation"
 	"github.com/idena-network/idena-go/common"
 	"github.com/idena-network/idena-go/common/hexutil"
+	"github.com/idena-network/idena-go/core/state"
 	"github.com/idena-network/idena-go/deferredtx"
 	"github.com/idena-network/idena-go/subscriptions"
 	"github.com/idena-network/idena-go/vm"
+	"github.com/idena-network/idena-go/vm/embedded"
 	"github.com/idena-network/idena-go/vm/env"
 	"github.com/idena-network/idena-go/vm/helpers"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"math/big"
+	"sort"
 	"strconv"
 )
 
@@ -196,6 +200,23 @@ type IterateMapResponse struct {
 	ContinuationToken *hexutil.Bytes `json:"continuationToken"`
 }
 
+type ContractDump struct {
+	Contract common.Address  `json:"contract"`
+	CodeHash common.Hash     `json:"codeHash"`
+	Stake    decimal.Decimal `json:"stake"`
+	Items    []*StorageItem  `json:"items"`
+}
+
+// StorageItem is a decoded item of the contract storage, Key is the name of the key or the map for known items
+// and hex of the raw key for unknown ones
+type StorageItem struct {
+	Key    string        `json:"key"`
+	MapKey interface{}   `json:"mapKey,omitempty"`
+	RawKey hexutil.Bytes `json:"rawKey"`
+	Value  interface{}   `json:"value"`
+	Known  bool          `json:"known"`
+}
+
 func (api *ContractApi) buildDeployContractTx(args DeployArgs, estimate bool) (*types.Transaction, error) {
 	var codeHash common.Hash
 	codeHash.SetBytes(args.CodeHash)
@@ -516,12 +537,73 @@ func (api *ContractApi) IterateMap(contract common.Address, mapName string, cont
 	}, nil
 }
 
+func (api *ContractApi) Dump(contract common.Address) (*ContractDump, error) {
+	return DumpContract(api.baseApi.getReadonlyAppState().State, contract)
+}
+
+// DumpContract enumerates the contract storage and decodes keys and values which are known by the layout
+// of the embedded contract
+func DumpContract(stateDb *state.StateDB, contract common.Address) (*ContractDump, error) {
+	codeHash := stateDb.GetCodeHash(contract)
+	if codeHash == nil {
+		return nil, errors.New("contract is not found")
+	}
+	dump := &ContractDump{
+		Contract: contract,
+		CodeHash: *codeHash,
+		Stake:    blockchain.ConvertToFloat(stateDb.GetContractStake(contract)),
+		Items:    []*StorageItem{},
+	}
+	layout := embedded.StorageLayout(*codeHash)
+	stateDb.IterateContractStore(contract, nil, nil, func(key []byte, value []byte) bool {
+		dump.Items = append(dump.Items, decodeStorageItem(layout, key, value))
+		return false
+	})
+	// items of the cache are iterated before the tree ones
+	sort.Slice(dump.Items, func(i, j int) bool {
+		return bytes.Compare(dump.Items[i].RawKey, dump.Items[j].RawKey) < 0
+	})
+	return dump, nil
+}
+
+func decodeStorageItem(layout []embedded.StorageField, key []byte, value []byte) *StorageItem {
+	item := &StorageItem{
+		Key:    hexutil.Encode(key),
+		RawKey: common.CopyBytes(key),
+		Value:  hexutil.Encode(value),
+	}
+	field, mapKey := embedded.FindStorageField(layout, key)
+	if field == nil {
+		return item
+	}
+	decodedValue, err := conversion(field.Format, value)
+	if err != nil {
+		return item
+	}
+	if field.Map {
+		decodedKey, err := conversion(field.KeyFormat, mapKey)
+		if err != nil {
+			return item
+		}
+		item.MapKey = decodedKey
+	}
+	item.Key, item.Value, item.Known = field.Name, decodedValue, true
+	return item
+}
+
 func conversion(convertTo string, data []byte) (interface{}, error) {
 	switch convertTo {
 	case "byte":
 		return helpers.ExtractByte(0, data)
+	case "uint16":
+		return helpers.ExtractUInt16(0, data)
 	case "uint64":
 		return helpers.ExtractUInt64(0, data)
+	case "address":
+		if len(data) != common.AddressLength {
+			return nil, errors.New("invalid address length")
+		}
+		return common.BytesToAddress(data).Hex(), nil
 	case "string":
 		return string(data), nil
 	case "bigint":
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/consensus"
+	"github.com/idena-network/idena-go/crypto"
+)
+
+// DebugApi offers node diagnostics
+type DebugApi struct {
+	engine *consensus.Engine
+}
+
+// NewDebugApi creates a new DebugApi instance
+func NewDebugApi(engine *consensus.Engine) *DebugApi {
+	return &DebugApi{engine}
+}
+
+type ConsensusRound struct {
+	Round          uint64                `json:"round"`
+	Start          int64                 `json:"start"`
+	Finish         int64                 `json:"finish"`
+	Duration       int64                 `json:"duration"`
+	IsProposer     bool                  `json:"isProposer"`
+	ProposerProofs int                   `json:"proposerProofs"`
+	Proposer       *common.Address       `json:"proposer"`
+	ProposedBlock  *common.Hash          `json:"proposedBlock"`
+	ReductionBlock common.Hash           `json:"reductionBlock"`
+	Block          *common.Hash          `json:"block"`
+	Result         string                `json:"result"`
+	Error          string                `json:"error,omitempty"`
+	Phases         []ConsensusRoundPhase `json:"phases"`
+	Steps          []ConsensusRoundStep  `json:"steps"`
+}
+
+type ConsensusRoundPhase struct {
+	Name     string `json:"name"`
+	Duration int64  `json:"duration"`
+}
+
+type ConsensusRoundStep struct {
+	Step               uint8               `json:"step"`
+	Votes              []ConsensusStepVote `json:"votes"`
+	CheckedVotes       int                 `json:"checkedVotes"`
+	NecessaryVotes     int                 `json:"necessaryVotes"`
+	ConsensusBlockHash *common.Hash        `json:"consensusBlockHash"`
+	Error              string              `json:"error,omitempty"`
+	Duration           int64               `json:"duration"`
+}
+
+type ConsensusStepVote struct {
+	Block common.Hash `json:"block"`
+	Count int         `json:"count"`
+}
+
+// ConsensusRounds returns data of up to count last completed consensus rounds (all stored rounds if count is 0),
+// durations are in milliseconds
+func (api *DebugApi) ConsensusRounds(count int) []ConsensusRound {
+	rounds := api.engine.GetRounds(count)
+	result := make([]ConsensusRound, 0, len(rounds))
+	for _, round := range rounds {
+		result = append(result, convertConsensusRound(round))
+	}
+	return result
+}
+
+// CurrentConsensusRound returns data collected so far for the round in progress
+func (api *DebugApi) CurrentConsensusRound() *ConsensusRound {
+	round := api.engine.GetCurrentRound()
+	if round == nil {
+		return nil
+	}
+	res := convertConsensusRound(round)
+	return &res
+}
+
+func convertConsensusRound(round *consensus.RoundInfo) ConsensusRound {
+	res := ConsensusRound{
+		Round:          round.Round,
+		Start:          round.Start.Unix(),
+		IsProposer:     round.IsProposer,
+		ProposerProofs: round.ProposerProofs,
+		ReductionBlock: round.ReductionBlock,
+		Result:         round.Result,
+		Error:          round.Err,
+		Phases:         make([]ConsensusRoundPhase, 0, len(round.Phases)),
+		Steps:          make([]ConsensusRoundStep, 0, len(round.Steps)),
+	}
+	if !round.Finish.IsZero() {
+		res.Finish = round.Finish.Unix()
+		res.Duration = round.Finish.Sub(round.Start).Milliseconds()
+	}
+	if len(round.ProposerPubKey) > 0 {
+		if addr, err := crypto.PubKeyBytesToAddress(round.ProposerPubKey); err == nil {
+			res.Proposer = &addr
+		}
+	}
+	if round.ProposedBlock != (common.Hash{}) {
+		hash := round.ProposedBlock
+		res.ProposedBlock = &hash
+	}
+	if round.Block != (common.Hash{}) {
+		hash := round.Block
+		res.Block = &hash
+	}
+	for _, phase := range round.Phases {
+		res.Phases = append(res.Phases, ConsensusRoundPhase{
+			Name:     phase.Name,
+			Duration: phase.Duration.Milliseconds(),
+		})
+	}
+	for _, step := range round.Steps {
+		s := ConsensusRoundStep{
+			Step:           step.Step,
+			Votes:          make([]ConsensusStepVote, 0, len(step.Votes)),
+			CheckedVotes:   step.CheckedVotes,
+			NecessaryVotes: step.NecessaryVotes,
+			Error:          step.Err,
+			Duration:       step.Duration.Milliseconds(),
+		}
+		for block, count := range step.Votes {
+			s.Votes = append(s.Votes, ConsensusStepVote{
+				Block: block,
+				Count: count,
+			})
+		}
+		if step.ConsensusBlockHash != (common.Hash{}) {
+			hash := step.ConsensusBlockHash
+			s.ConsensusBlockHash = &hash
+		}
+		res.Steps = append(res.Steps, s)
+	}
+	return res
+}
@@ -15,26 +15,35 @@ import (
 	"github.com/idena-network/idena-go/common/hexutil"
 	"github.com/idena-network/idena-go/core/appstate"
 	"github.com/idena-network/idena-go/core/ceremony"
+	"github.com/idena-network/idena-go/core/mempool"
 	"github.com/idena-network/idena-go/core/profile"
 	"github.com/idena-network/idena-go/core/state"
 	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/protocol"
 	"github.com/ipfs/go-cid"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	"math/big"
 	"time"
 )
 
+const maxInvitesPerRequest = 100
+
 type DnaApi struct {
 	bc             *blockchain.Blockchain
 	baseApi        *BaseApi
 	ceremony       *ceremony.ValidationCeremony
 	appVersion     string
 	profileManager *profile.Manager
+	pm             *protocol.IdenaGossipHandler
+	downloader     *protocol.Downloader
+	keysPool       *mempool.KeysPool
 }
 
 func NewDnaApi(baseApi *BaseApi, bc *blockchain.Blockchain, ceremony *ceremony.ValidationCeremony, appVersion string,
-	profileManager *profile.Manager) *DnaApi {
-	return &DnaApi{bc, baseApi, ceremony, appVersion, profileManager}
+	profileManager *profile.Manager, pm *protocol.IdenaGossipHandler, downloader *protocol.Downloader, keysPool *mempool.KeysPool) *DnaApi {
+	return &DnaApi{bc, baseApi, ceremony, appVersion, profileManager, pm, downloader, keysPool}
 }
 
 type State struct {
@@ -124,7 +133,10 @@ type Invite struct {
 }
 
 func (api *DnaApi) SendInvite(ctx context.Context, args SendInviteArgs) (Invite, error) {
-	receiver := args.To
+	return api.sendInvite(ctx, args.To, args.Amount, args.Nonce, args.Epoch)
+}
+
+func (api *DnaApi) sendInvite(ctx context.Context, receiver common.Address, amount decimal.Decimal, nonce uint32, epoch uint16) (Invite, error) {
 	var key *ecdsa.PrivateKey
 
 	if receiver == (common.Address{}) {
@@ -132,16 +144,30 @@ func (api *DnaApi) SendInvite(ctx context.Context, args SendInviteArgs) (Invite,
 		receiver = crypto.PubkeyToAddress(key.PublicKey)
 	}
 
-	hash, err := api.baseApi.sendTx(ctx, api.baseApi.getCurrentCoinbase(), &receiver, types.InviteTx, args.Amount, decimal.Zero, decimal.Zero, args.Nonce, args.Epoch, nil, nil)
+	inviter := api.baseApi.getCurrentCoinbase()
+	hash, err := api.baseApi.sendTx(ctx, inviter, &receiver, types.InviteTx, amount, decimal.Zero, decimal.Zero, nonce, epoch, nil, nil)
 
 	if err != nil {
 		return Invite{}, err
 	}
 
 	var stringKey string
+	var keyBytes []byte
 	if key != nil {
-		stringKey = hex.EncodeToString(crypto.FromECDSA(key))
-	}
+		keyBytes = crypto.FromECDSA(key)
+		stringKey = hex.EncodeToString(keyBytes)
+	}
+
+	api.bc.Repo().WriteOwnInvite(&types.OwnInvite{
+		Inviter:   inviter,
+		Receiver:  receiver,
+		Key:       keyBytes,
+		TxHash:    hash,
+		Epoch:     api.baseApi.getReadonlyAppState().State.Epoch(),
+		Amount:    blockchain.ConvertToInt(amount),
+		Timestamp: time.Now().UTC().Unix(),
+		Status:    types.OwnInvitePending,
+	})
 
 	return Invite{
 		Receiver: receiver,
@@ -150,6 +176,188 @@ func (api *DnaApi) SendInvite(ctx context.Context, args SendInviteArgs) (Invite,
 	}, nil
 }
 
+// partialResultError is returned by the methods which have sent some txs before the error,
+// the sent part of the result is passed in the data of the RPC error
+type partialResultError struct {
+	err    error
+	result interface{}
+}
+
+func (e *partialResultError) Error() string {
+	return e.err.Error()
+}
+
+func (e *partialResultError) ErrorData() interface{} {
+	return e.result
+}
+
+type SendInvitesArgs struct {
+	Count  uint32          `json:"count"`
+	Amount decimal.Decimal `json:"amount"`
+}
+
+// SendInvites sends the given number of invites to newly generated addresses.
+// Invites which have been sent before an error are returned together with the error.
+func (api *DnaApi) SendInvites(ctx context.Context, args SendInvitesArgs) ([]Invite, error) {
+	if args.Count == 0 || args.Count > maxInvitesPerRequest {
+		return nil, errors.Errorf("count should be in range [1, %v]", maxInvitesPerRequest)
+	}
+	result := make([]Invite, 0, args.Count)
+	for i := uint32(0); i < args.Count; i++ {
+		invite, err := api.sendInvite(ctx, common.Address{}, args.Amount, 0, 0)
+		if err != nil {
+			return result, &partialResultError{errors.Wrapf(err, "%v of %v invites sent", len(result), args.Count), result}
+		}
+		result = append(result, invite)
+	}
+	return result, nil
+}
+
+type OwnInvite struct {
+	Inviter    common.Address  `json:"inviter"`
+	Receiver   common.Address  `json:"receiver"`
+	Key        string          `json:"key"`
+	TxHash     common.Hash     `json:"txHash"`
+	Epoch      uint16          `json:"epoch"`
+	Amount     decimal.Decimal `json:"amount"`
+	Timestamp  int64           `json:"timestamp"`
+	Status     string          `json:"status"`
+	KillTxHash *common.Hash    `json:"killTxHash,omitempty"`
+	// address of the identity which has activated the invite
+	Activated *common.Address `json:"activated,omitempty"`
+}
+
+// Invites returns invites sent by the node with their current statuses
+func (api *DnaApi) Invites() []OwnInvite {
+	appState := api.baseApi.getReadonlyAppState()
+	var result []OwnInvite
+	for _, invite := range api.bc.Repo().GetOwnInvites() {
+		status, activated := api.inviteStatus(invite, appState)
+		var key string
+		if len(invite.Key) > 0 {
+			key = hex.EncodeToString(invite.Key)
+		}
+		result = append(result, OwnInvite{
+			Inviter:    invite.Inviter,
+			Receiver:   invite.Receiver,
+			Key:        key,
+			TxHash:     invite.TxHash,
+			Epoch:      invite.Epoch,
+			Amount:     blockchain.ConvertToFloat(invite.Amount),
+			Timestamp:  invite.Timestamp,
+			Status:     convertOwnInviteStatus(status),
+			KillTxHash: invite.KillTxHash,
+			Activated:  activated,
+		})
+	}
+	return result
+}
+
+// inviteStatus returns status of the invite by the current state, final statuses are stored by the blockchain indexer.
+// Address of the activated identity is returned if it's known.
+func (api *DnaApi) inviteStatus(invite *types.OwnInvite, appState *appstate.AppState) (types.OwnInviteStatus, *common.Address) {
+	activated := invite.Activated
+	if activated == nil {
+		for _, invitee := range appState.State.GetIdentity(invite.Inviter).Invitees {
+			if invitee.TxHash == invite.TxHash {
+				addr := invitee.Address
+				activated = &addr
+				break
+			}
+		}
+	}
+	if types.IsFinalOwnInviteStatus(invite.Status) {
+		return invite.Status, activated
+	}
+	if invite.KillTxHash != nil && api.bc.GetTxIndex(*invite.KillTxHash) != nil {
+		return types.OwnInviteKilled, activated
+	}
+	if activated != nil {
+		return types.OwnInviteActivated, activated
+	}
+	switch appState.State.GetIdentityState(invite.Receiver) {
+	case state.Invite:
+		return types.OwnInviteSent, nil
+	case state.Killed:
+		// activation is neither indexed nor linked with the inviter
+		return types.OwnInviteUnknown, nil
+	case state.Undefined:
+		switch {
+		case api.baseApi.txpool.GetTx(invite.TxHash) != nil:
+			return types.OwnInvitePending, nil
+		case appState.State.Epoch() > invite.Epoch:
+			return types.OwnInviteExpired, nil
+		case api.bc.GetTxIndex(invite.TxHash) == nil:
+			return types.OwnInviteFailed, nil
+		}
+	}
+	return invite.Status, nil
+}
+
+func convertOwnInviteStatus(status types.OwnInviteStatus) string {
+	switch status {
+	case types.OwnInvitePending:
+		return "Pending"
+	case types.OwnInviteSent:
+		return "Sent"
+	case types.OwnInviteActivated:
+		return "Activated"
+	case types.OwnInviteKilled:
+		return "Killed"
+	case types.OwnInviteExpired:
+		return "Expired"
+	case types.OwnInviteFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+type KillInviteesArgs struct {
+	Addresses []common.Address `json:"addresses"`
+}
+
+// KillInvitees sends KillInviteeTx for every address, the addresses can be both invites and activated invitees.
+// Hashes of txs which have been sent before an error are returned together with the error.
+func (api *DnaApi) KillInvitees(ctx context.Context, args KillInviteesArgs) ([]common.Hash, error) {
+	if len(args.Addresses) == 0 || len(args.Addresses) > maxInvitesPerRequest {
+		return nil, errors.Errorf("number of addresses should be in range [1, %v]", maxInvitesPerRequest)
+	}
+	appState := api.baseApi.getReadonlyAppState()
+	repo := api.bc.Repo()
+	from := api.baseApi.getCurrentCoinbase()
+	result := make([]common.Hash, 0, len(args.Addresses))
+	for _, addr := range args.Addresses {
+		to := addr
+		hash, err := api.baseApi.sendTx(ctx, from, &to, types.KillInviteeTx, decimal.Zero, decimal.Zero, decimal.Zero, 0, 0, nil, nil)
+		if err != nil {
+			return result, &partialResultError{errors.Wrapf(err, "failed to kill %v, %v of %v invitees killed", addr.Hex(), len(result), len(args.Addresses)), result}
+		}
+		result = append(result, hash)
+
+		invite := repo.ReadOwnInvite(addr)
+		if invite == nil {
+			if inviter := appState.State.GetInviter(addr); inviter != nil {
+				invite = findOwnInviteByTx(repo, inviter.TxHash)
+			}
+		}
+		if invite != nil {
+			invite.KillTxHash = &hash
+			repo.WriteOwnInvite(invite)
+		}
+	}
+	return result, nil
+}
+
+func findOwnInviteByTx(repo *database.Repo, txHash common.Hash) *types.OwnInvite {
+	for _, invite := range repo.GetOwnInvites() {
+		if invite.TxHash == txHash {
+			return invite
+		}
+	}
+	return nil
+}
+
 func (api *DnaApi) ActivateInvite(ctx context.Context, args ActivateInviteArgs) (common.Hash, error) {
 	var key *ecdsa.PrivateKey
 	from := api.baseApi.getCurrentCoinbase()
@@ -358,27 +566,7 @@ func (api *DnaApi) Identity(address *common.Address) Identity {
 }
 
 func convertIdentity(currentEpoch uint16, address common.Address, data state.Identity, flipKeyWordPairs []int, appState *appstate.AppState) Identity {
-	var s string
-	switch data.State {
-	case state.Invite:
-		s = "Invite"
-	case state.Candidate:
-		s = "Candidate"
-	case state.Newbie:
-		s = "Newbie"
-	case state.Verified:
-		s = "Verified"
-	case state.Suspended:
-		s = "Suspended"
-	case state.Zombie:
-		s = "Zombie"
-	case state.Killed:
-		s = "Killed"
-	case state.Human:
-		s = "Human"
-	default:
-		s = "Undefined"
-	}
+	s := data.State.String()
 
 	var flags []string
 	if data.LastValidationStatus.HasFlag(state.AllFlipsNotQualified) {
@@ -537,6 +725,144 @@ func (api *DnaApi) Epoch() Epoch {
 	}
 }
 
+type ReadinessCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Pending bool   `json:"pending"`
+	Message string `json:"message"`
+}
+
+// CeremonyReadiness checks whether the node is ready to take part in the upcoming validation ceremony
+func (api *DnaApi) CeremonyReadiness() []ReadinessCheck {
+	var result []ReadinessCheck
+	check := func(name string, passed bool, message string) {
+		result = append(result, ReadinessCheck{Name: name, Passed: passed, Message: message})
+	}
+	// pending checks can't be passed yet, they depend on the next steps of the ceremony
+	pending := func(name string, message string) {
+		result = append(result, ReadinessCheck{Name: name, Pending: true, Message: message})
+	}
+
+	if api.downloader.IsSyncing() {
+		head, top := api.downloader.SyncProgress()
+		check("sync", false, fmt.Sprintf("Node is synchronizing (%v of %v blocks), wait until it is synchronized", head, top))
+	} else {
+		check("sync", true, "Node is synchronized")
+	}
+
+	if peers, required := api.pm.OwnShardConnections(); peers < required {
+		check("peers", false, fmt.Sprintf("Node has %v peers from its shard, at least %v required, check network connectivity and firewall settings", peers, required))
+	} else {
+		check("peers", true, fmt.Sprintf("Node has %v peers from its shard", peers))
+	}
+
+	if api.pm.WrongTime() {
+		check("clock", false, "System clock seems to be off, enable network time synchronisation in system settings")
+	} else {
+		check("clock", true, "System clock is synchronized")
+	}
+
+	appState := api.baseApi.getReadonlyAppState()
+	coinbase := api.baseApi.getCurrentCoinbase()
+	identity := appState.State.GetIdentity(coinbase)
+	if !state.IsCeremonyCandidate(identity) {
+		check("identity", false, fmt.Sprintf("Identity %v with state %v is not allowed to take part in the validation, make sure the identity is validated and all required flips are submitted",
+			coinbase.Hex(), identity.State.String()))
+		return result
+	}
+	check("identity", true, fmt.Sprintf("Identity %v is a validation candidate", coinbase.Hex()))
+
+	api.checkOnlineReadiness(appState, coinbase, identity, check, pending)
+
+	period := appState.State.ValidationPeriod()
+	lotteryFinished := false
+	switch {
+	case period < state.FlipLotteryPeriod:
+		pending("flipLottery", fmt.Sprintf("Flip lottery has not started yet, the next validation is at %v", appState.State.NextValidationTime().UTC()))
+	case !api.ceremony.IsValidationReady():
+		pending("flipLottery", "Flip lottery is still being calculated, wait for it to finish")
+	default:
+		check("flipLottery", true, "Flip lottery is finished")
+		lotteryFinished = true
+	}
+
+	if len(identity.Flips) > 0 {
+		publicKey, privateKeysPackage := api.keysPool.HasFlipKeys(coinbase)
+		switch {
+		case period < state.FlipLotteryPeriod:
+			pending("flipKeys", "Flip keys are published since the flip lottery, keep the node online")
+		case !privateKeysPackage:
+			check("flipKeys", false, "Private flip keys package is not published yet, it is published during the flip lottery, keep the node online")
+		case !publicKey && api.ceremony.ShortSessionStarted():
+			check("flipKeys", false, "Public flip key is not published, make sure the node is synchronized and connected to peers")
+		default:
+			check("flipKeys", true, "Flip keys are published")
+		}
+	}
+
+	if !lotteryFinished {
+		pending("flips", "Flips to solve are known when the flip lottery is finished, keep the node online to load them")
+		return result
+	}
+	var flips, loaded int
+	shardId := identity.ShiftedShardId()
+	for _, cids := range [][][]byte{api.ceremony.GetShortFlipsToSolve(coinbase, shardId), api.ceremony.GetLongFlipsToSolve(coinbase, shardId)} {
+		for _, key := range cids {
+			flips++
+			if api.ceremony.IsFlipInMemory(key) {
+				loaded++
+			}
+		}
+	}
+	if loaded < flips {
+		check("flips", false, fmt.Sprintf("%v of %v flips to solve are loaded, check IPFS connectivity and keep the node online", loaded, flips))
+	} else {
+		check("flips", true, fmt.Sprintf("All %v flips to solve are loaded", flips))
+	}
+	return result
+}
+
+// checkOnlineReadiness reports whether the identity is online, taking into account the status switches which are
+// pending in the chain and in the mempool
+func (api *DnaApi) checkOnlineReadiness(appState *appstate.AppState, coinbase common.Address, identity state.Identity,
+	check func(name string, passed bool, message string), pending func(name string, message string)) {
+	if delegatee := identity.Delegatee(); delegatee != nil {
+		check("online", true, fmt.Sprintf("Identity is delegated to the pool %v, its own online status is not required", delegatee.Hex()))
+		return
+	}
+	isOnline := appState.ValidatorsCache.IsOnlineIdentity(coinbase)
+	if appState.State.HasDelayedOfflinePenalty(coinbase) {
+		check("online", false, "Identity is going offline because of the mining penalty, keep the node online and turn mining on again")
+		return
+	}
+	if appState.State.HasStatusSwitchAddresses(coinbase) {
+		if isOnline {
+			check("online", false, "Identity is going offline at the next status switch block, turn mining on again to stay online")
+		} else {
+			pending("online", "Identity becomes online at the next status switch block, keep the node online")
+		}
+		return
+	}
+	for _, tx := range api.baseApi.txpool.GetPendingByAddress(coinbase) {
+		if tx.Type != types.OnlineStatusTx {
+			continue
+		}
+		if attachment := attachments.ParseOnlineStatusAttachment(tx); attachment != nil && attachment.Online != isOnline {
+			if attachment.Online {
+				pending("online", fmt.Sprintf("Online status transaction %v is not mined yet, keep the node online and synchronized", tx.Hash().Hex()))
+			} else {
+				check("online", false, fmt.Sprintf("Offline status transaction %v is pending, turn mining on again to stay online", tx.Hash().Hex()))
+			}
+			return
+		}
+	}
+	if isOnline {
+		check("online", true, "Identity is online")
+	} else {
+		check("online", false, "Identity is offline, turn mining on (dna_becomeOnline) to be online during the validation")
+	}
+}
+
 type CeremonyIntervals struct {
 	FlipLotteryDuration  float64
 	ShortSessionDuration float64
@@ -833,3 +1159,181 @@ func (api *DnaApi) SendToIpfs(ctx context.Context, args SendToIpfsArgs) (common.
 	}
 	return txHash, nil
 }
+
+type ValidationReport struct {
+	Address               common.Address  `json:"address"`
+	Epoch                 uint16          `json:"epoch"`
+	ValidationFailed      bool            `json:"validationFailed"`
+	Candidate             bool            `json:"candidate"`
+	PrevState             string          `json:"prevState"`
+	NewState              string          `json:"newState"`
+	Approved              bool            `json:"approved"`
+	Missed                bool            `json:"missed"`
+	MissedReason          string          `json:"missedReason,omitempty"`
+	ShortFlipsToSolve     uint32          `json:"shortFlipsToSolve"`
+	LongFlipsToSolve      uint32          `json:"longFlipsToSolve"`
+	ShortPoints           float32         `json:"shortPoints"`
+	ShortQualifiedFlips   uint32          `json:"shortQualifiedFlips"`
+	ShortScore            float32         `json:"shortScore"`
+	LongPoints            float32         `json:"longPoints"`
+	LongQualifiedFlips    uint32          `json:"longQualifiedFlips"`
+	LongScore             float32         `json:"longScore"`
+	TotalScore            float32         `json:"totalScore"`
+	TotalQualifiedFlips   uint32          `json:"totalQualifiedFlips"`
+	NoQualifiedShortFlips bool            `json:"noQualifiedShortFlips"`
+	NoQualifiedLongFlips  bool            `json:"noQualifiedLongFlips"`
+	BadAuthor             bool            `json:"badAuthor"`
+	BadAuthorReason       string          `json:"badAuthorReason,omitempty"`
+	RewardedFlips         uint32          `json:"rewardedFlips"`
+	RewardedReports       uint32          `json:"rewardedReports"`
+	Reward                decimal.Decimal `json:"reward"`
+	Penalty               decimal.Decimal `json:"penalty"`
+}
+
+// ValidationReport explains validation result of the identity, the last finished epoch is used by default
+func (api *DnaApi) ValidationReport(address common.Address, epoch *uint16) (*ValidationReport, error) {
+	var e uint16
+	if epoch != nil {
+		e = *epoch
+	} else {
+		currentEpoch := api.baseApi.getReadonlyAppState().State.Epoch()
+		if currentEpoch == 0 {
+			return nil, errors.New("there are no finished epochs")
+		}
+		e = currentEpoch - 1
+	}
+	report := api.ceremony.GetValidationReport(address, e)
+	if report == nil {
+		return nil, errors.Errorf("validation report for epoch %v is not found", e)
+	}
+	res := &ValidationReport{
+		Address:               address,
+		Epoch:                 report.Epoch,
+		ValidationFailed:      report.Failed,
+		Candidate:             report.Candidate,
+		PrevState:             state.IdentityState(report.PrevState).String(),
+		NewState:              state.IdentityState(report.NewState).String(),
+		Approved:              report.Approved,
+		Missed:                report.Missed,
+		MissedReason:          convertMissedReason(report.MissedReason),
+		ShortFlipsToSolve:     report.ShortFlipsToSolve,
+		LongFlipsToSolve:      report.LongFlipsToSolve,
+		ShortPoints:           report.ShortPoint,
+		ShortQualifiedFlips:   report.ShortQualifiedFlips,
+		ShortScore:            report.ShortScore,
+		LongPoints:            report.LongPoint,
+		LongQualifiedFlips:    report.LongQualifiedFlips,
+		LongScore:             report.LongScore,
+		TotalScore:            report.TotalScore,
+		TotalQualifiedFlips:   report.TotalQualifiedFlips,
+		NoQualifiedShortFlips: report.NoQualifiedShortFlips,
+		NoQualifiedLongFlips:  report.NoQualifiedLongFlips,
+		BadAuthor:             report.BadAuthor,
+		RewardedFlips:         report.RewardedFlips,
+		RewardedReports:       report.RewardedReports,
+		Reward:                blockchain.ConvertToFloat(report.Reward),
+		Penalty:               blockchain.ConvertToFloat(report.Penalty),
+	}
+	if report.BadAuthor {
+		res.BadAuthorReason = convertBadAuthorReason(report.BadAuthorReason)
+	}
+	return res, nil
+}
+
+type RewardEstimate struct {
+	Balance decimal.Decimal `json:"balance"`
+	Stake   decimal.Decimal `json:"stake"`
+}
+
+type RewardPools struct {
+	Total       decimal.Decimal `json:"total"`
+	Validation  decimal.Decimal `json:"validation"`
+	Staking     decimal.Decimal `json:"staking"`
+	Candidate   decimal.Decimal `json:"candidate"`
+	Flips       decimal.Decimal `json:"flips"`
+	Reports     decimal.Decimal `json:"reports"`
+	Invitations decimal.Decimal `json:"invitations"`
+	Foundation  decimal.Decimal `json:"foundation"`
+	ZeroWallet  decimal.Decimal `json:"zeroWallet"`
+}
+
+type RewardsEstimate struct {
+	Address       common.Address  `json:"address"`
+	State         string          `json:"state"`
+	Epoch         uint16          `json:"epoch"`
+	EpochDuration uint32          `json:"epochDuration"`
+	Staking       *RewardEstimate `json:"staking"`
+	Candidate     *RewardEstimate `json:"candidate"`
+	Flips         *RewardEstimate `json:"flips"`
+	Reports       *RewardEstimate `json:"reports"`
+	Invitations   *RewardEstimate `json:"invitations"`
+	Total         decimal.Decimal `json:"total"`
+	Pools         *RewardPools    `json:"pools"`
+}
+
+// EstimateRewards calculates rewards of the identity for the current epoch assuming successful validation
+func (api *DnaApi) EstimateRewards(address common.Address) *RewardsEstimate {
+	appState := api.baseApi.getAppStateForCheck()
+	res := &RewardsEstimate{
+		Address: address,
+		State:   appState.State.GetIdentityState(address).String(),
+		Epoch:   appState.State.Epoch(),
+	}
+	estimate := blockchain.EstimateRewards(appState, api.bc.Config().Consensus, address, api.bc.Head.Height(), time.Now())
+	total := big.NewInt(0)
+	convertReward := func(reward *blockchain.RewardEstimate) *RewardEstimate {
+		total.Add(total, reward.Balance)
+		total.Add(total, reward.Stake)
+		return &RewardEstimate{
+			Balance: blockchain.ConvertToFloat(reward.Balance),
+			Stake:   blockchain.ConvertToFloat(reward.Stake),
+		}
+	}
+	res.EpochDuration = estimate.EpochDuration
+	res.Staking = convertReward(estimate.Staking)
+	res.Candidate = convertReward(estimate.Candidate)
+	res.Flips = convertReward(estimate.Flips)
+	res.Reports = convertReward(estimate.Reports)
+	res.Invitations = convertReward(estimate.Invitations)
+	res.Total = blockchain.ConvertToFloat(total)
+	res.Pools = &RewardPools{
+		Total:       blockchain.ConvertToFloat(estimate.Pools.Total),
+		Validation:  blockchain.ConvertToFloat(estimate.Pools.Validation),
+		Staking:     blockchain.ConvertToFloat(estimate.Pools.Staking),
+		Candidate:   blockchain.ConvertToFloat(estimate.Pools.Candidate),
+		Flips:       blockchain.ConvertToFloat(estimate.Pools.Flips),
+		Reports:     blockchain.ConvertToFloat(estimate.Pools.Reports),
+		Invitations: blockchain.ConvertToFloat(estimate.Pools.Invitations),
+		Foundation:  blockchain.ConvertToFloat(estimate.Pools.Foundation),
+		ZeroWallet:  blockchain.ConvertToFloat(estimate.Pools.ZeroWallet),
+	}
+	return res
+}
+
+func convertMissedReason(reason types.ValidationMissedReason) string {
+	switch reason {
+	case types.NotCandidateMissedReason:
+		return "NotCandidate"
+	case types.NotApprovedMissedReason:
+		return "NotApproved"
+	case types.NoShortAnswersMissedReason:
+		return "NoShortAnswers"
+	case types.NoLongAnswersMissedReason:
+		return "NoLongAnswers"
+	default:
+		return ""
+	}
+}
+
+func convertBadAuthorReason(reason types.BadAuthorReason) string {
+	switch reason {
+	case types.NoQualifiedFlipsBadAuthor:
+		return "NoQualifiedFlips"
+	case types.QualifiedByNoneBadAuthor:
+		return "QualifiedByNone"
+	case types.WrongWordsBadAuthor:
+		return "WrongWords"
+	default:
+		return "Unknown"
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/idena-network/idena-go/core/ceremony"
 	"github.com/idena-network/idena-go/core/flip"
 	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/deferredtx"
 	"github.com/idena-network/idena-go/ipfs"
 	"github.com/idena-network/idena-go/log"
 	"github.com/ipfs/go-cid"
@@ -19,20 +20,22 @@ import (
 )
 
 type FlipApi struct {
-	baseApi   *BaseApi
-	fp        *flip.Flipper
-	ipfsProxy ipfs.Proxy
-	ceremony  *ceremony.ValidationCeremony
+	baseApi     *BaseApi
+	fp          *flip.Flipper
+	ipfsProxy   ipfs.Proxy
+	ceremony    *ceremony.ValidationCeremony
+	deferredTxs *deferredtx.Job
 }
 
 // NewFlipApi creates a new FlipApi instance
-func NewFlipApi(baseApi *BaseApi, fp *flip.Flipper, ipfsProxy ipfs.Proxy, ceremony *ceremony.ValidationCeremony) *FlipApi {
-	return &FlipApi{baseApi, fp, ipfsProxy, ceremony}
+func NewFlipApi(baseApi *BaseApi, fp *flip.Flipper, ipfsProxy ipfs.Proxy, ceremony *ceremony.ValidationCeremony, deferredTxs *deferredtx.Job) *FlipApi {
+	return &FlipApi{baseApi, fp, ipfsProxy, ceremony, deferredTxs}
 }
 
 type FlipSubmitResponse struct {
 	TxHash common.Hash `json:"txHash"`
 	Hash   string      `json:"hash"`
+	Status string      `json:"status,omitempty"`
 }
 
 type FlipSubmitArgs struct {
@@ -40,6 +43,8 @@ type FlipSubmitArgs struct {
 	PublicHex  *hexutil.Bytes `json:"publicHex"`
 	PrivateHex *hexutil.Bytes `json:"privateHex"`
 	PairId     uint8          `json:"pairId"`
+	// Answer is the correct answer which is shared with other nodes of the test network to answer the flip automatically
+	Answer types.Answer `json:"answer"`
 }
 
 type RawFlipSubmitArgs struct {
@@ -94,38 +99,121 @@ func (api *FlipApi) Submit(args FlipSubmitArgs) (FlipSubmitResponse, error) {
 	if args.PrivateHex != nil {
 		rawPrivatePart = *args.PrivateHex
 	}
-	cid, encryptedPublicPart, encryptedPrivatePart, err := api.fp.PrepareFlip(rawPublicPart, rawPrivatePart)
-
-	if err != nil {
-		return FlipSubmitResponse{}, err
+	if args.Answer != types.None {
+		if args.Answer != types.Left && args.Answer != types.Right {
+			return FlipSubmitResponse{}, errors.New("answer should be left or right")
+		}
+		if !api.ceremony.KnownFlipAnswersEnabled() {
+			return FlipSubmitResponse{}, errors.New("answer is accepted only by nodes of the test network with known answers file")
+		}
 	}
-
-	addr := api.baseApi.getCurrentCoinbase()
-
-	tx, err := api.baseApi.getSignedTx(addr, nil, types.SubmitFlipTx, decimal.Zero, decimal.Zero, decimal.Zero, 0, 0, attachments.CreateFlipSubmitAttachment(cid.Bytes(), args.PairId), nil)
-
-	log.Info("Building new flip tx", "hash", tx.Hash().Hex(), "nonce", tx.AccountNonce, "epoch", tx.Epoch)
-
+	submission, err := api.fp.SubmitFlip(rawPublicPart, rawPrivatePart, args.PairId)
 	if err != nil {
 		return FlipSubmitResponse{}, err
 	}
+	if submission.Status == flip.SubmissionFailed {
+		return FlipSubmitResponse{}, errors.New(submission.LastError)
+	}
+	if args.Answer != types.None {
+		if err := api.ceremony.RegisterKnownFlipAnswer(submission.Cid, args.Answer); err != nil {
+			return FlipSubmitResponse{}, errors.Wrap(err, "flip is submitted, but its answer is not registered")
+		}
+	}
 
-	flip := &types.Flip{
-		Tx:          tx,
-		PublicPart:  encryptedPublicPart,
-		PrivatePart: encryptedPrivatePart,
+	c, _ := cid.Cast(submission.Cid)
+	res := FlipSubmitResponse{
+		Hash:   c.String(),
+		Status: convertSubmissionStatus(submission.Status),
+	}
+	if submission.Tx != nil {
+		res.TxHash = submission.Tx.Hash()
+		log.Info("Flip submitted", "hash", res.TxHash.Hex())
+	} else {
+		log.Warn("Flip is queued for submission", "cid", res.Hash, "err", submission.LastError)
+	}
+	return res, nil
+}
+
+type FlipSubmissionResponse struct {
+	Hash        string       `json:"hash"`
+	TxHash      *common.Hash `json:"txHash"`
+	PairId      uint8        `json:"pairId"`
+	Status      string       `json:"status"`
+	Attempts    uint32       `json:"attempts"`
+	LastError   string       `json:"lastError,omitempty"`
+	NextAttempt *int64       `json:"nextAttempt"`
+	Created     int64        `json:"created"`
+	Updated     int64        `json:"updated"`
+}
+
+// Submissions returns statuses of flips submitted by the node in the current epoch
+func (api *FlipApi) Submissions() []FlipSubmissionResponse {
+	submissions := api.fp.GetFlipSubmissions()
+	res := make([]FlipSubmissionResponse, 0, len(submissions))
+	for _, submission := range submissions {
+		c, _ := cid.Cast(submission.Cid)
+		item := FlipSubmissionResponse{
+			Hash:      c.String(),
+			PairId:    submission.PairId,
+			Status:    convertSubmissionStatus(submission.Status),
+			Attempts:  submission.Attempts,
+			LastError: submission.LastError,
+			Created:   submission.Created.Unix(),
+			Updated:   submission.Updated.Unix(),
+		}
+		if submission.Tx != nil {
+			txHash := submission.Tx.Hash()
+			item.TxHash = &txHash
+		}
+		if !submission.NextAttempt.IsZero() {
+			nextAttempt := submission.NextAttempt.Unix()
+			item.NextAttempt = &nextAttempt
+		}
+		res = append(res, item)
 	}
+	return res
+}
 
-	if err := api.fp.AddNewFlip(flip, true); err != nil {
-		return FlipSubmitResponse{}, err
+func convertSubmissionStatus(status flip.SubmissionStatus) string {
+	switch status {
+	case flip.SubmissionPrepared:
+		return "Prepared"
+	case flip.SubmissionPinned:
+		return "Pinned"
+	case flip.SubmissionTxSent:
+		return "TxSent"
+	case flip.SubmissionTxMined:
+		return "TxMined"
+	case flip.SubmissionFailed:
+		return "Failed"
+	default:
+		return "Unknown"
 	}
+}
 
-	log.Info("Flip submitted", "hash", tx.Hash().Hex())
+// ExportArchive returns encrypted archive with own flips, flip keys, own epoch records and deferred txs
+// which allows to move the node to another machine in the middle of the epoch
+func (api *FlipApi) ExportArchive(password string) (hexutil.Bytes, error) {
+	return api.fp.ExportArchive(api.deferredTxs.ExportTxs(), password)
+}
 
-	return FlipSubmitResponse{
-		TxHash: tx.Hash(),
-		Hash:   cid.String(),
-	}, nil
+type ImportFlipArchiveArgs struct {
+	Archive  hexutil.Bytes `json:"archive"`
+	Password string        `json:"password"`
+}
+
+type ImportFlipArchiveResponse struct {
+	SkippedDeferredTxs int `json:"skippedDeferredTxs"`
+}
+
+// ImportArchive restores the archive created by ExportArchive, deferred txs which are already queued are skipped
+func (api *FlipApi) ImportArchive(args ImportFlipArchiveArgs) (ImportFlipArchiveResponse, error) {
+	deferredTxs, err := api.fp.ImportArchive(args.Archive, args.Password)
+	if err != nil {
+		return ImportFlipArchiveResponse{}, err
+	}
+	skipped, err := api.deferredTxs.ImportTxs(deferredTxs)
+	return ImportFlipArchiveResponse{SkippedDeferredTxs: skipped}, err
 }
 
 func (api *FlipApi) Delete(ctx context.Context, hash string) (common.Hash, error) {
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/protocol"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// LightApi provides state of light node which is requested from full peers and verified by merkle proofs
+type LightApi struct {
+	state *protocol.LightState
+}
+
+// NewLightApi creates a new LightApi instance
+func NewLightApi(state *protocol.LightState) *LightApi {
+	return &LightApi{state}
+}
+
+type LightAccount struct {
+	Address common.Address  `json:"address"`
+	Balance decimal.Decimal `json:"balance"`
+	Stake   decimal.Decimal `json:"stake"`
+	Nonce   uint32          `json:"nonce"`
+	State   string          `json:"state"`
+	Height  uint64          `json:"height"`
+}
+
+func (api *LightApi) GetAccount(address common.Address) (*LightAccount, error) {
+	height := api.state.Height()
+	global, err := api.state.GetGlobal()
+	if err != nil {
+		return nil, err
+	}
+	account, err := api.state.GetAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := api.state.GetIdentity(address)
+	if err != nil {
+		return nil, err
+	}
+	nonce := account.Nonce
+	if account.Epoch < global.Epoch {
+		nonce = 0
+	}
+	return &LightAccount{
+		Address: address,
+		Balance: blockchain.ConvertToFloat(account.Balance),
+		Stake:   blockchain.ConvertToFloat(identity.Stake),
+		Nonce:   nonce,
+		State:   identity.State.String(),
+		Height:  height,
+	}, nil
+}
+
+func (api *LightApi) ReadContractData(contract common.Address, key string, format string) (interface{}, error) {
+	data, err := api.state.GetContractValue(contract, []byte(key))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New("data is nil")
+	}
+	return conversion(format, data)
+}
@@ -86,6 +86,8 @@ type Blockchain struct {
 	applyNewEpochFn func(height uint64, appState *appstate.AppState, collector collector.StatsCollector) types.TotalValidationResult
 	isSyncing       bool
 	ipfsLoadQueue   chan *attachments.StoreToIpfsAttachment
+	checkpoints     *checkpoints
+	prunedHorizon   uint64
 }
 
 type txsExecutionContext struct {
@@ -162,6 +164,10 @@ func (chain *Blockchain) Config() *config.Config {
 	return chain.config
 }
 
+func (chain *Blockchain) Repo() *database.Repo {
+	return chain.repo
+}
+
 func (chain *Blockchain) Indexer() *indexer {
 	return chain.indexer
 }
@@ -192,21 +198,7 @@ func (chain *Blockchain) InitializeChain() error {
 		chain.setCurrentHead(head)
 		chain.tryUpgrade(head)
 
-		genesisHeight := uint64(1)
-
-		if chain.config.Network == Testnet {
-			predefinedState, err := readPredefinedState()
-			if err == nil {
-				genesisHeight = predefinedState.Block
-			} else {
-				bindataGenesis, err := chain.readBindataGenesis()
-				if err == nil {
-					genesisHeight = bindataGenesis.Height()
-				}
-			}
-		}
-
-		predefinedGenesis := chain.GetBlockHeaderByHeight(genesisHeight)
+		predefinedGenesis := chain.GetBlockHeaderByHeight(predefinedGenesisHeight(chain.config.Network))
 		if predefinedGenesis == nil {
 			return errors.New("genesis block is not found")
 		}
@@ -228,6 +220,10 @@ func (chain *Blockchain) InitializeChain() error {
 			return err
 		}
 	}
+	if err := chain.initCheckpoints(); err != nil {
+		return err
+	}
+	chain.prunedHorizon = chain.repo.ReadPrunedHorizon()
 	chain.indexer.initialize(chain.coinBaseAddress)
 	chain.PreliminaryHead = chain.repo.ReadPreliminaryHead()
 	go chain.ipfsLoad()
@@ -245,7 +241,7 @@ func (chain *Blockchain) setHead(height uint64, batch dbm.Batch) {
 	chain.setCurrentHead(chain.GetHead())
 }
 
-func (chain *Blockchain) readBindataGenesis() (*types.Header, error) {
+func readBindataGenesis() (*types.Header, error) {
 	data, err := resources.IntermediateGenesisHeader()
 	if err != nil {
 		return nil, err
@@ -259,11 +255,11 @@ func (chain *Blockchain) readBindataGenesis() (*types.Header, error) {
 
 func (chain *Blockchain) loadPredefinedGenesis(network types.Network) (*types.Block, error) {
 
-	if network != Testnet {
+	if !hasPredefinedGenesis(network) {
 		return nil, errors.New(fmt.Sprintf("predefined genesis for network=%v was not found", network))
 	}
 
-	header, err := chain.readBindataGenesis()
+	header, err := readBindataGenesis()
 	if err != nil {
 		return nil, err
 	}
@@ -303,6 +299,12 @@ func (chain *Blockchain) generateGenesis(network types.Network) (*types.Block, e
 			chain.appState.State.AddStake(addr, alloc.Stake)
 		}
 		chain.appState.State.SetState(addr, state.IdentityState(alloc.State))
+		if len(alloc.PubKey) > 0 {
+			chain.appState.State.SetPubKey(addr, alloc.PubKey)
+		}
+		if alloc.RequiredFlips > 0 {
+			chain.appState.State.SetRequiredFlips(addr, alloc.RequiredFlips)
+		}
 		if state.IdentityState(alloc.State).NewbieOrBetter() {
 			chain.appState.IdentityState.SetValidated(addr, true)
 			if chain.appState.State.IsDiscriminated(addr, chain.appState.State.Epoch()) {
@@ -317,7 +319,7 @@ func (chain *Blockchain) generateGenesis(network types.Network) (*types.Block, e
 	blockNumber := uint64(1)
 	var feePerGas *big.Int
 
-	if network == Testnet {
+	if hasPredefinedGenesis(network) {
 		predefinedState, err := readPredefinedState()
 		if err != nil {
 			return nil, err
@@ -707,7 +709,11 @@ func (chain *Blockchain) applyNewEpoch(appState *appstate.AppState, block *types
 		for i := 0; i < epochDurationsLen; i++ {
 			epochDurations = append(epochDurations, uint32(epochBlocks[i+1]-epochBlocks[i]))
 		}
-		rewardValidIdentities(appState, chain.config.Consensus, validationResults, epochDurations, statsCollector)
+		rewards := make(validationRewards)
+		rewardValidIdentities(appState, chain.config.Consensus, validationResults, epochDurations, statsCollector, rewards)
+		for addr, report := range validationResult.Reports {
+			report.Reward = rewards[addr]
+		}
 		balanceShards(appState, totalNewbies, totalVerified, totalSuspended, newbiesByShard, verifiedByShard, suspendedByShard)
 	}
 
@@ -2290,6 +2296,9 @@ func (chain *Blockchain) GetBlock(hash common.Hash) *types.Block {
 			Body:   &types.Body{},
 		}
 	}
+	if chain.IsPruned(header.Height()) {
+		return nil
+	}
 	if bodyBytes, err := chain.ipfs.Get(header.ProposedHeader.IpfsHash, ipfs.Block); err != nil {
 		return nil
 	} else {
@@ -2325,6 +2334,10 @@ func (chain *Blockchain) GetBlockByHeight(height uint64) *types.Block {
 	return chain.GetBlock(hash)
 }
 
+func (chain *Blockchain) GetBlockHeader(hash common.Hash) *types.Header {
+	return chain.repo.ReadBlockHeader(hash)
+}
+
 func (chain *Blockchain) GetBlockHeaderByHeight(height uint64) *types.Header {
 	hash := chain.repo.ReadCanonicalHash(height)
 	if hash == (common.Hash{}) {
@@ -2360,7 +2373,7 @@ func (chain *Blockchain) GetTx(hash common.Hash) (*types.Transaction, *types.Tra
 		return nil, nil
 	}
 	header := chain.repo.ReadBlockHeader(idx.BlockHash)
-	if header == nil || header.ProposedHeader == nil {
+	if header == nil || header.ProposedHeader == nil || chain.IsPruned(header.Height()) {
 		return nil, nil
 	}
 
@@ -2382,6 +2395,16 @@ func (chain *Blockchain) GetTx(hash common.Hash) (*types.Transaction, *types.Tra
 	return tx, idx
 }
 
+// PrunedHorizon returns the lowest height whose block body, tx indexes and receipts are kept, 0 means nothing is pruned
+func (chain *Blockchain) PrunedHorizon() uint64 {
+	return chain.prunedHorizon
+}
+
+// IsPruned checks whether the body of the block at the height is removed by prune command
+func (chain *Blockchain) IsPruned(height uint64) bool {
+	return height < chain.prunedHorizon
+}
+
 func (chain *Blockchain) GetCommitteeSize(vc *validators.ValidatorsCache, final bool) int {
 	cnt := vc.ValidatorsSize()
 	percent := chain.config.Consensus.CommitteePercent
@@ -2642,6 +2665,34 @@ func (chain *Blockchain) ReadTotalBurntCoins() []*types.BurntCoins {
 	return chain.repo.GetTotalBurntCoins()
 }
 
+// hasPredefinedGenesis checks whether genesis files of the resources package are generated for the network
+func hasPredefinedGenesis(network types.Network) bool {
+	predefinedNetwork, err := resources.GenesisNetwork()
+	return err == nil && predefinedNetwork == network
+}
+
+func predefinedGenesisHeight(network types.Network) uint64 {
+	if !hasPredefinedGenesis(network) {
+		return 1
+	}
+	if predefinedState, err := readPredefinedState(); err == nil {
+		return predefinedState.Block
+	}
+	if bindataGenesis, err := readBindataGenesis(); err == nil {
+		return bindataGenesis.Height()
+	}
+	return 1
+}
+
+// GenesisHeight returns height of the genesis block of the chain database without initializing the chain,
+// the intermediate genesis replaces the predefined one like in InitializeChain
+func GenesisHeight(repo *database.Repo, network types.Network) uint64 {
+	if height := repo.ReadIntermediateGenesis(); height != 0 {
+		return height
+	}
+	return predefinedGenesisHeight(network)
+}
+
 func readPredefinedState() (*models.ProtoPredefinedState, error) {
 	data, err := resources.PredefinedState()
 	if err != nil {
@@ -2718,15 +2769,40 @@ func (chain *Blockchain) GetTopBlockHashes(count int) []common.Hash {
 }
 
 func (chain *Blockchain) AtomicSwitchToPreliminary(manifest *snapshot.Manifest) error {
+	oldIdentityStateDb, oldStateDb, err := chain.switchToPreliminary(manifest)
+	if err != nil {
+		return err
+	}
+	go func() {
+		_ = common.ClearDb(oldIdentityStateDb)
+		_ = common.ClearDb(oldStateDb)
+	}()
+	return nil
+}
+
+// SwitchToPreliminaryOffline is AtomicSwitchToPreliminary which clears the replaced state databases before returning,
+// so the database can be closed right after it
+func (chain *Blockchain) SwitchToPreliminaryOffline(manifest *snapshot.Manifest) error {
+	oldIdentityStateDb, oldStateDb, err := chain.switchToPreliminary(manifest)
+	if err != nil {
+		return err
+	}
+	if err := common.ClearDb(oldIdentityStateDb); err != nil {
+		return err
+	}
+	return common.ClearDb(oldStateDb)
+}
+
+func (chain *Blockchain) switchToPreliminary(manifest *snapshot.Manifest) (oldIdentityStateDb, oldStateDb dbm.DB, err error) {
 	batch, oldIdentityStateDb, err := chain.appState.IdentityState.SwitchToPreliminary(manifest.Height)
 
 	if err != nil {
 		chain.appState.State.DropSnapshot(manifest)
-		return err
+		return nil, nil, err
 	}
 	defer batch.Close()
 
-	oldStateDb := chain.appState.State.CommitSnapshot(manifest.Height, batch)
+	oldStateDb = chain.appState.State.CommitSnapshot(manifest.Height, batch)
 	chain.appState.ValidatorsCache.Load()
 
 	chain.setHead(chain.PreliminaryHead.Height(), batch)
@@ -2744,7 +2820,7 @@ func (chain *Blockchain) AtomicSwitchToPreliminary(manifest *snapshot.Manifest)
 		chain.repo.RemovePreliminaryIntermediateGenesis(batch)
 	}
 	if err := batch.WriteSync(); err != nil {
-		return err
+		return nil, nil, err
 	}
 	if preliminaryIntermediateGenesis > 0 {
 		hash := chain.repo.ReadCanonicalHash(preliminaryIntermediateGenesis)
@@ -2752,11 +2828,7 @@ func (chain *Blockchain) AtomicSwitchToPreliminary(manifest *snapshot.Manifest)
 		chain.genesisInfo.Genesis = chain.repo.ReadBlockHeader(hash)
 	}
 	chain.setCurrentHead(newHead)
-	go func() {
-		_ = common.ClearDb(oldIdentityStateDb)
-		_ = common.ClearDb(oldStateDb)
-	}()
-	return nil
+	return oldIdentityStateDb, oldStateDb, nil
 }
 
 func (chain *Blockchain) ReadEvents(contract common.Address) []*types.SavedEvent {
@@ -2892,3 +2964,35 @@ func applyHotfixToState(appState *appstate.AppState, prevBlock *types.Header) {
 		})
 	}
 }
+
+// CheckStateProofHeight returns an error if the state at the height is not retained by the node, so proofs can't be built for it
+func (chain *Blockchain) CheckStateProofHeight(height uint64) error {
+	if chain.config.Sync != nil && chain.config.Sync.LightMode {
+		return errors.New("state is not available in light mode")
+	}
+	head := chain.Head.Height()
+	if height > head {
+		return errors.New("height is greater than head")
+	}
+	if height+state.MaxSavedStatesCount <= head {
+		return errors.Errorf("state at the height is not retained, min height: %v", head-state.MaxSavedStatesCount+1)
+	}
+	return nil
+}
+
+// GetStateValueWithProof returns the raw state value of the key at the height with a merkle proof against the block state root,
+// if the state has no value for the key a proof of its absence is returned instead
+func (chain *Blockchain) GetStateValueWithProof(height uint64, key []byte) (proof []byte, absenceProof []byte, err error) {
+	if err := chain.CheckStateProofHeight(height); err != nil {
+		return nil, nil, err
+	}
+	st, err := chain.appState.State.Readonly(int64(height))
+	if err != nil {
+		return nil, nil, err
+	}
+	if proof, err = st.GetWithProof(key); err != nil || len(proof) > 0 {
+		return proof, nil, err
+	}
+	absenceProof, err = st.GetAbsenceProof(key)
+	return nil, absenceProof, err
+}
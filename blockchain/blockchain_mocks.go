@@ -85,10 +85,14 @@ func NewTestBlockchainWithBlocks(blocksCount int, emptyBlocksCount int) (*TestBl
 }
 
 func NewCustomTestBlockchain(blocksCount int, emptyBlocksCount int, key *ecdsa.PrivateKey) (*TestBlockchain, *appstate.AppState) {
+	return NewCustomTestBlockchainWithConfig(blocksCount, emptyBlocksCount, key, customTestBlockchainConfig(key))
+}
+
+func customTestBlockchainConfig(key *ecdsa.PrivateKey) *config.Config {
 	addr := crypto.PubkeyToAddress(key.PublicKey)
 	consensusCfg := GetDefaultConsensusConfig()
 	consensusCfg.Automine = true
-	cfg := &config.Config{
+	return &config.Config{
 		Network:   0x99,
 		Consensus: consensusCfg,
 		GenesisConf: &config.GenesisConf{
@@ -101,11 +105,13 @@ func NewCustomTestBlockchain(blocksCount int, emptyBlocksCount int, key *ecdsa.P
 		Validation: &config.ValidationConfig{},
 		Blockchain: &config.BlockchainConfig{},
 	}
-	return NewCustomTestBlockchainWithConfig(blocksCount, emptyBlocksCount, key, cfg)
 }
 
 func NewCustomTestBlockchainWithConfig(blocksCount int, emptyBlocksCount int, key *ecdsa.PrivateKey, cfg *config.Config) (*TestBlockchain, *appstate.AppState) {
-	db := db.NewMemDB()
+	return NewCustomTestBlockchainWithDb(db.NewMemDB(), blocksCount, emptyBlocksCount, key, cfg)
+}
+
+func NewCustomTestBlockchainWithDb(db db.DB, blocksCount int, emptyBlocksCount int, key *ecdsa.PrivateKey, cfg *config.Config) (*TestBlockchain, *appstate.AppState) {
 	bus := eventbus.New()
 	appState, _ := appstate.NewAppState(db, bus)
 	secStore := secstore.NewSecStore()
@@ -656,6 +656,24 @@ func Test_applyVrfProposerThreshold(t *testing.T) {
 	require.Equal(t, 0.5, chain.appState.State.VrfProposerThreshold())
 }
 
+func TestBlockchain_CheckStateProofHeight(t *testing.T) {
+	chain, _ := NewTestBlockchainWithBlocks(state.MaxSavedStatesCount+10, 0)
+	defer chain.SecStore().Destroy()
+
+	head := chain.Head.Height()
+	require.NoError(t, chain.CheckStateProofHeight(head))
+	require.NoError(t, chain.CheckStateProofHeight(head-state.MaxSavedStatesCount+1))
+	require.Error(t, chain.CheckStateProofHeight(head-state.MaxSavedStatesCount))
+	require.Error(t, chain.CheckStateProofHeight(1))
+	require.Error(t, chain.CheckStateProofHeight(head+1))
+
+	_, _, err := chain.GetStateValueWithProof(1, state.StateDbKeys.GlobalKey())
+	require.Error(t, err)
+	proof, _, err := chain.GetStateValueWithProof(head-state.MaxSavedStatesCount+1, state.StateDbKeys.GlobalKey())
+	require.NoError(t, err)
+	require.NotEmpty(t, proof)
+}
+
 type txWithTimestamp struct {
 	tx        *types.Transaction
 	timestamp int64
@@ -1896,3 +1914,20 @@ func Test_prepareBlockRewardCtx(t *testing.T) {
 	require.Equal(t, committeeMember9, res.committee[8].address)
 	require.Equal(t, "1866318.88", res.committee[8].stakeWeight.String())
 }
+
+func BenchmarkBlockchain_AddBlock(b *testing.B) {
+	for _, backend := range []dbm.BackendType{dbm.GoLevelDBBackend, dbm.BoltDBBackend} {
+		b.Run(string(backend), func(b *testing.B) {
+			db, err := dbm.NewDB("bench", backend, b.TempDir())
+			if err != nil {
+				b.Skipf("backend is not available: %v", err)
+			}
+			defer db.Close()
+			key, _ := crypto.GenerateKey()
+			chain, _ := NewCustomTestBlockchainWithDb(db, 0, 0, key, customTestBlockchainConfig(key))
+
+			b.ResetTimer()
+			chain.GenerateBlocks(b.N, 10)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package blockchain
+
+import (
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/config"
+	"github.com/pkg/errors"
+)
+
+var (
+	CheckpointMismatchErr = errors.New("block conflicts with trusted checkpoint")
+)
+
+type checkpoints struct {
+	byHeight map[uint64]config.Checkpoint
+	list     []config.Checkpoint
+}
+
+func newCheckpoints(list []config.Checkpoint) *checkpoints {
+	c := &checkpoints{
+		byHeight: make(map[uint64]config.Checkpoint, len(list)),
+		list:     list,
+	}
+	for _, checkpoint := range list {
+		c.byHeight[checkpoint.Height] = checkpoint
+	}
+	return c
+}
+
+func (c *checkpoints) validateHeader(header *types.Header) error {
+	if c == nil {
+		return nil
+	}
+	checkpoint, ok := c.byHeight[header.Height()]
+	if !ok {
+		return nil
+	}
+	if header.Hash() != checkpoint.Hash {
+		return errors.Wrapf(CheckpointMismatchErr, "height: %v, expected hash: %v, got: %v", checkpoint.Height, checkpoint.Hash.Hex(), header.Hash().Hex())
+	}
+	if checkpoint.IdentityRoot != nil && header.IdentityRoot() != *checkpoint.IdentityRoot {
+		return errors.Wrapf(CheckpointMismatchErr, "height: %v, expected identity root: %v, got: %v", checkpoint.Height, checkpoint.IdentityRoot.Hex(), header.IdentityRoot().Hex())
+	}
+	return nil
+}
+
+func (c *checkpoints) validateStateRoot(height uint64, root common.Hash) error {
+	if c == nil {
+		return nil
+	}
+	checkpoint, ok := c.byHeight[height]
+	if !ok || checkpoint.Root == nil {
+		return nil
+	}
+	if root != *checkpoint.Root {
+		return errors.Wrapf(CheckpointMismatchErr, "height: %v, expected state root: %v, got: %v", height, checkpoint.Root.Hex(), root.Hex())
+	}
+	return nil
+}
+
+// lastBelow returns the highest checkpoint height which is less or equal to the given height
+func (c *checkpoints) lastBelow(height uint64) uint64 {
+	if c == nil {
+		return 0
+	}
+	var result uint64
+	for _, checkpoint := range c.list {
+		if checkpoint.Height > height {
+			break
+		}
+		result = checkpoint.Height
+	}
+	return result
+}
+
+func (chain *Blockchain) initCheckpoints() error {
+	list, err := chain.config.Checkpoints()
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	chain.checkpoints = newCheckpoints(list)
+	for _, checkpoint := range list {
+		if checkpoint.Height > chain.Head.Height() {
+			break
+		}
+		header := chain.GetBlockHeaderByHeight(checkpoint.Height)
+		if header == nil {
+			// blocks before the snapshot height are missing after fast sync
+			continue
+		}
+		if err := chain.checkpoints.validateHeader(header); err != nil {
+			return errors.WithMessage(err, "local chain conflicts with trusted checkpoint, resync is required")
+		}
+	}
+	chain.log.Info("Trusted checkpoints loaded", "count", len(list), "last", list[len(list)-1].Height)
+	return nil
+}
+
+// ValidateCheckpoint checks that the header matches a trusted checkpoint if one is configured at its height
+func (chain *Blockchain) ValidateCheckpoint(header *types.Header) error {
+	return chain.checkpoints.validateHeader(header)
+}
+
+// ValidateCheckpointRoot checks that a state root (e.g. of a snapshot manifest) matches a trusted checkpoint if one is configured at the height
+func (chain *Blockchain) ValidateCheckpointRoot(height uint64, root common.Hash) error {
+	return chain.checkpoints.validateStateRoot(height, root)
+}
+
+// LastCheckpointHeight returns the height of the highest trusted checkpoint which is not above the given height
+func (chain *Blockchain) LastCheckpointHeight(height uint64) uint64 {
+	return chain.checkpoints.lastBelow(height)
+}
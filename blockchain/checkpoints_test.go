@@ -0,0 +1,50 @@
+package blockchain
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/config"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCheckpoints(t *testing.T) {
+	chain, _ := NewTestBlockchainWithBlocks(5, 0)
+	header := chain.GetBlockHeaderByHeight(3)
+	identityRoot := header.IdentityRoot()
+	wrongRoot := common.Hash{0x1}
+
+	cfg := &config.Config{
+		GenesisConf: &config.GenesisConf{
+			Checkpoints: []config.Checkpoint{{Height: 3, Hash: header.Hash(), IdentityRoot: &identityRoot}},
+		},
+		Sync: &config.SyncConfig{
+			Checkpoints: []config.Checkpoint{{Height: 10, Hash: common.Hash{0x2}, Root: &wrongRoot}, {Height: 3, Hash: header.Hash()}},
+		},
+	}
+	list, err := cfg.Checkpoints()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, uint64(3), list[0].Height)
+
+	c := newCheckpoints(list)
+	require.NoError(t, c.validateHeader(header))
+	require.NoError(t, c.validateHeader(chain.GetBlockHeaderByHeight(4)))
+	require.True(t, errors.Is(newCheckpoints([]config.Checkpoint{{Height: 3, Hash: common.Hash{0x3}}}).validateHeader(header), CheckpointMismatchErr))
+	require.True(t, errors.Is(newCheckpoints([]config.Checkpoint{{Height: 3, Hash: header.Hash(), IdentityRoot: &wrongRoot}}).validateHeader(header), CheckpointMismatchErr))
+
+	require.NoError(t, c.validateStateRoot(10, wrongRoot))
+	require.True(t, errors.Is(c.validateStateRoot(10, common.Hash{}), CheckpointMismatchErr))
+	require.NoError(t, c.validateStateRoot(3, common.Hash{}))
+
+	require.Equal(t, uint64(0), c.lastBelow(2))
+	require.Equal(t, uint64(3), c.lastBelow(9))
+	require.Equal(t, uint64(10), c.lastBelow(100))
+
+	var empty *checkpoints
+	require.NoError(t, empty.validateHeader(header))
+
+	cfg.Sync.Checkpoints[1].Hash = common.Hash{0x3}
+	_, err = cfg.Checkpoints()
+	require.Error(t, err)
+}
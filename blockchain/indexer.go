@@ -46,11 +46,18 @@ func (i *indexer) HandleBlockTransactions(header *types.Header, txs []*types.Tra
 	}
 	accountsMap[i.coinbase] = struct{}{}
 
+	if header.Flags().HasFlag(types.ValidationFinished) {
+		i.expireOwnInvites()
+	}
+
 	for _, tx := range txs {
 		sender, _ := types.Sender(tx)
 		i.handleOwnTx(header, sender, tx, accountsMap)
 		i.handleBurnTx(header.Height(), sender, tx)
 		i.handleOwnDeleteFlipTx(sender, tx)
+		i.handleOwnInviteTx(sender, tx)
+		i.handleOwnInviteActivationTx(sender, tx)
+		i.handleOwnKillInviteeTx(sender, tx)
 	}
 }
 
@@ -92,3 +99,72 @@ func (i *indexer) handleOwnDeleteFlipTx(sender common.Address, tx *types.Transac
 		FlipCid: attachment.Cid,
 	})
 }
+
+// expireOwnInvites marks own invites which haven't been activated before the end of the validation as expired
+func (i *indexer) expireOwnInvites() {
+	for _, invite := range i.repo.GetOwnInvites() {
+		if types.IsFinalOwnInviteStatus(invite.Status) {
+			continue
+		}
+		invite.Status = types.OwnInviteExpired
+		i.repo.WriteOwnInvite(invite)
+	}
+}
+
+func (i *indexer) handleOwnInviteTx(sender common.Address, tx *types.Transaction) {
+	if tx.Type != types.InviteTx || sender != i.coinbase || tx.To == nil {
+		return
+	}
+	invite := i.repo.ReadOwnInvite(*tx.To)
+	if invite == nil || invite.TxHash != tx.Hash() || invite.Status != types.OwnInvitePending {
+		return
+	}
+	invite.Status = types.OwnInviteSent
+	i.repo.WriteOwnInvite(invite)
+}
+
+// handleOwnInviteActivationTx links the mined activation tx and the activated identity with the own invite,
+// the activation tx is sent from the address of the invite
+func (i *indexer) handleOwnInviteActivationTx(sender common.Address, tx *types.Transaction) {
+	if tx.Type != types.ActivationTx || tx.To == nil {
+		return
+	}
+	invite := i.repo.ReadOwnInvite(sender)
+	if invite == nil || types.IsFinalOwnInviteStatus(invite.Status) {
+		return
+	}
+	hash := tx.Hash()
+	activated := *tx.To
+	invite.ActivationTxHash = &hash
+	invite.Activated = &activated
+	invite.Status = types.OwnInviteActivated
+	i.repo.WriteOwnInvite(invite)
+}
+
+// handleOwnKillInviteeTx links the mined kill tx with the own invite, the killed address is either the invite
+// or the identity which has activated it
+func (i *indexer) handleOwnKillInviteeTx(sender common.Address, tx *types.Transaction) {
+	if tx.Type != types.KillInviteeTx || sender != i.coinbase || tx.To == nil {
+		return
+	}
+	invite := i.repo.ReadOwnInvite(*tx.To)
+	if invite == nil {
+		invite = i.findOwnInviteByActivated(*tx.To)
+	}
+	if invite == nil {
+		return
+	}
+	hash := tx.Hash()
+	invite.KillTxHash = &hash
+	invite.Status = types.OwnInviteKilled
+	i.repo.WriteOwnInvite(invite)
+}
+
+func (i *indexer) findOwnInviteByActivated(addr common.Address) *types.OwnInvite {
+	for _, invite := range i.repo.GetOwnInvites() {
+		if invite.Activated != nil && *invite.Activated == addr {
+			return invite
+		}
+	}
+	return nil
+}
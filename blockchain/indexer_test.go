@@ -234,3 +234,83 @@ func Test_Blockchain_saveBurntCoins(t *testing.T) {
 	require.Equal(addr, burntCoins[0].Address)
 	require.Equal(big.NewInt(1), burntCoins[0].Amount)
 }
+
+func Test_handleOwnKillInviteeTx(t *testing.T) {
+	require := require.New(t)
+
+	chain, _, _, key := NewTestBlockchain(true, nil)
+	defer chain.SecStore().Destroy()
+	key2, _ := crypto.GenerateKey()
+
+	receiver := common.Address{0x1}
+	otherReceiver := common.Address{0x2}
+	activated := common.Address{0x3}
+	repo := chain.Repo()
+	repo.WriteOwnInvite(&types.OwnInvite{Receiver: receiver, Status: types.OwnInviteSent})
+	repo.WriteOwnInvite(&types.OwnInvite{Receiver: otherReceiver, Status: types.OwnInviteSent})
+	repo.WriteOwnInvite(&types.OwnInvite{Receiver: common.Address{0x4}, Status: types.OwnInviteActivated, Activated: &activated})
+
+	header := &types.Header{ProposedHeader: &types.ProposedHeader{Height: 1}}
+	killTx := tests.GetFullTx(1, 1, key, types.KillInviteeTx, nil, &receiver, nil)
+	killActivatedTx := tests.GetFullTx(2, 1, key, types.KillInviteeTx, nil, &activated, nil)
+	chain.indexer.HandleBlockTransactions(header, []*types.Transaction{
+		killTx,
+		killActivatedTx,
+		// kill tx of another inviter
+		tests.GetFullTx(1, 1, key2, types.KillInviteeTx, nil, &otherReceiver, nil),
+	})
+
+	invite := repo.ReadOwnInvite(receiver)
+	require.NotNil(invite.KillTxHash)
+	require.Equal(killTx.Hash(), *invite.KillTxHash)
+	require.Equal(types.OwnInviteKilled, invite.Status)
+
+	invite = repo.ReadOwnInvite(common.Address{0x4})
+	require.NotNil(invite.KillTxHash)
+	require.Equal(killActivatedTx.Hash(), *invite.KillTxHash)
+	require.Equal(types.OwnInviteKilled, invite.Status)
+
+	invite = repo.ReadOwnInvite(otherReceiver)
+	require.Nil(invite.KillTxHash)
+	require.Equal(types.OwnInviteSent, invite.Status)
+}
+
+func Test_handleOwnInvites(t *testing.T) {
+	require := require.New(t)
+
+	chain, _, _, key := NewTestBlockchain(true, nil)
+	defer chain.SecStore().Destroy()
+
+	inviteKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(inviteKey.PublicKey)
+	expiredReceiver := common.Address{0x1}
+	activated := common.Address{0x2}
+
+	inviteTx := tests.GetFullTx(1, 1, key, types.InviteTx, nil, &receiver, nil)
+	expiredInviteTx := tests.GetFullTx(2, 1, key, types.InviteTx, nil, &expiredReceiver, nil)
+	repo := chain.Repo()
+	repo.WriteOwnInvite(&types.OwnInvite{Receiver: receiver, TxHash: inviteTx.Hash(), Status: types.OwnInvitePending})
+	repo.WriteOwnInvite(&types.OwnInvite{Receiver: expiredReceiver, TxHash: expiredInviteTx.Hash(), Status: types.OwnInvitePending})
+
+	chain.indexer.HandleBlockTransactions(&types.Header{ProposedHeader: &types.ProposedHeader{Height: 1}}, []*types.Transaction{
+		inviteTx,
+		expiredInviteTx,
+	})
+	require.Equal(types.OwnInviteSent, repo.ReadOwnInvite(receiver).Status)
+	require.Equal(types.OwnInviteSent, repo.ReadOwnInvite(expiredReceiver).Status)
+
+	activationTx := tests.GetFullTx(1, 1, inviteKey, types.ActivationTx, nil, &activated, nil)
+	chain.indexer.HandleBlockTransactions(&types.Header{ProposedHeader: &types.ProposedHeader{Height: 2}}, []*types.Transaction{
+		activationTx,
+	})
+	invite := repo.ReadOwnInvite(receiver)
+	require.Equal(types.OwnInviteActivated, invite.Status)
+	require.Equal(activationTx.Hash(), *invite.ActivationTxHash)
+	require.Equal(activated, *invite.Activated)
+
+	chain.indexer.HandleBlockTransactions(&types.Header{ProposedHeader: &types.ProposedHeader{Height: 3, Flags: types.ValidationFinished}}, nil)
+	require.Equal(types.OwnInviteActivated, repo.ReadOwnInvite(receiver).Status)
+	invite = repo.ReadOwnInvite(expiredReceiver)
+	require.Equal(types.OwnInviteExpired, invite.Status)
+	require.Nil(invite.Activated)
+}
@@ -16,8 +16,28 @@ import (
 	"sort"
 )
 
+// validationRewards sums up validation rewards of identities, the rewards are reported in validation reports
+type validationRewards map[common.Address]*big.Int
+
+func (r validationRewards) add(addr common.Address, balance, stake *big.Int) {
+	if r == nil {
+		return
+	}
+	sum, ok := r[addr]
+	if !ok {
+		sum = new(big.Int)
+		r[addr] = sum
+	}
+	if balance != nil {
+		sum.Add(sum, balance)
+	}
+	if stake != nil {
+		sum.Add(sum, stake)
+	}
+}
+
 func rewardValidIdentities(appState *appstate.AppState, config *config.ConsensusConf, validationResults map[common.ShardId]*types.ValidationResults,
-	epochDurations []uint32, statsCollector collector.StatsCollector) {
+	epochDurations []uint32, statsCollector collector.StatsCollector, rewards validationRewards) {
 
 	totalReward := big.NewInt(0).Add(config.BlockReward, config.FinalCommitteeReward)
 	currentEpochDuration := epochDurations[len(epochDurations)-1]
@@ -28,16 +48,23 @@ func rewardValidIdentities(appState *appstate.AppState, config *config.Consensus
 	log.Info("Total validation reward", "reward", ConvertToFloat(totalReward).String())
 
 	totalRewardD := decimal.NewFromBigInt(totalReward, 0)
-	addSuccessfulValidationReward(appState, config, validationResults, totalRewardD, statsCollector)
-	addFlipReward(appState, config, validationResults, totalRewardD, statsCollector)
-	addReportReward(appState, config, validationResults, totalRewardD, statsCollector)
-	addInvitationReward(appState, config, validationResults, totalRewardD, epochDurations, statsCollector)
+	addSuccessfulValidationReward(appState, config, validationResults, totalRewardD, statsCollector, rewards)
+	addFlipReward(appState, config, validationResults, totalRewardD, statsCollector, rewards)
+	addReportReward(appState, config, validationResults, totalRewardD, statsCollector, rewards)
+	addInvitationReward(appState, config, validationResults, totalRewardD, epochDurations, statsCollector, rewards)
 	addFoundationPayouts(appState, config, totalRewardD, statsCollector)
 	addZeroWalletFund(appState, config, totalRewardD, statsCollector)
 }
 
+// RewardValidIdentities distributes validation rewards outside of block processing, it is used by ceremony simulations
+func RewardValidIdentities(appState *appstate.AppState, config *config.ConsensusConf, validationResults map[common.ShardId]*types.ValidationResults,
+	epochDurations []uint32) {
+	rewardValidIdentities(appState, config, validationResults, epochDurations, nil, nil)
+}
+
 func addSuccessfulValidationReward(appState *appstate.AppState, config *config.ConsensusConf,
-	validationResults map[common.ShardId]*types.ValidationResults, totalReward decimal.Decimal, statsCollector collector.StatsCollector) {
+	validationResults map[common.ShardId]*types.ValidationResults, totalReward decimal.Decimal, statsCollector collector.StatsCollector,
+	rewards validationRewards) {
 
 	epoch := appState.State.Epoch()
 
@@ -105,6 +132,7 @@ func addSuccessfulValidationReward(appState *appstate.AppState, config *config.C
 		collector.AddMintedCoins(statsCollector, stake)
 		addRewardToCollectorFunc(rewardDest, balance, stake)
 		collector.AfterAddStake(statsCollector, addr, stake, appState)
+		rewards.add(addr, balance, stake)
 	}
 
 	for _, value := range cache {
@@ -142,7 +170,7 @@ func getFlipRewardCoef(grade types.Grade) float32 {
 }
 
 func addFlipReward(appState *appstate.AppState, config *config.ConsensusConf, validationResults map[common.ShardId]*types.ValidationResults,
-	totalReward decimal.Decimal, statsCollector collector.StatsCollector) {
+	totalReward decimal.Decimal, statsCollector collector.StatsCollector, rewards validationRewards) {
 	flipRewardD := totalReward.Mul(decimal.NewFromFloat32(config.FlipRewardPercent))
 
 	totalWeight := float32(0)
@@ -205,6 +233,7 @@ func addFlipReward(appState *appstate.AppState, config *config.ConsensusConf, va
 			collector.AddMintedCoins(statsCollector, stake)
 			collector.AddFlipsReward(statsCollector, rewardDest, addr, reward, stake, author.FlipsToReward)
 			collector.AfterAddStake(statsCollector, addr, stake, appState)
+			rewards.add(addr, reward, stake)
 		}
 	}
 	if config.ReportsRewardPercent > 0 {
@@ -235,13 +264,14 @@ func addFlipReward(appState *appstate.AppState, config *config.ConsensusConf, va
 				collector.AddMintedCoins(statsCollector, stake)
 				collector.AddReportedFlipsReward(statsCollector, rewardDest, reporter.Address, shardId, flipIdx, reward, stake)
 				collector.AfterAddStake(statsCollector, reporter.Address, stake, appState)
+				rewards.add(reporter.Address, reward, stake)
 			}
 		}
 	}
 }
 
 func addReportReward(appState *appstate.AppState, config *config.ConsensusConf, validationResults map[common.ShardId]*types.ValidationResults,
-	totalReward decimal.Decimal, statsCollector collector.StatsCollector) {
+	totalReward decimal.Decimal, statsCollector collector.StatsCollector, rewards validationRewards) {
 	if config.ReportsRewardPercent == 0 {
 		return
 	}
@@ -291,6 +321,7 @@ func addReportReward(appState *appstate.AppState, config *config.ConsensusConf,
 				collector.AddMintedCoins(statsCollector, stake)
 				collector.AddReportedFlipsReward(statsCollector, rewardDest, reporter.Address, shardId, flipIdx, reward, stake)
 				collector.AfterAddStake(statsCollector, reporter.Address, stake, appState)
+				rewards.add(reporter.Address, reward, stake)
 			}
 		}
 	}
@@ -320,7 +351,7 @@ func getInvitationRewardCoef(age uint16, epochHeight uint32, epochDurations []ui
 }
 
 func addInvitationReward(appState *appstate.AppState, config *config.ConsensusConf, validationResults map[common.ShardId]*types.ValidationResults,
-	totalReward decimal.Decimal, epochDurations []uint32, statsCollector collector.StatsCollector) {
+	totalReward decimal.Decimal, epochDurations []uint32, statsCollector collector.StatsCollector, rewards validationRewards) {
 	invitationRewardD := totalReward.Mul(decimal.NewFromFloat32(config.ValidInvitationRewardPercent))
 
 	totalWeight := float32(0)
@@ -382,6 +413,7 @@ func addInvitationReward(appState *appstate.AppState, config *config.ConsensusCo
 		collector.AddMintedCoins(statsCollector, stake)
 		collector.AddInvitationsReward(statsCollector, rewardDest, addr, reward, stake, age, txHash, epochHeight, isSavedInviteWinner)
 		collector.AfterAddStake(statsCollector, addr, stake, appState)
+		rewards.add(addr, reward, stake)
 	}
 
 	for _, inviterWrapper := range goodInviters {
@@ -0,0 +1,256 @@
+package blockchain
+
+import (
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/math"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/stats/collector"
+	"github.com/shopspring/decimal"
+	"math/big"
+	"time"
+)
+
+type RewardEstimate struct {
+	Balance *big.Int
+	Stake   *big.Int
+}
+
+func (r *RewardEstimate) add(balance, stake *big.Int) {
+	r.Balance.Add(r.Balance, balance)
+	r.Stake.Add(r.Stake, stake)
+}
+
+type RewardPools struct {
+	Total       *big.Int
+	Validation  *big.Int
+	Staking     *big.Int
+	Candidate   *big.Int
+	Flips       *big.Int
+	Reports     *big.Int
+	Invitations *big.Int
+	Foundation  *big.Int
+	ZeroWallet  *big.Int
+}
+
+type RewardsEstimate struct {
+	Staking     *RewardEstimate
+	Candidate   *RewardEstimate
+	Flips       *RewardEstimate
+	Reports     *RewardEstimate
+	Invitations *RewardEstimate
+	Pools       *RewardPools
+	// estimated duration of the current epoch in blocks
+	EpochDuration uint32
+}
+
+// rewardsEstimateCollector catches rewards of the single identity while reward functions are applied
+type rewardsEstimateCollector struct {
+	collector.StatsCollector
+	addr     common.Address
+	estimate *RewardsEstimate
+}
+
+func (c *rewardsEstimateCollector) AddStakingReward(balanceDest, stakeDest common.Address, stakedAmount *big.Int, balance, stake *big.Int) {
+	if stakeDest == c.addr {
+		c.estimate.Staking.add(balance, stake)
+	}
+}
+
+func (c *rewardsEstimateCollector) AddCandidateReward(balanceDest, stakeDest common.Address, balance, stake *big.Int) {
+	if stakeDest == c.addr {
+		c.estimate.Candidate.add(balance, stake)
+	}
+}
+
+func (c *rewardsEstimateCollector) AddFlipsReward(balanceDest, stakeDest common.Address, balance, stake *big.Int, flipsToReward []*types.FlipToReward) {
+	if stakeDest == c.addr {
+		c.estimate.Flips.add(balance, stake)
+	}
+}
+
+func (c *rewardsEstimateCollector) AddReportedFlipsReward(balanceDest, stakeDest common.Address, shardId common.ShardId, flipIdx int, balance, stake *big.Int) {
+	if stakeDest == c.addr {
+		c.estimate.Reports.add(balance, stake)
+	}
+}
+
+func (c *rewardsEstimateCollector) AddInvitationsReward(balanceDest, stakeDest common.Address, balance, stake *big.Int, age uint16,
+	txHash *common.Hash, epochHeight uint32, isSavedInviteWinner bool) {
+	if stakeDest == c.addr {
+		c.estimate.Invitations.add(balance, stake)
+	}
+}
+
+// EstimateRewards calculates epoch rewards of the identity assuming that all identities which are able to pass
+// the next validation pass it successfully and all their flips get the same grade.
+// Rewards for reports can't be predicted, so only the pool is estimated for them.
+// appState is modified, so a copy of the current state should be passed.
+func EstimateRewards(appState *appstate.AppState, config *config.ConsensusConf, addr common.Address, headHeight uint64, now time.Time) *RewardsEstimate {
+	newEstimate := func() *RewardEstimate {
+		return &RewardEstimate{Balance: big.NewInt(0), Stake: big.NewInt(0)}
+	}
+	estimate := &RewardsEstimate{
+		Staking:     newEstimate(),
+		Candidate:   newEstimate(),
+		Flips:       newEstimate(),
+		Reports:     newEstimate(),
+		Invitations: newEstimate(),
+	}
+	epochDurations := estimateEpochDurations(appState, config, headHeight, now)
+	estimate.EpochDuration = epochDurations[len(epochDurations)-1]
+	estimate.Pools = calculateRewardPools(config, estimate.EpochDuration)
+
+	validationResults := assumeSuccessfulValidation(appState)
+	statsCollector := &rewardsEstimateCollector{
+		StatsCollector: collector.NewStatsCollector(),
+		addr:           addr,
+		estimate:       estimate,
+	}
+	rewardValidIdentities(appState, config, validationResults, epochDurations, statsCollector, nil)
+	return estimate
+}
+
+func estimateEpochDurations(appState *appstate.AppState, config *config.ConsensusConf, headHeight uint64, now time.Time) []uint32 {
+	epochEnd := headHeight
+	if left := appState.State.NextValidationTime().Sub(now); left > 0 && config.MinBlockDistance > 0 {
+		epochEnd += uint64(left / config.MinBlockDistance)
+	}
+	prevEpochBlocks := appState.State.PrevEpochBlocks()
+	epochBlocks := make([]uint64, 0, len(prevEpochBlocks)+2)
+	epochBlocks = append(append(epochBlocks, prevEpochBlocks...), appState.State.EpochBlock(), epochEnd)
+	epochDurations := make([]uint32, 0, len(epochBlocks)-1)
+	for i := 0; i < len(epochBlocks)-1; i++ {
+		epochDurations = append(epochDurations, uint32(epochBlocks[i+1]-epochBlocks[i]))
+	}
+	return epochDurations
+}
+
+func calculateRewardPools(config *config.ConsensusConf, epochDuration uint32) *RewardPools {
+	totalReward := new(big.Int).Add(config.BlockReward, config.FinalCommitteeReward)
+	totalReward.Mul(totalReward, big.NewInt(int64(epochDuration)))
+	totalRewardD := decimal.NewFromBigInt(totalReward, 0)
+	pool := func(percent float32) *big.Int {
+		return math.ToInt(totalRewardD.Mul(decimal.NewFromFloat32(percent)))
+	}
+	return &RewardPools{
+		Total:       totalReward,
+		Validation:  pool(config.SuccessfulValidationRewardPercent),
+		Staking:     pool(config.StakingRewardPercent),
+		Candidate:   pool(config.CandidateRewardPercent),
+		Flips:       pool(config.FlipRewardPercent),
+		Reports:     pool(config.ReportsRewardPercent),
+		Invitations: pool(config.ValidInvitationRewardPercent),
+		Foundation:  pool(config.FoundationPayoutsPercent),
+		ZeroWallet:  pool(config.ZeroWalletPercent),
+	}
+}
+
+// assumedValidationState determines the state of the identity after the validation where required flips are submitted
+// and all short and long flips are solved correctly
+func assumedValidationState(identity state.Identity) (state.IdentityState, bool) {
+	if !state.IsCeremonyCandidateData(identity.State, true) {
+		return state.Undefined, false
+	}
+	// required flips are assumed to be submitted before the validation
+	identity.RequiredFlips = 0
+	shortFlips := uint32(common.ShortSessionFlipsCount())
+	totalScore, totalFlips := common.CalculateNewTotalScore(identity.Scores, float32(shortFlips), shortFlips,
+		identity.GetShortFlipPoints(), identity.QualifiedFlips)
+	newState := state.DetermineNewIdentityState(identity, 1, 1, totalScore, totalFlips, false, false, false, true)
+	return newState, newState.NewbieOrBetter()
+}
+
+// assumeSuccessfulValidation applies states of the successful validation and builds validation results for rewarding
+func assumeSuccessfulValidation(appState *appstate.AppState) map[common.ShardId]*types.ValidationResults {
+	epoch := appState.State.Epoch()
+	validationResults := make(map[common.ShardId]*types.ValidationResults)
+	getShardResults := func(shardId common.ShardId) *types.ValidationResults {
+		shardResults, ok := validationResults[shardId]
+		if !ok {
+			shardResults = &types.ValidationResults{
+				BadAuthors:              make(map[common.Address]types.BadAuthorReason),
+				GoodAuthors:             make(map[common.Address]*types.ValidationResult),
+				AuthorResults:           make(map[common.Address]*types.AuthorResults),
+				GoodInviters:            make(map[common.Address]*types.InviterValidationResult),
+				ReportersToRewardByFlip: make(map[int]map[common.Address]*types.Candidate),
+			}
+			validationResults[shardId] = shardResults
+		}
+		return shardResults
+	}
+	for i := uint32(1); i <= appState.State.ShardsNum(); i++ {
+		getShardResults(common.ShardId(i))
+	}
+
+	type validatedIdentity struct {
+		addr     common.Address
+		identity state.Identity
+		newState state.IdentityState
+	}
+	var validated []validatedIdentity
+	appState.State.IterateOverIdentities(func(addr common.Address, identity state.Identity) {
+		if newState, ok := assumedValidationState(identity); ok {
+			validated = append(validated, validatedIdentity{addr, identity, newState})
+		}
+	})
+
+	invites := make(map[common.Address][]*types.SuccessfulInvite)
+	for _, v := range validated {
+		birthday := v.identity.Birthday
+		if v.identity.State == state.Candidate {
+			birthday = epoch
+			appState.State.SetBirthday(v.addr, birthday)
+		}
+		appState.State.SetState(v.addr, v.newState)
+
+		shardResults := getShardResults(v.identity.ShiftedShardId())
+		flipsCount := len(v.identity.Flips)
+		if flipsCount < int(v.identity.RequiredFlips) {
+			flipsCount = int(v.identity.RequiredFlips)
+		}
+		if flipsCount > 0 {
+			flipsToReward := make([]*types.FlipToReward, 0, flipsCount)
+			for i := 0; i < flipsCount; i++ {
+				var cid []byte
+				if i < len(v.identity.Flips) {
+					cid = v.identity.Flips[i].Cid
+				}
+				flipsToReward = append(flipsToReward, &types.FlipToReward{Cid: cid, Grade: types.GradeD})
+			}
+			shardResults.GoodAuthors[v.addr] = &types.ValidationResult{
+				FlipsToReward:    flipsToReward,
+				NewIdentityState: uint8(v.newState),
+			}
+		}
+
+		inviter := v.identity.Inviter
+		if inviter == nil || v.newState != state.Newbie && v.newState != state.Verified {
+			continue
+		}
+		if birthday > epoch {
+			continue
+		}
+		if age := epoch - birthday + 1; age <= 3 {
+			invites[inviter.Address] = append(invites[inviter.Address], &types.SuccessfulInvite{
+				Age:         age,
+				TxHash:      inviter.TxHash,
+				EpochHeight: inviter.EpochHeight,
+			})
+		}
+	}
+	for _, v := range validated {
+		successfulInvites, ok := invites[v.addr]
+		if !ok {
+			continue
+		}
+		getShardResults(v.identity.ShiftedShardId()).GoodInviters[v.addr] = &types.InviterValidationResult{
+			SuccessfulInvites:   successfulInvites,
+			NewIdentityState:    uint8(v.newState),
+			PayInvitationReward: true,
+		}
+	}
+	return validationResults
+}
@@ -0,0 +1,44 @@
+package blockchain
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_assumedValidationState(t *testing.T) {
+	perfect := common.EncodeScore(6, 6)
+	poor := common.EncodeScore(3, 6)
+
+	cases := []struct {
+		name      string
+		identity  state.Identity
+		expected  state.IdentityState
+		validated bool
+	}{
+		{"undefined", state.Identity{State: state.Undefined}, state.Undefined, false},
+		{"invite", state.Identity{State: state.Invite}, state.Undefined, false},
+		{"killed", state.Identity{State: state.Killed}, state.Undefined, false},
+		{"candidate", state.Identity{State: state.Candidate, Birthday: 10}, state.Newbie, true},
+		{"candidate without flips", state.Identity{State: state.Candidate, RequiredFlips: 3}, state.Newbie, true},
+		{"newbie without enough flips", state.Identity{State: state.Newbie, Birthday: 9, Scores: []byte{perfect}}, state.Newbie, true},
+		{"newbie born after the epoch", state.Identity{State: state.Newbie, Birthday: 11}, state.Newbie, true},
+		{"newbie with enough flips", state.Identity{State: state.Newbie, Birthday: 8, Scores: []byte{perfect, perfect}}, state.Verified, true},
+		{"verified", state.Identity{State: state.Verified, Scores: []byte{perfect, perfect}}, state.Verified, true},
+		{"verified with enough flips for human", state.Identity{State: state.Verified, Scores: []byte{perfect, perfect, perfect}}, state.Human, true},
+		{"verified with low score", state.Identity{State: state.Verified, Scores: []byte{poor, poor, poor}}, state.Killed, false},
+		{"suspended", state.Identity{State: state.Suspended}, state.Verified, true},
+		{"suspended with enough flips for human", state.Identity{State: state.Suspended, Scores: []byte{perfect, perfect, perfect}}, state.Human, true},
+		{"zombie", state.Identity{State: state.Zombie}, state.Verified, true},
+		{"human", state.Identity{State: state.Human, Scores: []byte{perfect, perfect, perfect}}, state.Human, true},
+		{"human with low score", state.Identity{State: state.Human, Scores: []byte{poor, poor, poor}}, state.Suspended, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			newState, validated := assumedValidationState(c.identity)
+			require.Equal(t, c.expected, newState)
+			require.Equal(t, c.validated, validated)
+		})
+	}
+}
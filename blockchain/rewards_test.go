@@ -17,6 +17,7 @@ import (
 	"math/big"
 	"sort"
 	"testing"
+	"time"
 )
 
 func Test_rewardValidIdentities(t *testing.T) {
@@ -126,7 +127,7 @@ func Test_rewardValidIdentities(t *testing.T) {
 	appState.State.SetState(addr2, state.Newbie)
 	appState.State.SetBirthday(addr2, 5)
 
-	rewardValidIdentities(appState, conf, validationResults, []uint32{400, 200, 100}, nil)
+	rewardValidIdentities(appState, conf, validationResults, []uint32{400, 200, 100}, nil, nil)
 
 	appState.Commit(nil)
 
@@ -325,7 +326,8 @@ func Test_addSuccessfulValidationReward1(t *testing.T) {
 
 	totalReward := decimal.RequireFromString("545000149673614247952282")
 
-	addSuccessfulValidationReward(appState, conf, validationResults, totalReward, nil)
+	rewards := make(validationRewards)
+	addSuccessfulValidationReward(appState, conf, validationResults, totalReward, nil, rewards)
 	_ = appState.Commit(nil)
 
 	require.Zero(t, appState.State.GetBalance(addrZeroStake).Sign())
@@ -337,6 +339,11 @@ func Test_addSuccessfulValidationReward1(t *testing.T) {
 	require.Equal(t, "156.106680689089783251", ConvertToFloat(appState.State.GetBalance(addr4)).String())
 	require.Equal(t, "78323.87772688500601495", ConvertToFloat(appState.State.GetBalance(addr5)).String())
 	require.Equal(t, "0.000000000000000001", ConvertToFloat(appState.State.GetBalance(addr6)).String())
+
+	require.NotContains(t, rewards, addrPenalized)
+	require.NotContains(t, rewards, addrNotValidated)
+	addr4StakeReward := new(big.Int).Sub(appState.State.GetStakeBalance(addr4), ConvertToInt(decimal.RequireFromString("9125849.019823751067178698")))
+	require.Equal(t, new(big.Int).Add(appState.State.GetBalance(addr4), addr4StakeReward), rewards[addr4])
 }
 
 func Test_addSuccessfulValidationReward2(t *testing.T) {
@@ -401,7 +408,7 @@ func Test_addSuccessfulValidationReward2(t *testing.T) {
 
 	totalReward := decimal.RequireFromString("1000000000000000000000")
 
-	addSuccessfulValidationReward(appState, conf, validationResults, totalReward, nil)
+	addSuccessfulValidationReward(appState, conf, validationResults, totalReward, nil, nil)
 	_ = appState.Commit(nil)
 
 	for i, addr := range addrs {
@@ -410,3 +417,71 @@ func Test_addSuccessfulValidationReward2(t *testing.T) {
 	}
 
 }
+
+func TestEstimateRewards(t *testing.T) {
+	memdb := db.NewMemDB()
+	appState, _ := appstate.NewAppState(memdb, eventbus.New())
+	_ = appState.Initialize(0)
+
+	conf := GetDefaultConsensusConfig()
+	conf.BlockReward = ConvertToInt(decimal.NewFromInt(1))
+	conf.FinalCommitteeReward = ConvertToInt(decimal.NewFromInt(1))
+
+	now := time.Unix(100000, 0)
+	appState.State.SetGlobalEpoch(5)
+	appState.State.SetEpochBlock(100)
+	appState.State.SetNextValidationTime(now.Add(conf.MinBlockDistance * 50))
+
+	inviter := common.Address{0x1}
+	appState.State.SetState(inviter, state.Verified)
+	appState.State.AddStake(inviter, ConvertToInt(decimal.NewFromInt(100)))
+	appState.State.SetRequiredFlips(inviter, 3)
+	appState.State.AddNewScore(inviter, common.EncodeScore(6, 6))
+	appState.State.AddNewScore(inviter, common.EncodeScore(6, 6))
+
+	other := common.Address{0x2}
+	appState.State.SetState(other, state.Verified)
+	appState.State.AddStake(other, ConvertToInt(decimal.NewFromInt(100)))
+	appState.State.SetRequiredFlips(other, 3)
+	appState.State.AddNewScore(other, common.EncodeScore(6, 6))
+	appState.State.AddNewScore(other, common.EncodeScore(6, 6))
+
+	invitee := common.Address{0x3}
+	appState.State.SetState(invitee, state.Candidate)
+	appState.State.SetInviter(invitee, inviter, common.Hash{0x1}, 10)
+	appState.State.AddInvitee(inviter, invitee, common.Hash{0x1})
+
+	notValidated := common.Address{0x4}
+	appState.State.SetState(notValidated, state.Killed)
+	appState.State.AddStake(notValidated, ConvertToInt(decimal.NewFromInt(1000)))
+	_ = appState.Commit(nil)
+
+	forCheck, err := appState.ForCheck(uint64(appState.State.Version()))
+	require.NoError(t, err)
+	estimate := EstimateRewards(forCheck, conf, inviter, 150, now)
+	require.Equal(t, uint32(100), estimate.EpochDuration)
+	require.Equal(t, ConvertToInt(decimal.NewFromInt(200)), estimate.Pools.Total)
+	require.Equal(t, ConvertToInt(decimal.NewFromInt(36)), estimate.Pools.Staking)
+
+	total := func(r *RewardEstimate) *big.Int {
+		return new(big.Int).Add(r.Balance, r.Stake)
+	}
+	toFloat := func(r *RewardEstimate) float64 {
+		f, _ := ConvertToFloat(total(r)).Float64()
+		return f
+	}
+	// stakes are equal, so the staking pool is split in half
+	require.InDelta(t, 18, toFloat(estimate.Staking), 1e-9)
+	// flips of two authors have the same weight
+	require.InDelta(t, 35, toFloat(estimate.Flips), 1e-9)
+	require.True(t, total(estimate.Invitations).Sign() > 0)
+	require.Zero(t, total(estimate.Candidate).Sign())
+	require.Zero(t, total(estimate.Reports).Sign())
+
+	forCheck, err = appState.ForCheck(uint64(appState.State.Version()))
+	require.NoError(t, err)
+	estimate = EstimateRewards(forCheck, conf, invitee, 150, now)
+	require.InDelta(t, 4, toFloat(estimate.Candidate), 1e-9)
+	require.Zero(t, total(estimate.Staking).Sign())
+	require.Equal(t, state.Candidate, appState.State.GetIdentityState(invitee))
+}
@@ -1425,6 +1425,8 @@ type TotalValidationResult struct {
 	ShardResults    map[common.ShardId]*ValidationResults
 	Pools           map[common.Address]struct{}
 	Failed          bool
+	// Reports are filled with rewards when the results are applied
+	Reports map[common.Address]*ValidationReport
 }
 
 type Candidate struct {
@@ -1440,6 +1442,200 @@ const (
 	WrongWordsBadAuthor       BadAuthorReason = 2
 )
 
+type ValidationMissedReason = byte
+
+const (
+	NotMissed                  ValidationMissedReason = 0
+	NotCandidateMissedReason   ValidationMissedReason = 1
+	NotApprovedMissedReason    ValidationMissedReason = 2
+	NoShortAnswersMissedReason ValidationMissedReason = 3
+	NoLongAnswersMissedReason  ValidationMissedReason = 4
+)
+
+// ValidationReport explains validation ceremony result of the identity
+type ValidationReport struct {
+	Epoch                 uint16
+	Failed                bool
+	Candidate             bool
+	PrevState             uint8
+	NewState              uint8
+	Approved              bool
+	Missed                bool
+	MissedReason          ValidationMissedReason
+	ShortFlipsToSolve     uint32
+	LongFlipsToSolve      uint32
+	ShortPoint            float32
+	ShortQualifiedFlips   uint32
+	LongPoint             float32
+	LongQualifiedFlips    uint32
+	ShortScore            float32
+	LongScore             float32
+	TotalScore            float32
+	TotalQualifiedFlips   uint32
+	NoQualifiedShortFlips bool
+	NoQualifiedLongFlips  bool
+	BadAuthor             bool
+	BadAuthorReason       BadAuthorReason
+	RewardedFlips         uint32
+	RewardedReports       uint32
+	// Reward is the sum of validation rewards paid to the identity, the balance part may be paid to its pool
+	Reward *big.Int
+	// Penalty is the burnt stake of the killed identity
+	Penalty *big.Int
+}
+
+func (r *ValidationReport) ToBytes() ([]byte, error) {
+	protoObj := &models.ProtoValidationReport{
+		Epoch:                 uint32(r.Epoch),
+		Failed:                r.Failed,
+		Candidate:             r.Candidate,
+		PrevState:             uint32(r.PrevState),
+		NewState:              uint32(r.NewState),
+		Approved:              r.Approved,
+		Missed:                r.Missed,
+		MissedReason:          uint32(r.MissedReason),
+		ShortFlipsToSolve:     r.ShortFlipsToSolve,
+		LongFlipsToSolve:      r.LongFlipsToSolve,
+		ShortPoint:            r.ShortPoint,
+		ShortQualifiedFlips:   r.ShortQualifiedFlips,
+		LongPoint:             r.LongPoint,
+		LongQualifiedFlips:    r.LongQualifiedFlips,
+		ShortScore:            r.ShortScore,
+		LongScore:             r.LongScore,
+		TotalScore:            r.TotalScore,
+		TotalQualifiedFlips:   r.TotalQualifiedFlips,
+		NoQualifiedShortFlips: r.NoQualifiedShortFlips,
+		NoQualifiedLongFlips:  r.NoQualifiedLongFlips,
+		BadAuthor:             r.BadAuthor,
+		BadAuthorReason:       uint32(r.BadAuthorReason),
+		RewardedFlips:         r.RewardedFlips,
+		RewardedReports:       r.RewardedReports,
+		Reward:                common.BigIntBytesOrNil(r.Reward),
+		Penalty:               common.BigIntBytesOrNil(r.Penalty),
+	}
+	return proto.Marshal(protoObj)
+}
+
+func (r *ValidationReport) FromBytes(data []byte) error {
+	protoObj := new(models.ProtoValidationReport)
+	if err := proto.Unmarshal(data, protoObj); err != nil {
+		return err
+	}
+	r.Epoch = uint16(protoObj.Epoch)
+	r.Failed = protoObj.Failed
+	r.Candidate = protoObj.Candidate
+	r.PrevState = uint8(protoObj.PrevState)
+	r.NewState = uint8(protoObj.NewState)
+	r.Approved = protoObj.Approved
+	r.Missed = protoObj.Missed
+	r.MissedReason = ValidationMissedReason(protoObj.MissedReason)
+	r.ShortFlipsToSolve = protoObj.ShortFlipsToSolve
+	r.LongFlipsToSolve = protoObj.LongFlipsToSolve
+	r.ShortPoint = protoObj.ShortPoint
+	r.ShortQualifiedFlips = protoObj.ShortQualifiedFlips
+	r.LongPoint = protoObj.LongPoint
+	r.LongQualifiedFlips = protoObj.LongQualifiedFlips
+	r.ShortScore = protoObj.ShortScore
+	r.LongScore = protoObj.LongScore
+	r.TotalScore = protoObj.TotalScore
+	r.TotalQualifiedFlips = protoObj.TotalQualifiedFlips
+	r.NoQualifiedShortFlips = protoObj.NoQualifiedShortFlips
+	r.NoQualifiedLongFlips = protoObj.NoQualifiedLongFlips
+	r.BadAuthor = protoObj.BadAuthor
+	r.BadAuthorReason = BadAuthorReason(protoObj.BadAuthorReason)
+	r.RewardedFlips = protoObj.RewardedFlips
+	r.RewardedReports = protoObj.RewardedReports
+	r.Reward = common.BigIntOrNil(protoObj.Reward)
+	r.Penalty = common.BigIntOrNil(protoObj.Penalty)
+	return nil
+}
+
+type OwnInviteStatus = byte
+
+const (
+	OwnInvitePending   OwnInviteStatus = 0
+	OwnInviteSent      OwnInviteStatus = 1
+	OwnInviteActivated OwnInviteStatus = 2
+	OwnInviteKilled    OwnInviteStatus = 3
+	OwnInviteExpired   OwnInviteStatus = 4
+	OwnInviteFailed    OwnInviteStatus = 5
+	// OwnInviteUnknown is reported for the invite which identity is killed without the known activation or kill tx
+	OwnInviteUnknown OwnInviteStatus = 6
+)
+
+// IsFinalOwnInviteStatus returns true if the status of the invite isn't derived from the current state anymore
+func IsFinalOwnInviteStatus(status OwnInviteStatus) bool {
+	return status == OwnInviteActivated || status == OwnInviteKilled || status == OwnInviteExpired ||
+		status == OwnInviteFailed
+}
+
+// OwnInvite is the invite created by one of the local accounts
+type OwnInvite struct {
+	Inviter    common.Address
+	Receiver   common.Address
+	Key        []byte
+	TxHash     common.Hash
+	Epoch      uint16
+	Amount     *big.Int
+	Timestamp  int64
+	KillTxHash *common.Hash
+	Status     OwnInviteStatus
+	// ActivationTxHash and Activated are set by the indexer when the activation tx of the invite is mined
+	ActivationTxHash *common.Hash
+	Activated        *common.Address
+}
+
+func (i *OwnInvite) ToBytes() ([]byte, error) {
+	protoObj := &models.ProtoOwnInvite{
+		Inviter:   i.Inviter.Bytes(),
+		Receiver:  i.Receiver.Bytes(),
+		Key:       i.Key,
+		TxHash:    i.TxHash.Bytes(),
+		Epoch:     uint32(i.Epoch),
+		Amount:    common.BigIntBytesOrNil(i.Amount),
+		Timestamp: i.Timestamp,
+		Status:    uint32(i.Status),
+	}
+	if i.KillTxHash != nil {
+		protoObj.KillTxHash = i.KillTxHash.Bytes()
+	}
+	if i.ActivationTxHash != nil {
+		protoObj.ActivationTxHash = i.ActivationTxHash.Bytes()
+	}
+	if i.Activated != nil {
+		protoObj.Activated = i.Activated.Bytes()
+	}
+	return proto.Marshal(protoObj)
+}
+
+func (i *OwnInvite) FromBytes(data []byte) error {
+	protoObj := new(models.ProtoOwnInvite)
+	if err := proto.Unmarshal(data, protoObj); err != nil {
+		return err
+	}
+	i.Inviter = common.BytesToAddress(protoObj.Inviter)
+	i.Receiver = common.BytesToAddress(protoObj.Receiver)
+	i.Key = protoObj.Key
+	i.TxHash = common.BytesToHash(protoObj.TxHash)
+	i.Epoch = uint16(protoObj.Epoch)
+	i.Amount = common.BigIntOrNil(protoObj.Amount)
+	i.Timestamp = protoObj.Timestamp
+	if len(protoObj.KillTxHash) > 0 {
+		hash := common.BytesToHash(protoObj.KillTxHash)
+		i.KillTxHash = &hash
+	}
+	if len(protoObj.ActivationTxHash) > 0 {
+		hash := common.BytesToHash(protoObj.ActivationTxHash)
+		i.ActivationTxHash = &hash
+	}
+	if len(protoObj.Activated) > 0 {
+		addr := common.BytesToAddress(protoObj.Activated)
+		i.Activated = &addr
+	}
+	i.Status = OwnInviteStatus(protoObj.Status)
+	return nil
+}
+
 type TransactionIndex struct {
 	BlockHash common.Hash
 	// tx index in block's body
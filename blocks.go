@@ -0,0 +1,87 @@
+package main
+
+import (
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"os"
+)
+
+var (
+	blocksFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "First block height to export, genesis by default",
+	}
+	blocksToFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Last block height to export, head by default",
+	}
+	blocksFileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "Path of the blocks archive (tar.gz)",
+	}
+
+	exportBlocksCommand = cli.Command{
+		Name:   "export-blocks",
+		Usage:  "Export canonical blocks with bodies and certificates of the stopped node to a compressed file",
+		Flags:  []cli.Flag{blocksFromFlag, blocksToFlag, blocksFileFlag},
+		Action: exportBlocks,
+	}
+	importBlocksCommand = cli.Command{
+		Name:   "import-blocks",
+		Usage:  "Validate and apply blocks from a file created by export-blocks on top of the local chain",
+		Flags:  []cli.Flag{blocksFileFlag},
+		Action: importBlocks,
+	}
+)
+
+// makeCommandConfig builds the node config from global flags, which have to be set before the command name
+func makeCommandConfig(context *cli.Context) (*config.Config, error) {
+	handler := log.LvlFilterHandler(log.Lvl(context.GlobalInt(config.VerbosityFlag.Name)), log.StreamHandler(os.Stdout, log.TerminalFormat(true)))
+	log.Root().SetHandler(handler)
+	return config.MakeConfig(context.Parent(), transformConsensusConfig)
+}
+
+func exportBlocks(context *cli.Context) error {
+	fileName := context.String(blocksFileFlag.Name)
+	if fileName == "" {
+		return errors.New("file option is required")
+	}
+	cfg, err := makeCommandConfig(context)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	exported, err := node.ExportBlocks(cfg, context.Uint64(blocksFromFlag.Name), context.Uint64(blocksToFlag.Name), file)
+	if err != nil {
+		file.Close()
+		os.Remove(fileName)
+		return err
+	}
+	log.Info("Blocks exported", "count", exported, "file", fileName)
+	return nil
+}
+
+func importBlocks(context *cli.Context) error {
+	fileName := context.String(blocksFileFlag.Name)
+	if fileName == "" {
+		return errors.New("file option is required")
+	}
+	cfg, err := makeCommandConfig(context)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = node.ImportBlocks(cfg, file)
+	return err
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/core/ceremony"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tm-db"
+	"github.com/urfave/cli"
+	"os"
+	"runtime"
+)
+
+var (
+	epochFlag = cli.UintFlag{
+		Name:  "epoch",
+		Usage: "Epoch which validation ceremony should be replayed",
+	}
+	epochDbFlag = cli.BoolFlag{
+		Name:  "epochdb",
+		Usage: "Also read ceremony data which is absent in blocks from the epoch db retained by Validation.KeepFinishedEpochDb",
+	}
+)
+
+type identityResult struct {
+	Address           common.Address `json:"address"`
+	ShardId           common.ShardId `json:"shardId"`
+	Candidate         bool           `json:"candidate"`
+	PrevState         string         `json:"prevState"`
+	NewState          string         `json:"newState"`
+	Birthday          uint16         `json:"birthday"`
+	ShortFlipsToSolve int            `json:"shortFlipsToSolve"`
+	LongFlipsToSolve  int            `json:"longFlipsToSolve"`
+	ShortPoint        float32        `json:"shortPoint"`
+	ShortFlips        uint32         `json:"shortFlips"`
+	LongPoint         float32        `json:"longPoint"`
+	LongFlips         uint32         `json:"longFlips"`
+	Approved          bool           `json:"approved"`
+	Missed            bool           `json:"missed"`
+	Reasons           []string       `json:"reasons,omitempty"`
+	ActualState       string         `json:"actualState,omitempty"`
+	ActualValidated   bool           `json:"actualValidated"`
+	Mismatch          bool           `json:"mismatch"`
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Usage = "Replays validation ceremony of the finished epoch from blocks of the epoch and compares its results " +
+		"with the applied ones, the node should be stopped"
+
+	app.Flags = []cli.Flag{
+		config.CfgFileFlag,
+		config.DataDirFlag,
+		config.DbBackendFlag,
+		config.VerbosityFlag,
+		epochFlag,
+		epochDbFlag,
+	}
+
+	app.Action = func(context *cli.Context) error {
+		logLvl := log.Lvl(context.Int("verbosity"))
+
+		var handler log.Handler
+		if runtime.GOOS == "windows" {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stderr, log.LogfmtFormat()))
+		} else {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stderr, log.TerminalFormat(true)))
+		}
+		log.Root().SetHandler(handler)
+
+		if !context.IsSet(config.DataDirFlag.Name) {
+			return errors.New("datadir option is required")
+		}
+		if !context.IsSet(epochFlag.Name) {
+			return errors.New("epoch option is required")
+		}
+		epoch := uint16(context.Uint(epochFlag.Name))
+		nodeCfg, err := config.MakeConfig(context, func(cfg *config.Config) {})
+		if err != nil {
+			return err
+		}
+
+		db, err := node.OpenOfflineDatabase(nodeCfg.DataDir, "idenachain", context.String(config.DbBackendFlag.Name))
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		ipfsProxy, stopIpfs, err := ipfs.NewOfflineIpfsProxy(nodeCfg.IpfsConf)
+		if err != nil {
+			return errors.Wrap(err, "failed to open ipfs repo")
+		}
+		defer stopIpfs()
+		// replay changes state and epoch db, nothing should be written to the node database
+		memDb := database.NewBackedMemDb(db)
+		repo := database.NewRepo(db)
+
+		head := repo.ReadHead()
+		if head == nil {
+			return errors.New("head is not found")
+		}
+		height, err := findValidationBlock(memDb, head.Height(), epoch)
+		if err != nil {
+			return err
+		}
+		header := repo.ReadBlockHeader(repo.ReadCanonicalHash(height))
+		if header == nil || !header.Flags().HasFlag(types.ValidationFinished) {
+			return errors.Errorf("block %v is not found or has no validation finished flag", height)
+		}
+
+		appState, err := appstate.NewAppState(memDb, eventbus.New())
+		if err != nil {
+			return err
+		}
+		if err := appState.Initialize(height - 1); err != nil {
+			return errors.Wrapf(err, "state at height %v is not available, it may have been pruned", height-1)
+		}
+		prevValidated := make(map[common.Address]bool)
+		appState.State.IterateIdentities(func(key []byte, value []byte) bool {
+			if key == nil {
+				return true
+			}
+			addr := common.BytesToAddress(key[1:])
+			prevValidated[addr] = appState.IdentityState.IsValidated(addr)
+			return false
+		})
+
+		data, err := ceremony.ReadCeremonyData(repo, ipfsProxy, blockchain.GenesisHeight(repo, nodeCfg.Network), appState.State.EpochBlock(), height)
+		if err != nil {
+			return err
+		}
+		lotteryState, err := appstate.NewAppState(memDb, eventbus.New())
+		if err != nil {
+			return err
+		}
+		if err := lotteryState.Initialize(data.LotteryHeight); err != nil {
+			log.Warn("State at the flip lottery block is not available, lottery identities are taken from the state before validation", "height", data.LotteryHeight)
+		} else {
+			data.LotteryIdentities = ceremony.LotteryIdentities(lotteryState)
+		}
+		var epochDb *database.EpochDb
+		if context.Bool(epochDbFlag.Name) {
+			epochDb = database.NewEpochDb(memDb, epoch)
+		}
+
+		cfg := &config.Config{
+			Consensus:  consensusConfig(repo),
+			Validation: &config.ValidationConfig{},
+		}
+		replay, err := ceremony.ReplayCeremony(cfg, appState, data, epochDb, height)
+		if err != nil {
+			return err
+		}
+
+		actualStates := readActualStates(memDb, height)
+		actualValidated, err := readValidatedDiff(repo, height)
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		mismatches := 0
+		for _, identity := range replay.Identities {
+			res := toIdentityResult(identity, replay.Failed)
+			if actual, ok := actualStates[identity.Address]; ok {
+				res.ActualState = actual.String()
+				if !sameState(identity.NewState, actual) {
+					res.Mismatch = true
+				}
+			}
+			validated, ok := actualValidated[identity.Address]
+			if !ok {
+				validated = prevValidated[identity.Address]
+			}
+			res.ActualValidated = validated
+			if !replay.Failed && identity.NewState.NewbieOrBetter() != validated {
+				res.Mismatch = true
+			}
+			if res.Mismatch {
+				mismatches++
+			}
+			if err := encoder.Encode(res); err != nil {
+				return err
+			}
+		}
+		log.Info("Ceremony replayed", "epoch", replay.Epoch, "height", height, "failed", replay.Failed,
+			"identities", len(replay.Identities), "mismatches", mismatches)
+		return nil
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+// findValidationBlock returns height of the block which has finished validation ceremony of the given epoch
+func findValidationBlock(chainDb db.DB, headHeight uint64, epoch uint16) (uint64, error) {
+	appState, err := appstate.NewAppState(chainDb, eventbus.New())
+	if err != nil {
+		return 0, err
+	}
+	if err := appState.Initialize(headHeight); err != nil {
+		return 0, err
+	}
+	currentEpoch := appState.State.Epoch()
+	if epoch >= currentEpoch {
+		return 0, errors.Errorf("epoch %v is not finished yet, current epoch is %v", epoch, currentEpoch)
+	}
+	if epoch == currentEpoch-1 {
+		return appState.State.EpochBlock(), nil
+	}
+	prevEpochBlocks := appState.State.PrevEpochBlocks()
+	idx := len(prevEpochBlocks) - int(currentEpoch-1-epoch)
+	if idx < 0 {
+		return 0, errors.Errorf("epoch %v is too old", epoch)
+	}
+	return prevEpochBlocks[idx], nil
+}
+
+func consensusConfig(repo *database.Repo) *config.ConsensusConf {
+	cfg := *config.GetDefaultConsensusConfig()
+	consVersion := repo.ReadConsensusVersion()
+	for v := cfg.Version + 1; v <= config.ConsensusVerson(consVersion); v++ {
+		config.ApplyConsensusVersion(v, &cfg)
+	}
+	return &cfg
+}
+
+// readActualStates returns identity states applied by the block, nil if the state is not available
+func readActualStates(chainDb db.DB, height uint64) map[common.Address]state.IdentityState {
+	appState, err := appstate.NewAppState(chainDb, eventbus.New())
+	if err != nil {
+		return nil
+	}
+	if err := appState.Initialize(height); err != nil {
+		log.Warn("State after validation is not available, only identity state diff will be compared", "height", height)
+		return nil
+	}
+	result := make(map[common.Address]state.IdentityState)
+	appState.State.IterateIdentities(func(key []byte, value []byte) bool {
+		if key == nil {
+			return true
+		}
+		var data state.Identity
+		if err := data.FromBytes(value); err != nil {
+			return false
+		}
+		result[common.BytesToAddress(key[1:])] = data.State
+		return false
+	})
+	return result
+}
+
+func readValidatedDiff(repo *database.Repo, height uint64) (map[common.Address]bool, error) {
+	result := make(map[common.Address]bool)
+	data := repo.ReadIdentityStateDiff(height)
+	if data == nil {
+		return result, nil
+	}
+	diff := new(state.IdentityStateDiff)
+	if err := diff.FromBytes(data); err != nil {
+		return nil, errors.Wrap(err, "failed to read identity state diff")
+	}
+	for _, value := range diff.Values {
+		if value.Deleted {
+			result[value.Address] = false
+			continue
+		}
+		var identity state.ApprovedIdentity
+		if err := identity.FromBytes(value.Value); err != nil {
+			return nil, errors.Wrap(err, "failed to read identity state diff")
+		}
+		result[value.Address] = identity.Validated
+	}
+	return result, nil
+}
+
+func sameState(expected, actual state.IdentityState) bool {
+	// data of killed identities is cleared after validation
+	if expected == state.Killed || expected == state.Undefined {
+		return actual == state.Killed || actual == state.Undefined
+	}
+	return expected == actual
+}
+
+func toIdentityResult(identity *ceremony.ReplayedIdentity, failed bool) *identityResult {
+	res := &identityResult{
+		Address:           identity.Address,
+		ShardId:           identity.ShardId,
+		Candidate:         identity.Candidate,
+		PrevState:         identity.PrevState.String(),
+		NewState:          identity.NewState.String(),
+		Birthday:          identity.Birthday,
+		ShortFlipsToSolve: identity.ShortFlipsToSolve,
+		LongFlipsToSolve:  identity.LongFlipsToSolve,
+		ShortPoint:        identity.ShortPoint,
+		ShortFlips:        identity.ShortFlips,
+		LongPoint:         identity.LongPoint,
+		LongFlips:         identity.LongFlips,
+		Approved:          identity.Approved,
+		Missed:            identity.Missed,
+	}
+	if failed {
+		res.Reasons = append(res.Reasons, "validationFailed")
+	}
+	if !identity.Candidate {
+		res.Reasons = append(res.Reasons, "notCandidate")
+		return res
+	}
+	if !identity.Approved {
+		res.Reasons = append(res.Reasons, "notApprovedByEvidence")
+	} else if identity.Missed {
+		res.Reasons = append(res.Reasons, "noAnswers")
+	}
+	if identity.BadAuthor {
+		res.Reasons = append(res.Reasons, fmt.Sprintf("badAuthor:%v", convertBadAuthorReason(identity.BadAuthorReason)))
+	}
+	return res
+}
+
+func convertBadAuthorReason(reason types.BadAuthorReason) string {
+	switch reason {
+	case types.NoQualifiedFlipsBadAuthor:
+		return "noQualifiedFlips"
+	case types.QualifiedByNoneBadAuthor:
+		return "qualifiedByNone"
+	case types.WrongWordsBadAuthor:
+		return "wrongWords"
+	default:
+		return fmt.Sprintf("%v", reason)
+	}
+}
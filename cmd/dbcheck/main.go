@@ -0,0 +1,549 @@
+package main
+
+import (
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+	"github.com/urfave/cli"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	repairReindex     = "reindex"
+	repairRollback    = "rollback"
+	repairSnapshots   = "snapshots"
+	repairPreliminary = "preliminary"
+)
+
+var (
+	bodiesFlag = cli.BoolFlag{
+		Name:  "bodies",
+		Usage: "Read block bodies and receipts from the local IPFS repo to verify tx and receipt indexes completely",
+	}
+	repairFlag = cli.StringFlag{
+		Name: "repair",
+		Usage: "Comma separated list of repair actions: reindex (fix tx and receipt indexes), rollback (reset to the last " +
+			"consistent height), snapshots (drop unused state trees and broken snapshot), preliminary (drop preliminary data)",
+	}
+)
+
+type checker struct {
+	db       dbm.DB
+	repo     *database.Repo
+	ipfs     ipfs.Proxy
+	repair   map[string]bool
+	problems int
+	repaired int
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Usage = "Verifies integrity of the stopped node database and optionally repairs it"
+
+	app.Flags = []cli.Flag{
+		config.DataDirFlag,
+		config.DbBackendFlag,
+		config.VerbosityFlag,
+		bodiesFlag,
+		repairFlag,
+	}
+
+	app.Action = func(context *cli.Context) error {
+		logLvl := log.Lvl(context.Int("verbosity"))
+		var handler log.Handler
+		if runtime.GOOS == "windows" {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stdout, log.LogfmtFormat()))
+		} else {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stderr, log.TerminalFormat(true)))
+		}
+		log.Root().SetHandler(handler)
+
+		if !context.IsSet(config.DataDirFlag.Name) {
+			return errors.New("datadir option is required")
+		}
+		dataDir := context.String(config.DataDirFlag.Name)
+
+		repair := make(map[string]bool)
+		if actions := context.String(repairFlag.Name); actions != "" {
+			for _, action := range strings.Split(actions, ",") {
+				action = strings.TrimSpace(action)
+				switch action {
+				case repairReindex, repairRollback, repairSnapshots, repairPreliminary:
+					repair[action] = true
+				default:
+					return errors.Errorf("unknown repair action %v", action)
+				}
+			}
+		}
+
+		db, err := node.OpenOfflineDatabase(dataDir, "idenachain", context.String(config.DbBackendFlag.Name))
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		c := &checker{
+			db:     db,
+			repo:   database.NewRepo(db),
+			repair: repair,
+		}
+		if context.Bool(bodiesFlag.Name) {
+			ipfsConf := config.GetDefaultIpfsConfig()
+			ipfsConf.DataDir = filepath.Join(dataDir, config.DefaultIpfsDataDir)
+			proxy, stop, err := ipfs.NewOfflineIpfsProxy(ipfsConf)
+			if err != nil {
+				return errors.Wrap(err, "failed to open ipfs repo")
+			}
+			defer stop()
+			c.ipfs = proxy
+		}
+		if err := c.run(dataDir); err != nil {
+			return err
+		}
+		if c.problems > 0 {
+			log.Warn("Database check completed", "problems", c.problems, "repaired", c.repaired)
+			if c.repaired < c.problems {
+				return errors.Errorf("%v problems found", c.problems)
+			}
+			return nil
+		}
+		log.Info("Database check completed, no problems found")
+		return nil
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func (c *checker) problem(msg string, ctx ...interface{}) {
+	c.problems++
+	log.Warn(msg, ctx...)
+}
+
+func (c *checker) fixed(msg string, ctx ...interface{}) {
+	c.repaired++
+	log.Info(msg, ctx...)
+}
+
+func (c *checker) run(dataDir string) error {
+	head := c.repo.ReadHead()
+	if head == nil {
+		return errors.New("head is not found, the database is empty or corrupted")
+	}
+	log.Info("Checking database", "head", head.Height())
+
+	c.checkPreliminary()
+	if err := c.checkStateTrees(); err != nil {
+		return err
+	}
+	c.checkSnapshotManifest(dataDir)
+
+	linked := c.checkChain(head)
+	consistent, err := c.checkStateRoots(head, linked)
+	if err != nil {
+		return err
+	}
+	if consistent < head.Height() {
+		c.problem("Head is inconsistent", "head", head.Height(), "lastConsistentHeight", consistent)
+		if c.repair[repairRollback] {
+			if consistent == 0 {
+				return errors.New("there is no consistent height to roll back to, sync from scratch is required")
+			}
+			if err := c.rollback(head.Height(), consistent); err != nil {
+				return err
+			}
+			c.fixed("Rolled back to the last consistent height", "height", consistent)
+			head = c.repo.ReadHead()
+		}
+	}
+
+	c.checkTxIndexes(head)
+	c.checkReceiptIndexes()
+	if c.ipfs != nil {
+		c.checkBodies(head)
+	}
+	return nil
+}
+
+// checkPreliminary reports data of interrupted fast sync which has not been switched to
+func (c *checker) checkPreliminary() {
+	preliminaryHead := c.repo.ReadPreliminaryHead()
+	identityPrefix, _ := state.IdentityStateDbKeys.ReadDbPrefix(c.db, true)
+	consensusVersion := c.repo.ReadPreliminaryConsensusVersion()
+	intermediateGenesis := c.repo.ReadPreliminaryIntermediateGenesis()
+	if preliminaryHead == nil && len(identityPrefix) == 0 && consensusVersion == 0 && intermediateGenesis == 0 {
+		return
+	}
+	var preliminaryHeight uint64
+	if preliminaryHead != nil {
+		preliminaryHeight = preliminaryHead.Height()
+	}
+	c.problem("Dangling preliminary data is found", "head", preliminaryHeight, "identityTree", len(identityPrefix) > 0,
+		"consensusVersion", consensusVersion, "intermediateGenesis", intermediateGenesis)
+	if !c.repair[repairPreliminary] {
+		return
+	}
+	identityStateDb, err := state.NewLazyIdentityState(c.db)
+	if err != nil {
+		log.Error("Failed to open identity state", "err", err)
+		return
+	}
+	identityStateDb.DropPreliminary()
+	c.repo.RemovePreliminaryHead(nil)
+	c.repo.RemovePreliminaryConsensusVersion(nil)
+	c.repo.RemovePreliminaryIntermediateGenesis(nil)
+	c.fixed("Preliminary data is dropped")
+}
+
+// checkStateTrees reports trees which are neither current nor preliminary, they are left by interrupted snapshot switching
+func (c *checker) checkStateTrees() error {
+	current, err := state.StateDbKeys.ReadDbPrefix(c.db)
+	if err != nil {
+		return err
+	}
+	prefixes, err := state.StateDbKeys.StoredDbPrefixes(c.db)
+	if err != nil {
+		return err
+	}
+	used := [][]byte{current}
+	c.checkUnusedTrees("state", prefixes, used)
+
+	current, err = state.IdentityStateDbKeys.ReadDbPrefix(c.db, false)
+	if err != nil {
+		return err
+	}
+	used = [][]byte{current}
+	if preliminary, _ := state.IdentityStateDbKeys.ReadDbPrefix(c.db, true); len(preliminary) > 0 && !c.repair[repairPreliminary] {
+		used = append(used, preliminary)
+	}
+	prefixes, err = state.IdentityStateDbKeys.StoredDbPrefixes(c.db)
+	if err != nil {
+		return err
+	}
+	c.checkUnusedTrees("identity state", prefixes, used)
+	return nil
+}
+
+func (c *checker) checkUnusedTrees(name string, prefixes [][]byte, used [][]byte) {
+	isUsed := func(prefix []byte) bool {
+		for _, p := range used {
+			if string(p) == string(prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, prefix := range prefixes {
+		if isUsed(prefix) {
+			continue
+		}
+		c.problem("Unused "+name+" tree is found", "prefix", common.ToHex(prefix))
+		if c.repair[repairSnapshots] {
+			if err := common.ClearDb(dbm.NewPrefixDB(c.db, prefix)); err != nil {
+				log.Error("Failed to drop tree", "prefix", common.ToHex(prefix), "err", err)
+				continue
+			}
+			c.fixed("Unused "+name+" tree is dropped", "prefix", common.ToHex(prefix))
+		}
+	}
+}
+
+// checkSnapshotManifest verifies that the last created snapshot file exists and matches the manifest root
+func (c *checker) checkSnapshotManifest(dataDir string) {
+	cid, root, height, fileName := c.repo.LastSnapshotManifest()
+	if cid == nil {
+		return
+	}
+	if !filepath.IsAbs(fileName) {
+		fileName = filepath.Join(dataDir, fileName)
+	}
+	err := func() error {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		pdb := dbm.NewPrefixDB(dbm.NewMemDB(), nil)
+		return state.ReadTreeFrom2(pdb, height, root, file)
+	}()
+	if err == nil {
+		return
+	}
+	c.problem("Last snapshot is broken", "height", height, "file", fileName, "err", err)
+	if c.repair[repairSnapshots] {
+		c.repo.RemoveLastSnapshotManifest()
+		os.Remove(fileName)
+		c.fixed("Broken snapshot is dropped", "height", height)
+	}
+}
+
+// checkChain walks canonical headers from genesis or the restored snapshot to head and returns the last height which is linked with genesis
+func (c *checker) checkChain(head *types.Header) (linked uint64) {
+	from := c.repo.ReadIntermediateGenesis()
+	if from == 0 {
+		from = 1
+	}
+	if restored := c.repo.ReadRestoredSnapshotHeight(); restored > from {
+		from = restored
+	}
+	var prevHash common.Hash
+	broken := false
+	for h := from; h <= head.Height(); h++ {
+		hash := c.repo.ReadCanonicalHash(h)
+		if hash == (common.Hash{}) {
+			c.problem("Canonical hash is missing", "height", h)
+			broken = true
+			continue
+		}
+		header := c.repo.ReadBlockHeader(hash)
+		if header == nil {
+			c.problem("Block header is missing", "height", h, "hash", hash.Hex())
+			broken = true
+			prevHash = common.Hash{}
+			continue
+		}
+		ok := true
+		if header.Height() != h || header.Hash() != hash {
+			c.problem("Block header doesn't match canonical hash", "height", h, "hash", hash.Hex())
+			ok = false
+		}
+		if h > from && prevHash != (common.Hash{}) && header.ParentHash() != prevHash {
+			c.problem("Block header is not linked to the previous block", "height", h, "hash", hash.Hex())
+			ok = false
+		}
+		if cert := c.repo.ReadCertificate(hash); cert != nil && (cert.Round != h || cert.VotedHash != hash) {
+			c.problem("Certificate doesn't match the block", "height", h, "hash", hash.Hex())
+		}
+		if !ok {
+			broken = true
+		}
+		if !broken {
+			linked = h
+		}
+		prevHash = hash
+	}
+	if head.Hash() != c.repo.ReadCanonicalHash(head.Height()) {
+		c.problem("Head doesn't match canonical hash", "height", head.Height())
+		if linked == head.Height() {
+			linked--
+		}
+	}
+	for h := head.Height() + 1; c.repo.ReadCanonicalHash(h) != (common.Hash{}); h++ {
+		c.problem("Canonical hash above head is found", "height", h)
+		if c.repair[repairRollback] {
+			c.repo.RemoveHeader(c.repo.ReadCanonicalHash(h))
+			c.repo.RemoveCanonicalHash(h)
+			c.fixed("Canonical hash above head is removed", "height", h)
+		}
+	}
+	return linked
+}
+
+// checkStateRoots compares roots of retained state versions with headers and returns the last consistent height
+func (c *checker) checkStateRoots(head *types.Header, linked uint64) (consistent uint64, err error) {
+	stateDb, err := state.NewLazy(c.db)
+	if err != nil {
+		return 0, err
+	}
+	identityStateDb, err := state.NewLazyIdentityState(c.db)
+	if err != nil {
+		return 0, err
+	}
+	from := uint64(1)
+	if head.Height() > state.MaxSavedStatesCount {
+		from = head.Height() - state.MaxSavedStatesCount
+	}
+	for h := from; h <= linked; h++ {
+		hasState, hasIdentityState := stateDb.HasVersion(h), identityStateDb.HasVersion(h)
+		if !hasState || !hasIdentityState {
+			if h == head.Height() {
+				c.problem("State of head is missing", "height", h, "state", hasState, "identityState", hasIdentityState)
+			}
+			continue
+		}
+		header := c.repo.ReadBlockHeader(c.repo.ReadCanonicalHash(h))
+		versionState, err := stateDb.Readonly(int64(h))
+		if err != nil {
+			c.problem("State version cannot be loaded", "height", h, "err", err)
+			continue
+		}
+		versionIdentityState, err := identityStateDb.Readonly(h)
+		if err != nil {
+			c.problem("Identity state version cannot be loaded", "height", h, "err", err)
+			continue
+		}
+		if versionState.Root() != header.Root() {
+			c.problem("State root doesn't match header", "height", h, "header", header.Root().Hex(),
+				"state", versionState.Root().Hex())
+			continue
+		}
+		if versionIdentityState.Root() != header.IdentityRoot() {
+			c.problem("Identity state root doesn't match header", "height", h, "header", header.IdentityRoot().Hex(),
+				"state", versionIdentityState.Root().Hex())
+			continue
+		}
+		consistent = h
+	}
+	return consistent, nil
+}
+
+// rollback resets state trees and head to the height and removes headers above it
+func (c *checker) rollback(headHeight uint64, height uint64) error {
+	stateDb, err := state.NewLazy(c.db)
+	if err != nil {
+		return err
+	}
+	identityStateDb, err := state.NewLazyIdentityState(c.db)
+	if err != nil {
+		return err
+	}
+	if err := stateDb.ResetTo(height); err != nil {
+		return errors.Wrap(err, "failed to reset state")
+	}
+	if err := identityStateDb.ResetTo(height); err != nil {
+		return errors.Wrap(err, "failed to reset identity state")
+	}
+	batch := c.db.NewBatch()
+	defer batch.Close()
+	c.repo.SetHead(batch, height)
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+	for h := height + 1; h <= headHeight; h++ {
+		hash := c.repo.ReadCanonicalHash(h)
+		if hash == (common.Hash{}) {
+			continue
+		}
+		c.repo.RemoveHeader(hash)
+		c.repo.RemoveCanonicalHash(h)
+	}
+	return nil
+}
+
+// isCanonical checks that the block is in the canonical chain not above head
+func (c *checker) isCanonical(head *types.Header, blockHash common.Hash) bool {
+	header := c.repo.ReadBlockHeader(blockHash)
+	return header != nil && header.Height() <= head.Height() && c.repo.ReadCanonicalHash(header.Height()) == blockHash
+}
+
+// checkTxIndexes reports indexes of transactions from blocks which are not canonical anymore
+func (c *checker) checkTxIndexes(head *types.Header) {
+	var stale []common.Hash
+	total := 0
+	c.repo.IterateTxIndexes(func(txHash common.Hash, index *types.TransactionIndex) bool {
+		total++
+		if index == nil || !c.isCanonical(head, index.BlockHash) {
+			stale = append(stale, txHash)
+		}
+		return false
+	})
+	log.Info("Tx indexes checked", "total", total, "stale", len(stale))
+	for _, txHash := range stale {
+		c.problem("Tx index refers to non-canonical block", "tx", txHash.Hex())
+		if c.repair[repairReindex] {
+			c.repo.RemoveTxIndex(nil, txHash)
+			c.repo.RemoveReceiptIndex(nil, txHash)
+			c.fixed("Stale tx index is removed", "tx", txHash.Hex())
+		}
+	}
+}
+
+// checkReceiptIndexes reports receipt indexes of transactions which are not indexed
+func (c *checker) checkReceiptIndexes() {
+	var stale []common.Hash
+	total := 0
+	c.repo.IterateReceiptIndexes(func(txHash common.Hash, index *types.TxReceiptIndex) bool {
+		total++
+		if index == nil || c.repo.ReadTxIndex(txHash) == nil {
+			stale = append(stale, txHash)
+		}
+		return false
+	})
+	log.Info("Receipt indexes checked", "total", total, "stale", len(stale))
+	for _, txHash := range stale {
+		c.problem("Receipt index refers to unknown tx", "tx", txHash.Hex())
+		if c.repair[repairReindex] {
+			c.repo.RemoveReceiptIndex(nil, txHash)
+			c.fixed("Stale receipt index is removed", "tx", txHash.Hex())
+		}
+	}
+}
+
+// checkBodies verifies that every transaction of canonical blocks is indexed, bodies are read from the local IPFS repo
+func (c *checker) checkBodies(head *types.Header) {
+	from := c.repo.ReadIntermediateGenesis()
+	if from == 0 {
+		from = 1
+	}
+	if horizon := c.repo.ReadPrunedHorizon(); horizon > from {
+		from = horizon
+	}
+	missingBodies := 0
+	for h := from; h <= head.Height(); h++ {
+		hash := c.repo.ReadCanonicalHash(h)
+		header := c.repo.ReadBlockHeader(hash)
+		if header == nil || header.EmptyBlockHeader != nil {
+			continue
+		}
+		bodyBytes, err := c.ipfs.Get(header.ProposedHeader.IpfsHash, ipfs.Block)
+		if err != nil {
+			missingBodies++
+			log.Debug("Block body is not available", "height", h, "err", err)
+			continue
+		}
+		body := &types.Body{}
+		body.FromBytes(bodyBytes)
+		for i, tx := range body.Transactions {
+			index := c.repo.ReadTxIndex(tx.Hash())
+			if index != nil && index.BlockHash == hash && index.Idx == uint32(i) {
+				continue
+			}
+			c.problem("Tx index is missing or wrong", "height", h, "tx", tx.Hash().Hex())
+			if c.repair[repairReindex] {
+				c.repo.WriteTxIndex(tx.Hash(), &types.TransactionIndex{BlockHash: hash, Idx: uint32(i)})
+				c.fixed("Tx index is written", "tx", tx.Hash().Hex())
+			}
+		}
+		c.checkBlockReceipts(header)
+	}
+	if missingBodies > 0 {
+		log.Warn("Some block bodies are not stored locally, their transactions are not verified", "count", missingBodies)
+	}
+}
+
+func (c *checker) checkBlockReceipts(header *types.Header) {
+	receiptsCid := header.ProposedHeader.TxReceiptsCid
+	if len(receiptsCid) == 0 {
+		return
+	}
+	data, err := c.ipfs.Get(receiptsCid, ipfs.TxReceipt)
+	if err != nil {
+		log.Debug("Block receipts are not available", "height", header.Height(), "err", err)
+		return
+	}
+	receipts := types.TxReceipts{}.FromBytes(data)
+	for i, receipt := range receipts {
+		index := c.repo.ReadReceiptIndex(receipt.TxHash)
+		if index != nil && index.Idx == uint32(i) && string(index.ReceiptCid) == string(receiptsCid) {
+			continue
+		}
+		c.problem("Receipt index is missing or wrong", "height", header.Height(), "tx", receipt.TxHash.Hex())
+		if c.repair[repairReindex] {
+			c.repo.WriteReceiptIndex(receipt.TxHash, &types.TxReceiptIndex{ReceiptCid: receiptsCid, Idx: uint32(i)})
+			c.fixed("Receipt index is written", "tx", receipt.TxHash.Hex())
+		}
+	}
+}
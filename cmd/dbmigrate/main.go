@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/tendermint/tm-db"
+)
+
+const dbName = "idenachain"
+
+var (
+	fromFlag = cli.StringFlag{
+		Name:  "from",
+		Usage: "Backend of the existing database",
+		Value: config.GoLevelDbBackend,
+	}
+	toFlag = cli.StringFlag{
+		Name:  "to",
+		Usage: "Backend of the new database: goleveldb or boltdb",
+	}
+	batchSizeFlag = cli.IntFlag{
+		Name:  "batchsize",
+		Usage: "Size of write batch in bytes",
+		Value: 16 * 1024 * 1024,
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Usage = "Copies the chain database of the stopped node into another key-value store backend"
+
+	app.Flags = []cli.Flag{
+		config.DataDirFlag,
+		config.VerbosityFlag,
+		fromFlag,
+		toFlag,
+		batchSizeFlag,
+	}
+
+	app.Action = func(context *cli.Context) error {
+		logLvl := log.Lvl(context.Int("verbosity"))
+		var handler log.Handler
+		if runtime.GOOS == "windows" {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stdout, log.LogfmtFormat()))
+		} else {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stderr, log.TerminalFormat(true)))
+		}
+		log.Root().SetHandler(handler)
+
+		if !context.IsSet(config.DataDirFlag.Name) {
+			return errors.New("datadir option is required")
+		}
+		if !context.IsSet(toFlag.Name) {
+			return errors.New("to option is required")
+		}
+		dataDir := context.String(config.DataDirFlag.Name)
+		from, to := context.String(fromFlag.Name), context.String(toFlag.Name)
+		if from == to {
+			return errors.New("source and target backends are the same")
+		}
+		if _, err := os.Stat(node.DatabasePath(dataDir, dbName, from)); err != nil {
+			return errors.Errorf("%v database is not found in datadir", from)
+		}
+		if _, err := os.Stat(node.DatabasePath(dataDir, dbName, to)); err == nil {
+			return errors.Errorf("%v database already exists, remove %v to migrate again", to, node.DatabasePath(dataDir, dbName, to))
+		}
+
+		srcCfg := config.GetDefaultDatabaseConfig()
+		srcCfg.Backend = from
+		src, err := node.OpenDatabase(dataDir, dbName, srcCfg, false)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		dst, err := openTarget(dataDir, to)
+		if err != nil {
+			return err
+		}
+		defer dst.Close()
+
+		start := time.Now()
+		count, err := copyDatabase(src, dst, context.Int(batchSizeFlag.Name))
+		if err != nil {
+			return errors.Wrap(err, "failed to copy database")
+		}
+		log.Info("Database copied", "keys", count, "d", time.Since(start))
+		if err := verifyDatabase(src, dst); err != nil {
+			return errors.Wrap(err, "copied database doesn't match the source")
+		}
+		log.Info("Migration completed, set Database.Backend in the node config or use --dbbackend flag to start with the new database",
+			"backend", to, "path", node.DatabasePath(dataDir, dbName, to))
+		return nil
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func openTarget(dataDir string, backend string) (db.DB, error) {
+	res, err := db.NewDB(node.DatabaseName(dbName, backend), db.BackendType(backend), dataDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create %v database, check that the tool is built with %v tag", backend, backend)
+	}
+	return res, nil
+}
+
+func copyDatabase(src, dst db.DB, batchSize int) (count int, err error) {
+	it, err := src.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	batch := dst.NewBatch()
+	size := 0
+	logTicker := time.NewTicker(time.Second * 10)
+	defer logTicker.Stop()
+	for ; it.Valid(); it.Next() {
+		if err := batch.Set(it.Key(), it.Value()); err != nil {
+			batch.Close()
+			return count, err
+		}
+		count++
+		size += len(it.Key()) + len(it.Value())
+		if size >= batchSize {
+			if err := batch.Write(); err != nil {
+				batch.Close()
+				return count, err
+			}
+			batch.Close()
+			batch = dst.NewBatch()
+			size = 0
+		}
+		select {
+		case <-logTicker.C:
+			log.Info("Copying database", "keys", count)
+		default:
+		}
+	}
+	defer batch.Close()
+	if err := it.Error(); err != nil {
+		return count, err
+	}
+	return count, batch.WriteSync()
+}
+
+// verifyDatabase walks both databases simultaneously and compares every key and value
+func verifyDatabase(src, dst db.DB) error {
+	srcIt, err := src.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer srcIt.Close()
+	dstIt, err := dst.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer dstIt.Close()
+	for ; srcIt.Valid(); srcIt.Next() {
+		if !dstIt.Valid() {
+			return errors.Errorf("key %x is missing", srcIt.Key())
+		}
+		if !bytes.Equal(srcIt.Key(), dstIt.Key()) {
+			return errors.Errorf("key %x is expected, got %x", srcIt.Key(), dstIt.Key())
+		}
+		if !bytes.Equal(srcIt.Value(), dstIt.Value()) {
+			return errors.Errorf("value of key %x differs", srcIt.Key())
+		}
+		dstIt.Next()
+	}
+	if dstIt.Valid() {
+		return errors.Errorf("unexpected key %x", dstIt.Key())
+	}
+	return nil
+}
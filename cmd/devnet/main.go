@@ -0,0 +1,462 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/hexutil"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/log"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	nodesFlag = cli.IntFlag{
+		Name:  "nodes",
+		Usage: "Number of nodes",
+		Value: 4,
+	}
+	binaryFlag = cli.StringFlag{
+		Name:  "binary",
+		Usage: "Path to the node executable",
+		Value: "idena-go",
+	}
+	networkFlag = cli.UintFlag{
+		Name:  "network",
+		Usage: "Network id, it should differ from the default networks",
+		Value: 0x100,
+	}
+	rpcPortFlag = cli.IntFlag{
+		Name:  "rpcport",
+		Usage: "RPC port of the first node, next nodes use next ports",
+		Value: 9010,
+	}
+	ipfsPortFlag = cli.IntFlag{
+		Name:  "ipfsport",
+		Usage: "IPFS port of the first node, next nodes use next ports",
+		Value: 40410,
+	}
+	ceremonyDelayFlag = cli.DurationFlag{
+		Name:  "ceremonydelay",
+		Usage: "Delay before the first validation ceremony",
+		Value: time.Minute * 10,
+	}
+	validationIntervalFlag = cli.DurationFlag{
+		Name:  "validationinterval",
+		Usage: "Interval between validation ceremonies",
+		Value: time.Minute * 30,
+	}
+	flipLotteryFlag = cli.DurationFlag{
+		Name:  "fliplottery",
+		Usage: "Flip lottery duration",
+		Value: time.Minute,
+	}
+	shortSessionFlag = cli.DurationFlag{
+		Name:  "shortsession",
+		Usage: "Short session duration",
+		Value: time.Minute,
+	}
+	longSessionFlag = cli.DurationFlag{
+		Name:  "longsession",
+		Usage: "Long session duration",
+		Value: time.Minute * 2,
+	}
+	autoAnswerFlag = cli.StringFlag{
+		Name:  "autoanswer",
+		Usage: "Strategy to answer flips automatically: known, random or abstain, with known strategy nodes submit required flips with random content and answer them correctly",
+	}
+)
+
+// knownAnswersFile is shared by all nodes of the devnet
+const knownAnswersFile = "knownanswers"
+
+const (
+	flipsSubmissionInterval = time.Second * 30
+	devnetRequiredFlips     = 3
+)
+
+type devnetNode struct {
+	idx      int
+	dataDir  string
+	address  common.Address
+	pubKey   []byte
+	apiKey   string
+	rpcPort  int
+	ipfsPort int
+	cmd      *exec.Cmd
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Usage = "Starts local network of several nodes with predefined identities"
+
+	app.Flags = []cli.Flag{
+		config.DataDirFlag,
+		config.VerbosityFlag,
+		nodesFlag,
+		binaryFlag,
+		networkFlag,
+		rpcPortFlag,
+		ipfsPortFlag,
+		ceremonyDelayFlag,
+		validationIntervalFlag,
+		flipLotteryFlag,
+		shortSessionFlag,
+		longSessionFlag,
+		autoAnswerFlag,
+	}
+
+	app.Action = func(context *cli.Context) error {
+		logLvl := log.Lvl(context.Int("verbosity"))
+		var handler log.Handler
+		if runtime.GOOS == "windows" {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stdout, log.LogfmtFormat()))
+		} else {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stderr, log.TerminalFormat(true)))
+		}
+		log.Root().SetHandler(handler)
+
+		if !context.IsSet(config.DataDirFlag.Name) {
+			return errors.New("datadir option is required")
+		}
+		dataDir := context.String(config.DataDirFlag.Name)
+		if files, err := ioutil.ReadDir(dataDir); err == nil && len(files) > 0 {
+			return errors.Errorf("datadir %v is not empty", dataDir)
+		}
+		nodesCount := context.Int(nodesFlag.Name)
+		if nodesCount < 1 {
+			return errors.New("at least one node is required")
+		}
+
+		nodes, err := prepareNodes(dataDir, nodesCount, context.Int(rpcPortFlag.Name), context.Int(ipfsPortFlag.Name))
+		if err != nil {
+			return err
+		}
+		swarmKey, err := randomHex(32)
+		if err != nil {
+			return err
+		}
+		base := baseConfig(context, nodes, swarmKey, time.Now().Add(context.Duration(ceremonyDelayFlag.Name)))
+		if base.Validation.AutoAnswer == config.AutoAnswerKnown {
+			absDataDir, err := filepath.Abs(dataDir)
+			if err != nil {
+				return err
+			}
+			base.Validation.KnownAnswersFile = filepath.Join(absDataDir, knownAnswersFile)
+		}
+
+		binary := context.String(binaryFlag.Name)
+		verbosity := context.Int(config.VerbosityFlag.Name)
+		defer stopNodes(nodes)
+
+		// the first node is started alone to get its address which is used as bootstrap node by others
+		if err := startNode(binary, nodes[0], base, nil, verbosity); err != nil {
+			return err
+		}
+		bootNode, err := waitIpfsAddress(nodes[0], time.Minute)
+		if err != nil {
+			return err
+		}
+		for _, n := range nodes[1:] {
+			if err := startNode(binary, n, base, []string{bootNode}, verbosity); err != nil {
+				return err
+			}
+		}
+
+		printNodes(nodes, base)
+
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+		if base.Validation.AutoAnswer == config.AutoAnswerKnown {
+			ticker := time.NewTicker(flipsSubmissionInterval)
+			defer ticker.Stop()
+			go func() {
+				for range ticker.C {
+					for _, n := range nodes {
+						if err := submitRequiredFlips(n); err != nil {
+							log.Warn("Failed to submit flips", "idx", n.idx, "err", err)
+						}
+					}
+				}
+			}()
+		}
+		<-stop
+		log.Info("Stopping devnet")
+		return nil
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func prepareNodes(dataDir string, count int, rpcPort int, ipfsPort int) ([]*devnetNode, error) {
+	var nodes []*devnetNode
+	for i := 0; i < count; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		apiKey, err := randomHex(16)
+		if err != nil {
+			return nil, err
+		}
+		n := &devnetNode{
+			idx:      i,
+			dataDir:  filepath.Join(dataDir, fmt.Sprintf("node%d", i)),
+			address:  crypto.PubkeyToAddress(key.PublicKey),
+			pubKey:   crypto.FromECDSAPub(&key.PublicKey),
+			apiKey:   apiKey,
+			rpcPort:  rpcPort + i,
+			ipfsPort: ipfsPort + i,
+		}
+		keyDir := filepath.Join(n.dataDir, "keystore")
+		if err := os.MkdirAll(keyDir, 0700); err != nil {
+			return nil, err
+		}
+		if err := crypto.SaveECDSA(filepath.Join(keyDir, "nodekey"), key); err != nil {
+			return nil, errors.Wrap(err, "failed to save node key")
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// baseConfig returns config which is shared by all nodes, so they have the same genesis
+func baseConfig(context *cli.Context, nodes []*devnetNode, swarmKey string, firstCeremony time.Time) *config.Config {
+	cfg, _ := config.MakeConfigFromFile("")
+	cfg.Network = uint32(context.Uint(networkFlag.Name))
+	cfg.AutoOnline = true
+	cfg.Sync.FastSync = false
+
+	alloc := make(map[common.Address]config.GenesisAllocation, len(nodes))
+	for _, n := range nodes {
+		alloc[n.address] = config.GenesisAllocation{
+			Balance: new(big.Int).Mul(common.DnaBase, big.NewInt(1000)),
+			Stake:   new(big.Int).Mul(common.DnaBase, big.NewInt(1000)),
+			State:   uint8(state.Verified),
+			PubKey:  n.pubKey,
+		}
+	}
+	// nodes make flips and answer them correctly, so validations are passed unattended,
+	// humans are allocated since verified identities without qualified flips of previous validations are not validated
+	if context.String(autoAnswerFlag.Name) == config.AutoAnswerKnown {
+		for addr, item := range alloc {
+			item.State = uint8(state.Human)
+			item.RequiredFlips = devnetRequiredFlips
+			alloc[addr] = item
+		}
+	}
+	cfg.GenesisConf = &config.GenesisConf{
+		Alloc:             alloc,
+		GodAddress:        nodes[0].address,
+		FirstCeremonyTime: firstCeremony.Unix(),
+		GodAddressInvites: 100,
+	}
+
+	cfg.IpfsConf.SwarmKey = swarmKey
+	cfg.IpfsConf.StaticPort = true
+	cfg.IpfsConf.BootNodes = nil
+
+	cfg.Validation = &config.ValidationConfig{
+		ValidationInterval:   context.Duration(validationIntervalFlag.Name),
+		FlipLotteryDuration:  context.Duration(flipLotteryFlag.Name),
+		ShortSessionDuration: context.Duration(shortSessionFlag.Name),
+		LongSessionDuration:  context.Duration(longSessionFlag.Name),
+		AutoAnswer:           context.String(autoAnswerFlag.Name),
+	}
+	return cfg
+}
+
+func startNode(binary string, n *devnetNode, base *config.Config, bootNodes []string, verbosity int) error {
+	cfg := *base
+	ipfsConf := *base.IpfsConf
+	rpcConf := *base.RPC
+	cfg.DataDir = n.dataDir
+	cfg.IpfsConf = &ipfsConf
+	cfg.IpfsConf.DataDir = filepath.Join(n.dataDir, config.DefaultIpfsDataDir)
+	cfg.IpfsConf.IpfsPort = n.ipfsPort
+	cfg.IpfsConf.BootNodes = bootNodes
+	cfg.RPC = &rpcConf
+	cfg.RPC.HTTPHost = config.DefaultRpcHost
+	cfg.RPC.HTTPPort = n.rpcPort
+	cfg.RPC.APIKey = n.apiKey
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	cfgFile := filepath.Join(n.dataDir, "config.json")
+	if err := ioutil.WriteFile(cfgFile, data, 0600); err != nil {
+		return err
+	}
+
+	logFile, err := os.Create(filepath.Join(n.dataDir, "output.log"))
+	if err != nil {
+		return err
+	}
+	n.cmd = exec.Command(binary,
+		"--"+config.CfgFileFlag.Name, cfgFile,
+		"--"+config.DataDirFlag.Name, n.dataDir,
+		"--"+config.VerbosityFlag.Name, fmt.Sprint(verbosity),
+	)
+	n.cmd.Stdout = logFile
+	n.cmd.Stderr = logFile
+	if err := n.cmd.Start(); err != nil {
+		logFile.Close()
+		return errors.Wrapf(err, "failed to start node %v", n.idx)
+	}
+	log.Info("Node started", "idx", n.idx, "pid", n.cmd.Process.Pid)
+	go func() {
+		err := n.cmd.Wait()
+		logFile.Close()
+		log.Warn("Node stopped", "idx", n.idx, "err", err)
+	}()
+	return nil
+}
+
+func stopNodes(nodes []*devnetNode) {
+	for _, n := range nodes {
+		if n.cmd != nil && n.cmd.Process != nil {
+			n.cmd.Process.Signal(os.Interrupt)
+		}
+	}
+}
+
+// callRpc calls the method of the node RPC and decodes its result
+func callRpc(n *devnetNode, method string, params []interface{}, result interface{}) error {
+	request, _ := json.Marshal(map[string]interface{}{
+		"method": method,
+		"params": params,
+		"id":     1,
+		"key":    n.apiKey,
+	})
+	url := fmt.Sprintf("http://%v:%v", config.DefaultRpcHost, n.rpcPort)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(request))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return errors.New(response.Error.Message)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(response.Result, result)
+}
+
+// waitIpfsAddress polls RPC of the node until it returns its IPFS address
+func waitIpfsAddress(n *devnetNode, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(time.Second)
+		var address string
+		if err := callRpc(n, "net_ipfsAddress", []interface{}{}, &address); err != nil {
+			continue
+		}
+		idx := strings.LastIndex(address, "/ipfs/")
+		if idx < 0 {
+			continue
+		}
+		return fmt.Sprintf("/ip4/127.0.0.1/tcp/%v%v", n.ipfsPort, address[idx:]), nil
+	}
+	return "", errors.Errorf("node %v has not started in %v", n.idx, timeout)
+}
+
+// submitRequiredFlips submits flips with random content which the node is required to make in the current epoch,
+// the node shares their answers with other nodes by the known answers file
+func submitRequiredFlips(n *devnetNode) error {
+	var epoch struct {
+		CurrentPeriod string `json:"currentPeriod"`
+	}
+	if err := callRpc(n, "dna_epoch", []interface{}{}, &epoch); err != nil {
+		return err
+	}
+	// flips are accepted before the flip lottery only
+	if epoch.CurrentPeriod != "None" {
+		return nil
+	}
+	var identity struct {
+		RequiredFlips uint8 `json:"requiredFlips"`
+	}
+	if err := callRpc(n, "dna_identity", []interface{}{}, &identity); err != nil {
+		return err
+	}
+	var submissions []struct {
+		Status string `json:"status"`
+	}
+	if err := callRpc(n, "flip_submissions", []interface{}{}, &submissions); err != nil {
+		return err
+	}
+	submitted := 0
+	for _, submission := range submissions {
+		if submission.Status != "Failed" {
+			submitted++
+		}
+	}
+	for pairId := submitted; pairId < int(identity.RequiredFlips); pairId++ {
+		publicPart, privatePart := make([]byte, 128), make([]byte, 128)
+		answer := make([]byte, 1)
+		for _, data := range [][]byte{publicPart, privatePart, answer} {
+			if _, err := rand.Read(data); err != nil {
+				return err
+			}
+		}
+		args := map[string]interface{}{
+			"publicHex":  hexutil.Encode(publicPart),
+			"privateHex": hexutil.Encode(privatePart),
+			"pairId":     pairId,
+			"answer":     answer[0]%2 + 1,
+		}
+		if err := callRpc(n, "flip_submit", []interface{}{args}, nil); err != nil {
+			return err
+		}
+		log.Info("Flip submitted", "idx", n.idx, "pairId", pairId)
+	}
+	return nil
+}
+
+func printNodes(nodes []*devnetNode, base *config.Config) {
+	fmt.Printf("Devnet is started, network: %v, first ceremony: %v\n", base.Network,
+		time.Unix(base.GenesisConf.FirstCeremonyTime, 0).UTC())
+	for _, n := range nodes {
+		fmt.Printf("node%d address: %v rpc: http://%v:%v apikey: %v ipfsport: %v datadir: %v\n",
+			n.idx, n.address.Hex(), config.DefaultRpcHost, n.rpcPort, n.apiKey, n.ipfsPort, n.dataDir)
+	}
+}
+
+func randomHex(size int) (string, error) {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
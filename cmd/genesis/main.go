@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/golang/protobuf/proto"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/log"
+	models "github.com/idena-network/idena-go/protobuf"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tm-db"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v3"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+var (
+	specFlag = cli.StringFlag{
+		Name:  "spec",
+		Usage: "YAML or JSON file with the genesis spec",
+	}
+	outFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Directory for the genesis files, copy them to the resources package to embed them",
+	}
+	networkFlag = cli.UintFlag{
+		Name:  "network",
+		Usage: "Network id which the genesis is generated for, nodes of other networks generate their own genesis",
+	}
+	publicNetworkFlag = cli.BoolFlag{
+		Name:  "public-network",
+		Usage: "Allows to generate genesis for the main or test network",
+	}
+)
+
+// genesisInfo is written next to the genesis files, the resources package embeds it to apply them only to the network
+type genesisInfo struct {
+	Network      uint32 `json:"network"`
+	Height       uint64 `json:"height"`
+	Hash         string `json:"hash"`
+	Root         string `json:"root"`
+	IdentityRoot string `json:"identityRoot"`
+}
+
+func main() {
+	app := cli.NewApp()
+
+	app.Flags = []cli.Flag{
+		specFlag,
+		outFlag,
+		networkFlag,
+		publicNetworkFlag,
+		config.VerbosityFlag,
+	}
+
+	app.Action = func(context *cli.Context) error {
+		logLvl := log.Lvl(context.Int("verbosity"))
+		var handler log.Handler
+		if runtime.GOOS == "windows" {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stdout, log.LogfmtFormat()))
+		} else {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stderr, log.TerminalFormat(true)))
+		}
+		log.Root().SetHandler(handler)
+		if !context.IsSet(specFlag.Name) {
+			return errors.New("spec option is required")
+		}
+		if !context.IsSet(outFlag.Name) {
+			return errors.New("out option is required")
+		}
+		if !context.IsSet(networkFlag.Name) {
+			return errors.New("network option is required")
+		}
+		network := uint32(context.Uint(networkFlag.Name))
+		if err := checkNetwork(network, context.Bool(publicNetworkFlag.Name)); err != nil {
+			return err
+		}
+
+		data, err := ioutil.ReadFile(context.String(specFlag.Name))
+		if err != nil {
+			return err
+		}
+		spec := new(genesisSpec)
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(spec); err != nil {
+			return errors.Wrap(err, "cannot parse spec")
+		}
+		predefinedState, err := buildPredefinedState(spec, crypto.Keccak256(data))
+		if err != nil {
+			return err
+		}
+
+		outDir := context.String(outFlag.Name)
+		if err := os.MkdirAll(outDir, 0777); err != nil {
+			return err
+		}
+		header, err := writeGenesis(predefinedState, outDir)
+		if err != nil {
+			return err
+		}
+
+		info := genesisInfo{
+			Network:      network,
+			Height:       header.Height(),
+			Hash:         header.Hash().Hex(),
+			Root:         header.Root().Hex(),
+			IdentityRoot: header.IdentityRoot().Hex(),
+		}
+		data, err = json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(outDir, "genesis.json"), data, 0644); err != nil {
+			return err
+		}
+		log.Info("Genesis generated", "height", info.Height, "hash", info.Hash, "network", info.Network,
+			"accounts", len(predefinedState.Accounts), "identities", len(predefinedState.Identities))
+		return nil
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+// checkNetwork protects genesis files of the main and test networks which the resources package embeds
+func checkNetwork(network uint32, allowPublic bool) error {
+	if (network == blockchain.Mainnet || network == blockchain.Testnet) && !allowPublic {
+		return errors.Errorf("network %v is public, pass --%v to generate its genesis", network, publicNetworkFlag.Name)
+	}
+	return nil
+}
+
+// writeGenesis applies the predefined state like the node does for the genesis of the network which has predefined
+// state and writes the files which are embedded by the resources package
+func writeGenesis(predefinedState *models.ProtoPredefinedState, outDir string) (*types.Header, error) {
+	appState, err := appstate.NewAppState(db.NewMemDB(), eventbus.New())
+	if err != nil {
+		return nil, err
+	}
+	if err := appState.CommitAt(predefinedState.Block - 1); err != nil {
+		return nil, err
+	}
+	appState.SetPredefinedState(predefinedState)
+	if err := appState.Commit(nil); err != nil {
+		return nil, err
+	}
+
+	header := &types.Header{
+		ProposedHeader: &types.ProposedHeader{
+			Height:       predefinedState.Block,
+			Root:         appState.State.Root(),
+			IdentityRoot: appState.IdentityState.Root(),
+			BlockSeed:    types.BytesToSeed(predefinedState.Seed),
+			IpfsHash:     ipfs.EmptyCid.Bytes(),
+			FeePerGas:    common.BigIntOrNil(predefinedState.Global.FeePerGas),
+		},
+	}
+
+	var stateRoot, identityRoot common.Hash
+	err = writeFile(filepath.Join(outDir, "statedb.tar"), func(w io.Writer) (err error) {
+		stateRoot, err = appState.State.WriteSnapshot2(header.Height(), w)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = writeFile(filepath.Join(outDir, "identitystatedb.tar"), func(w io.Writer) (err error) {
+		identityRoot, err = appState.IdentityState.WriteSnapshot2(header.Height(), w)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stateRoot != header.Root() || identityRoot != header.IdentityRoot() {
+		return nil, errors.New("written tree is incompatible with block header")
+	}
+
+	data, err := header.ToBytes()
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(outDir, "header.tar"), data, 0644); err != nil {
+		return nil, err
+	}
+	data, err = proto.Marshal(predefinedState)
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(outDir, "stategen.out"), data, 0644); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func writeFile(fileName string, write func(w io.Writer) error) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
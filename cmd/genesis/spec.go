@@ -0,0 +1,283 @@
+package main
+
+import (
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/hexutil"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/crypto"
+	models "github.com/idena-network/idena-go/protobuf"
+	"github.com/idena-network/idena-go/vm/embedded"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// genesisSpec is a declarative description of the genesis state, amounts are in DNA
+type genesisSpec struct {
+	Height            uint64         `yaml:"height"`
+	Seed              string         `yaml:"seed"`
+	Epoch             uint16         `yaml:"epoch"`
+	FirstCeremonyTime int64          `yaml:"firstCeremonyTime"`
+	GodAddress        string         `yaml:"godAddress"`
+	GodAddressInvites uint16         `yaml:"godAddressInvites"`
+	FeePerGas         string         `yaml:"feePerGas"`
+	Accounts          []accountSpec  `yaml:"accounts"`
+	Identities        []identitySpec `yaml:"identities"`
+	Contracts         []contractSpec `yaml:"contracts"`
+}
+
+type accountSpec struct {
+	Address string `yaml:"address"`
+	Balance string `yaml:"balance"`
+}
+
+type identitySpec struct {
+	Address  string `yaml:"address"`
+	State    string `yaml:"state"`
+	Birthday uint16 `yaml:"birthday"`
+	// public key of the identity, it's required for identities which should take part in the first validation
+	PubKey  string `yaml:"pubKey"`
+	Stake   string `yaml:"stake"`
+	Invites uint8  `yaml:"invites"`
+}
+
+type contractSpec struct {
+	Address string `yaml:"address"`
+	// Type is a name of the embedded contract, e.g. OracleVoting, or a hex code hash
+	Type    string            `yaml:"type"`
+	Balance string            `yaml:"balance"`
+	Stake   string            `yaml:"stake"`
+	Storage map[string]string `yaml:"storage"`
+}
+
+var contractTypes = map[string]embedded.EmbeddedContractType{
+	"TimeLock":             embedded.TimeLockContract,
+	"OracleVoting":         embedded.OracleVotingContract,
+	"OracleLock":           embedded.OracleLockContract,
+	"RefundableOracleLock": embedded.RefundableOracleLockContract,
+	"Multisig":             embedded.MultisigContract,
+}
+
+func parseAddress(value string) (common.Address, error) {
+	var addr common.Address
+	if err := addr.UnmarshalText([]byte(value)); err != nil {
+		return addr, errors.Wrapf(err, "invalid address %q", value)
+	}
+	return addr, nil
+}
+
+// parseAmount converts DNA amount to the big int, empty value is nil
+func parseAmount(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid amount %q", value)
+	}
+	if amount.IsNegative() {
+		return nil, errors.Errorf("negative amount %q", value)
+	}
+	return blockchain.ConvertToInt(amount), nil
+}
+
+func parseContractType(value string) (common.Hash, error) {
+	if codeHash, ok := contractTypes[value]; ok {
+		return codeHash, nil
+	}
+	data, err := hexutil.Decode(value)
+	if err != nil {
+		return common.Hash{}, errors.Errorf("unknown contract type %q", value)
+	}
+	codeHash := common.BytesToHash(data)
+	if _, ok := embedded.AvailableContracts[codeHash]; !ok {
+		return common.Hash{}, errors.Errorf("unknown contract code hash %v", value)
+	}
+	return codeHash, nil
+}
+
+// buildPredefinedState converts the spec to the state which is applied to the genesis like stategen output,
+// seed is used when the spec doesn't define it
+func buildPredefinedState(spec *genesisSpec, seed []byte) (*models.ProtoPredefinedState, error) {
+	if spec.FirstCeremonyTime == 0 {
+		return nil, errors.New("firstCeremonyTime is required")
+	}
+	godAddress, err := parseAddress(spec.GodAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "godAddress")
+	}
+	if spec.Seed != "" {
+		if seed, err = hexutil.Decode(spec.Seed); err != nil {
+			return nil, errors.Wrap(err, "invalid seed")
+		}
+	}
+	feePerGas, err := parseAmount(spec.FeePerGas)
+	if err != nil {
+		return nil, errors.Wrap(err, "feePerGas")
+	}
+	height := spec.Height
+	if height == 0 {
+		height = 1
+	}
+	godAddressInvites := spec.GodAddressInvites
+	if godAddressInvites == 0 {
+		godAddressInvites = common.GodAddressInvitesCount(0)
+	}
+	wordsSeed := types.BytesToSeed(seed)
+
+	result := &models.ProtoPredefinedState{
+		Block: height,
+		Seed:  seed,
+		Global: &models.ProtoPredefinedState_Global{
+			Epoch:              uint32(spec.Epoch),
+			NextValidationTime: spec.FirstCeremonyTime,
+			GodAddress:         godAddress.Bytes(),
+			WordsSeed:          wordsSeed[:],
+			FeePerGas:          common.BigIntBytesOrNil(feePerGas),
+			GodAddressInvites:  uint32(godAddressInvites),
+		},
+		StatusSwitch: &models.ProtoPredefinedState_StatusSwitch{},
+	}
+
+	accounts := make(map[common.Address]*models.ProtoPredefinedState_Account)
+	account := func(addr common.Address) *models.ProtoPredefinedState_Account {
+		acc, ok := accounts[addr]
+		if !ok {
+			acc = &models.ProtoPredefinedState_Account{Address: addr.Bytes()}
+			accounts[addr] = acc
+			result.Accounts = append(result.Accounts, acc)
+		}
+		return acc
+	}
+
+	for i, item := range spec.Accounts {
+		addr, err := parseAddress(item.Address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "account %v", i)
+		}
+		if _, ok := accounts[addr]; ok {
+			return nil, errors.Errorf("account %v is duplicated", addr.Hex())
+		}
+		balance, err := parseAmount(item.Balance)
+		if err != nil {
+			return nil, errors.Wrapf(err, "account %v", addr.Hex())
+		}
+		account(addr).Balance = common.BigIntBytesOrNil(balance)
+	}
+
+	identities := make(map[common.Address]struct{})
+	for i, item := range spec.Identities {
+		addr, err := parseAddress(item.Address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "identity %v", i)
+		}
+		if _, ok := identities[addr]; ok {
+			return nil, errors.Errorf("identity %v is duplicated", addr.Hex())
+		}
+		identities[addr] = struct{}{}
+		identityState, ok := state.ParseIdentityState(item.State)
+		if !ok {
+			return nil, errors.Errorf("identity %v has unknown state %q", addr.Hex(), item.State)
+		}
+		stake, err := parseAmount(item.Stake)
+		if err != nil {
+			return nil, errors.Wrapf(err, "identity %v", addr.Hex())
+		}
+		var pubKey []byte
+		if item.PubKey != "" {
+			if pubKey, err = hexutil.Decode(item.PubKey); err != nil {
+				return nil, errors.Wrapf(err, "identity %v has invalid public key", addr.Hex())
+			}
+			pubKeyAddr, err := crypto.PubKeyBytesToAddress(pubKey)
+			if err != nil {
+				return nil, errors.Wrapf(err, "identity %v has invalid public key", addr.Hex())
+			}
+			if pubKeyAddr != addr {
+				return nil, errors.Errorf("public key of identity %v belongs to %v", addr.Hex(), pubKeyAddr.Hex())
+			}
+		}
+		result.Identities = append(result.Identities, &models.ProtoPredefinedState_Identity{
+			Address:  addr.Bytes(),
+			State:    uint32(identityState),
+			Birthday: uint32(item.Birthday),
+			PubKey:   pubKey,
+			Stake:    common.BigIntBytesOrNil(stake),
+			Invites:  uint32(item.Invites),
+		})
+		if identityState.NewbieOrBetter() {
+			flags := state.Validated
+			if (&state.Identity{State: identityState}).IsDiscriminated(spec.Epoch) {
+				flags |= state.Discriminated
+			}
+			result.ApprovedIdentities = append(result.ApprovedIdentities, &models.ProtoPredefinedState_ApprovedIdentity{
+				Address: addr.Bytes(),
+				Flags:   uint32(flags),
+			})
+		}
+	}
+
+	for i, item := range spec.Contracts {
+		addr, err := parseAddress(item.Address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "contract %v", i)
+		}
+		if acc, ok := accounts[addr]; ok && acc.ContractData != nil {
+			return nil, errors.Errorf("contract %v is duplicated", addr.Hex())
+		}
+		codeHash, err := parseContractType(item.Type)
+		if err != nil {
+			return nil, errors.Wrapf(err, "contract %v", addr.Hex())
+		}
+		balance, err := parseAmount(item.Balance)
+		if err != nil {
+			return nil, errors.Wrapf(err, "contract %v", addr.Hex())
+		}
+		stake, err := parseAmount(item.Stake)
+		if err != nil {
+			return nil, errors.Wrapf(err, "contract %v", addr.Hex())
+		}
+		acc := account(addr)
+		if balance != nil {
+			acc.Balance = common.BigIntBytesOrNil(balance)
+		}
+		acc.ContractData = &models.ProtoPredefinedState_Account_ContractData{
+			CodeHash: codeHash.Bytes(),
+		}
+		if stake != nil {
+			acc.ContractData.Stake = stake.Bytes()
+		}
+		keys := make([]string, 0, len(item.Storage))
+		for key := range item.Storage {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := item.Storage[key]
+			keyBytes, err := parseStorageBytes(key)
+			if err != nil {
+				return nil, errors.Wrapf(err, "contract %v has invalid key %q", addr.Hex(), key)
+			}
+			valueBytes, err := parseStorageBytes(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "contract %v has invalid value of key %q", addr.Hex(), key)
+			}
+			result.ContractValues = append(result.ContractValues, &models.ProtoPredefinedState_ContractKeyValue{
+				Key:   state.StateDbKeys.ContractStoreKey(addr, keyBytes),
+				Value: valueBytes,
+			})
+		}
+	}
+	return result, nil
+}
+
+// parseStorageBytes decodes 0x prefixed hex, other values are taken as strings like keys of embedded contracts
+func parseStorageBytes(value string) ([]byte, error) {
+	if strings.HasPrefix(value, "0x") {
+		return hexutil.Decode(value)
+	}
+	return []byte(value), nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/hexutil"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/vm/embedded"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+)
+
+func Test_buildPredefinedState(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	newbie := common.Address{0x1}
+	contract := common.Address{0x2}
+
+	spec := &genesisSpec{
+		Epoch:             3,
+		FirstCeremonyTime: 1900000000,
+		GodAddress:        addr.Hex(),
+		FeePerGas:         "0.000001",
+		Accounts: []accountSpec{
+			{Address: addr.Hex(), Balance: "1000.5"},
+		},
+		Identities: []identitySpec{
+			{Address: addr.Hex(), State: "Verified", PubKey: hexutil.Encode(crypto.FromECDSAPub(&key.PublicKey)), Stake: "10"},
+			{Address: newbie.Hex(), State: "newbie", Birthday: 2, Invites: 1},
+		},
+		Contracts: []contractSpec{
+			{Address: contract.Hex(), Type: "OracleVoting", Stake: "5", Storage: map[string]string{"state": "0x01", "fact": "0xdeadbeef"}},
+		},
+	}
+	result, err := buildPredefinedState(spec, []byte{0x5})
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(1), result.Block)
+	require.Equal(t, []byte{0x5}, result.Seed)
+	require.Equal(t, uint32(3), result.Global.Epoch)
+	require.Equal(t, int64(1900000000), result.Global.NextValidationTime)
+	require.Equal(t, addr.Bytes(), result.Global.GodAddress)
+	require.Equal(t, uint32(common.GodAddressInvitesCount(0)), result.Global.GodAddressInvites)
+	require.Equal(t, big.NewInt(1e12).Bytes(), result.Global.FeePerGas)
+
+	require.Len(t, result.Accounts, 2)
+	require.Equal(t, addr.Bytes(), result.Accounts[0].Address)
+	require.Equal(t, new(big.Int).Mul(big.NewInt(10005), big.NewInt(1e17)).Bytes(), result.Accounts[0].Balance)
+	require.Equal(t, contract.Bytes(), result.Accounts[1].Address)
+	require.Equal(t, embedded.OracleVotingContract.Bytes(), result.Accounts[1].ContractData.CodeHash)
+	require.Equal(t, new(big.Int).Mul(big.NewInt(5), common.DnaBase).Bytes(), result.Accounts[1].ContractData.Stake)
+
+	require.Len(t, result.Identities, 2)
+	require.Equal(t, uint32(state.Verified), result.Identities[0].State)
+	require.Equal(t, crypto.FromECDSAPub(&key.PublicKey), result.Identities[0].PubKey)
+	require.Equal(t, uint32(state.Newbie), result.Identities[1].State)
+	require.Equal(t, uint32(2), result.Identities[1].Birthday)
+	require.Equal(t, uint32(1), result.Identities[1].Invites)
+
+	// newbies are discriminated since the third epoch
+	require.Len(t, result.ApprovedIdentities, 2)
+	require.Equal(t, uint32(state.Validated), result.ApprovedIdentities[0].Flags)
+	require.Equal(t, uint32(state.Validated|state.Discriminated), result.ApprovedIdentities[1].Flags)
+
+	// storage keys are sorted
+	require.Len(t, result.ContractValues, 2)
+	require.Equal(t, state.StateDbKeys.ContractStoreKey(contract, []byte("fact")), result.ContractValues[0].Key)
+	require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, result.ContractValues[0].Value)
+	require.Equal(t, state.StateDbKeys.ContractStoreKey(contract, []byte("state")), result.ContractValues[1].Key)
+	require.Equal(t, []byte{0x1}, result.ContractValues[1].Value)
+
+	spec.Seed = "0x0102"
+	spec.Height = 10
+	spec.GodAddressInvites = 5
+	result, err = buildPredefinedState(spec, []byte{0x5})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1, 0x2}, result.Seed)
+	require.Equal(t, uint64(10), result.Block)
+	require.Equal(t, uint32(5), result.Global.GodAddressInvites)
+}
+
+func Test_buildPredefinedState_errors(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	otherKey, _ := crypto.GenerateKey()
+
+	cases := []struct {
+		name   string
+		modify func(spec *genesisSpec)
+		err    string
+	}{
+		{"no ceremony time", func(spec *genesisSpec) { spec.FirstCeremonyTime = 0 }, "firstCeremonyTime is required"},
+		{"invalid god address", func(spec *genesisSpec) { spec.GodAddress = "0x1" }, "godAddress"},
+		{"negative balance", func(spec *genesisSpec) {
+			spec.Accounts = []accountSpec{{Address: addr.Hex(), Balance: "-1"}}
+		}, "negative amount"},
+		{"duplicated account", func(spec *genesisSpec) {
+			spec.Accounts = []accountSpec{{Address: addr.Hex()}, {Address: addr.Hex()}}
+		}, "is duplicated"},
+		{"unknown identity state", func(spec *genesisSpec) {
+			spec.Identities = []identitySpec{{Address: addr.Hex(), State: "Alive"}}
+		}, "unknown state"},
+		{"foreign public key", func(spec *genesisSpec) {
+			spec.Identities = []identitySpec{{Address: addr.Hex(), State: "Human", PubKey: hexutil.Encode(crypto.FromECDSAPub(&otherKey.PublicKey))}}
+		}, "public key of identity"},
+		{"unknown contract type", func(spec *genesisSpec) {
+			spec.Contracts = []contractSpec{{Address: addr.Hex(), Type: "Lottery"}}
+		}, "unknown contract type"},
+		{"duplicated contract", func(spec *genesisSpec) {
+			spec.Contracts = []contractSpec{{Address: addr.Hex(), Type: "TimeLock"}, {Address: addr.Hex(), Type: "Multisig"}}
+		}, "is duplicated"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			spec := &genesisSpec{FirstCeremonyTime: 1900000000, GodAddress: addr.Hex()}
+			c.modify(spec)
+			_, err := buildPredefinedState(spec, nil)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), c.err)
+		})
+	}
+}
+
+func Test_checkNetwork(t *testing.T) {
+	require.Error(t, checkNetwork(uint32(blockchain.Mainnet), false))
+	require.Error(t, checkNetwork(uint32(blockchain.Testnet), false))
+	require.NoError(t, checkNetwork(uint32(blockchain.Testnet), true))
+	require.NoError(t, checkNetwork(256, false))
+}
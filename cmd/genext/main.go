@@ -7,9 +7,8 @@ import (
 	"github.com/idena-network/idena-go/core/state"
 	"github.com/idena-network/idena-go/database"
 	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
 	"github.com/pkg/errors"
-	"github.com/syndtr/goleveldb/leveldb/filter"
-	"github.com/syndtr/goleveldb/leveldb/opt"
 	dbm "github.com/tendermint/tm-db"
 	"github.com/urfave/cli"
 	"os"
@@ -21,6 +20,7 @@ func main() {
 
 	app.Flags = []cli.Flag{
 		config.DataDirFlag,
+		config.DbBackendFlag,
 		config.VerbosityFlag,
 	}
 
@@ -37,7 +37,7 @@ func main() {
 			return errors.New("datadir option is required")
 		}
 
-		db, err := OpenDatabase(context.String(config.DataDirFlag.Name), "idenachain", 16, 16)
+		db, err := node.OpenOfflineDatabase(context.String(config.DataDirFlag.Name), "idenachain", context.String(config.DbBackendFlag.Name))
 		if err != nil {
 			return err
 		}
@@ -123,12 +123,3 @@ func main() {
 		log.Error(err.Error())
 	}
 }
-
-func OpenDatabase(datadir string, name string, cache int, handles int) (dbm.DB, error) {
-	return dbm.NewGoLevelDBWithOpts(name, datadir, &opt.Options{
-		OpenFilesCacheCapacity: handles,
-		BlockCacheCapacity:     cache / 2 * opt.MiB,
-		WriteBuffer:            cache / 4 * opt.MiB,
-		Filter:                 filter.NewBloomFilter(10),
-	})
-}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/hexutil"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/urfave/cli"
+	"io"
+	"os"
+	"runtime"
+)
+
+var (
+	fromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "Height of the first state version",
+	}
+	toFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Height of the second state version",
+	}
+	limitFlag = cli.IntFlag{
+		Name:  "limit",
+		Usage: "Maximal number of changes to output, 0 means no limit",
+	}
+	outputFlag = cli.StringFlag{
+		Name:  "output",
+		Usage: "Output file, stdout is used by default",
+	}
+)
+
+type change struct {
+	Type     string         `json:"type"`
+	Change   string         `json:"change"`
+	Address  common.Address `json:"address"`
+	Key      hexutil.Bytes  `json:"key,omitempty"`
+	OldValue interface{}    `json:"old"`
+	NewValue interface{}    `json:"new"`
+}
+
+type accountValue struct {
+	Balance       decimal.Decimal  `json:"balance"`
+	Nonce         uint32           `json:"nonce"`
+	Epoch         uint16           `json:"epoch"`
+	CodeHash      *common.Hash     `json:"codeHash,omitempty"`
+	ContractStake *decimal.Decimal `json:"contractStake,omitempty"`
+}
+
+type identityValue struct {
+	State      uint8            `json:"state"`
+	Stake      decimal.Decimal  `json:"stake"`
+	Birthday   uint16           `json:"birthday"`
+	Invites    uint8            `json:"invites"`
+	Generation uint32           `json:"generation"`
+	Penalty    decimal.Decimal  `json:"penalty"`
+	MadeFlips  int              `json:"madeFlips"`
+	Delegatee  *common.Address  `json:"delegatee"`
+	Inviter    *common.Address  `json:"inviter"`
+	Invitees   []common.Address `json:"invitees"`
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Usage = "Prints accounts, identities and contract values which differ between two retained state versions as json lines"
+
+	app.Flags = []cli.Flag{
+		config.DataDirFlag,
+		config.DbBackendFlag,
+		config.VerbosityFlag,
+		fromFlag,
+		toFlag,
+		limitFlag,
+		outputFlag,
+	}
+
+	app.Action = func(context *cli.Context) error {
+		logLvl := log.Lvl(context.Int("verbosity"))
+
+		var handler log.Handler
+		if runtime.GOOS == "windows" {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stdout, log.LogfmtFormat()))
+		} else {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stderr, log.TerminalFormat(true)))
+		}
+		log.Root().SetHandler(handler)
+
+		if !context.IsSet(config.DataDirFlag.Name) {
+			return errors.New("datadir option is required")
+		}
+		if !context.IsSet(fromFlag.Name) || !context.IsSet(toFlag.Name) {
+			return errors.New("from and to options are required")
+		}
+
+		db, err := node.OpenOfflineDatabase(context.String(config.DataDirFlag.Name), "idenachain", context.String(config.DbBackendFlag.Name))
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		stateDb, err := state.NewLazy(db)
+		if err != nil {
+			return err
+		}
+		diff, err := stateDb.DiffVersions(int64(context.Uint64(fromFlag.Name)), int64(context.Uint64(toFlag.Name)), context.Int(limitFlag.Name))
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if output := context.String(outputFlag.Name); output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+		buffered := bufio.NewWriter(out)
+		defer buffered.Flush()
+		encoder := json.NewEncoder(buffered)
+
+		for _, item := range diff.Accounts {
+			c := &change{Type: "account", Address: item.Address}
+			c.Change, c.OldValue, c.NewValue = changeType(item.Old == nil, item.New == nil), convertAccount(item.Old), convertAccount(item.New)
+			if err := encoder.Encode(c); err != nil {
+				return err
+			}
+		}
+		for _, item := range diff.Identities {
+			c := &change{Type: "identity", Address: item.Address}
+			c.Change, c.OldValue, c.NewValue = changeType(item.Old == nil, item.New == nil), convertIdentity(item.Old), convertIdentity(item.New)
+			if err := encoder.Encode(c); err != nil {
+				return err
+			}
+		}
+		for _, item := range diff.ContractValues {
+			c := &change{Type: "contract", Address: item.Contract, Key: item.Key}
+			c.Change = changeType(item.Old == nil, item.New == nil)
+			if item.Old != nil {
+				c.OldValue = hexutil.Bytes(item.Old)
+			}
+			if item.New != nil {
+				c.NewValue = hexutil.Bytes(item.New)
+			}
+			if err := encoder.Encode(c); err != nil {
+				return err
+			}
+		}
+		if diff.Truncated {
+			log.Warn("Diff is truncated by the limit", "limit", context.Int(limitFlag.Name))
+		}
+		log.Info("State diff completed", "accounts", len(diff.Accounts), "identities", len(diff.Identities),
+			"contractValues", len(diff.ContractValues))
+		return nil
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func changeType(added bool, removed bool) string {
+	switch {
+	case added:
+		return "added"
+	case removed:
+		return "removed"
+	default:
+		return "modified"
+	}
+}
+
+func convertAccount(account *state.Account) interface{} {
+	if account == nil {
+		return nil
+	}
+	res := &accountValue{
+		Balance: blockchain.ConvertToFloat(account.Balance),
+		Nonce:   account.Nonce,
+		Epoch:   account.Epoch,
+	}
+	if account.Contract != nil {
+		codeHash := account.Contract.CodeHash
+		stake := blockchain.ConvertToFloat(account.Contract.Stake)
+		res.CodeHash = &codeHash
+		res.ContractStake = &stake
+	}
+	return res
+}
+
+func convertIdentity(identity *state.Identity) interface{} {
+	if identity == nil {
+		return nil
+	}
+	res := &identityValue{
+		State:      uint8(identity.State),
+		Stake:      blockchain.ConvertToFloat(identity.Stake),
+		Birthday:   identity.Birthday,
+		Invites:    identity.Invites,
+		Generation: identity.Generation,
+		Penalty:    blockchain.ConvertToFloat(identity.Penalty),
+		MadeFlips:  len(identity.Flips),
+		Delegatee:  identity.Delegatee(),
+	}
+	if identity.Inviter != nil {
+		inviter := identity.Inviter.Address
+		res.Inviter = &inviter
+	}
+	for _, invitee := range identity.Invitees {
+		res.Invitees = append(res.Invitees, invitee.Address)
+	}
+	return res
+}
@@ -0,0 +1,444 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/urfave/cli"
+	"io"
+	"math/big"
+	"os"
+	"runtime"
+	"strings"
+)
+
+const (
+	kindAccounts   = "accounts"
+	kindIdentities = "identities"
+	kindContracts  = "contracts"
+
+	formatJson = "json"
+	formatCsv  = "csv"
+)
+
+var (
+	heightFlag = cli.Uint64Flag{
+		Name:  "height",
+		Usage: "Height of the state to export, head is used by default",
+	}
+	kindFlag = cli.StringFlag{
+		Name:  "kind",
+		Usage: "What to export: accounts, identities or contracts",
+		Value: kindAccounts,
+	}
+	formatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "Output format: json (one object per line) or csv",
+		Value: formatJson,
+	}
+	columnsFlag = cli.StringFlag{
+		Name:  "columns",
+		Usage: "Comma separated list of columns to export, all columns of the kind are exported by default",
+	}
+	stateFilterFlag = cli.StringFlag{
+		Name:  "state",
+		Usage: "Comma separated list of identity states to export, e.g. Verified,Human",
+	}
+	minBalanceFlag = cli.StringFlag{
+		Name:  "minbalance",
+		Usage: "Minimal balance in DNA of exported accounts and identities",
+	}
+	outputFlag = cli.StringFlag{
+		Name:  "output",
+		Usage: "Output file, stdout is used by default",
+	}
+)
+
+type row struct {
+	addr     common.Address
+	account  *state.Account
+	identity *state.Identity
+	balance  *big.Int
+	key      []byte
+	value    []byte
+}
+
+type column struct {
+	name  string
+	value func(r *row) interface{}
+}
+
+var accountColumns = []column{
+	{"address", func(r *row) interface{} { return r.addr.Hex() }},
+	{"balance", func(r *row) interface{} { return blockchain.ConvertToFloat(r.account.Balance) }},
+	{"nonce", func(r *row) interface{} { return r.account.Nonce }},
+	{"epoch", func(r *row) interface{} { return r.account.Epoch }},
+	{"codeHash", func(r *row) interface{} {
+		if r.account.Contract == nil {
+			return nil
+		}
+		return r.account.Contract.CodeHash.Hex()
+	}},
+	{"contractStake", func(r *row) interface{} {
+		if r.account.Contract == nil {
+			return nil
+		}
+		return blockchain.ConvertToFloat(r.account.Contract.Stake)
+	}},
+}
+
+var identityColumns = []column{
+	{"address", func(r *row) interface{} { return r.addr.Hex() }},
+	{"state", func(r *row) interface{} { return r.identity.State.String() }},
+	{"balance", func(r *row) interface{} { return blockchain.ConvertToFloat(r.balance) }},
+	{"stake", func(r *row) interface{} { return blockchain.ConvertToFloat(r.identity.Stake) }},
+	{"birthday", func(r *row) interface{} { return r.identity.Birthday }},
+	{"invites", func(r *row) interface{} { return r.identity.Invites }},
+	{"generation", func(r *row) interface{} { return r.identity.Generation }},
+	{"penalty", func(r *row) interface{} { return blockchain.ConvertToFloat(r.identity.Penalty) }},
+	{"madeFlips", func(r *row) interface{} { return len(r.identity.Flips) }},
+	{"requiredFlips", func(r *row) interface{} { return r.identity.RequiredFlips }},
+	{"shardId", func(r *row) interface{} { return r.identity.ShiftedShardId() }},
+	{"delegatee", func(r *row) interface{} {
+		if delegatee := r.identity.Delegatee(); delegatee != nil {
+			return delegatee.Hex()
+		}
+		return nil
+	}},
+	{"inviter", func(r *row) interface{} {
+		if r.identity.Inviter == nil {
+			return nil
+		}
+		return r.identity.Inviter.Address.Hex()
+	}},
+	{"invitees", func(r *row) interface{} {
+		invitees := make([]string, 0, len(r.identity.Invitees))
+		for _, invitee := range r.identity.Invitees {
+			invitees = append(invitees, invitee.Address.Hex())
+		}
+		return invitees
+	}},
+}
+
+var contractColumns = []column{
+	{"address", func(r *row) interface{} { return r.addr.Hex() }},
+	{"key", func(r *row) interface{} { return hexOrNil(r.key) }},
+	{"value", func(r *row) interface{} { return hexOrNil(r.value) }},
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Usage = "Exports accounts, identities or contract storage at the given height to json lines or csv"
+
+	app.Flags = []cli.Flag{
+		config.DataDirFlag,
+		config.DbBackendFlag,
+		config.VerbosityFlag,
+		heightFlag,
+		kindFlag,
+		formatFlag,
+		columnsFlag,
+		stateFilterFlag,
+		minBalanceFlag,
+		outputFlag,
+	}
+
+	app.Action = func(context *cli.Context) error {
+		logLvl := log.Lvl(context.Int("verbosity"))
+
+		var handler log.Handler
+		if runtime.GOOS == "windows" {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stdout, log.LogfmtFormat()))
+		} else {
+			handler = log.LvlFilterHandler(logLvl, log.StreamHandler(os.Stderr, log.TerminalFormat(true)))
+		}
+		log.Root().SetHandler(handler)
+
+		if !context.IsSet(config.DataDirFlag.Name) {
+			return errors.New("datadir option is required")
+		}
+
+		kind := context.String(kindFlag.Name)
+		columns, err := selectColumns(kind, context.String(columnsFlag.Name))
+		if err != nil {
+			return err
+		}
+		rowFilter, err := newRowFilter(kind, context.String(stateFilterFlag.Name), context.String(minBalanceFlag.Name))
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if output := context.String(outputFlag.Name); output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+		buffered := bufio.NewWriter(out)
+		defer buffered.Flush()
+
+		var writer rowWriter
+		switch context.String(formatFlag.Name) {
+		case formatJson:
+			writer = newJsonWriter(buffered, columns)
+		case formatCsv:
+			writer = newCsvWriter(buffered, columns)
+		default:
+			return errors.Errorf("unknown format %v", context.String(formatFlag.Name))
+		}
+
+		db, err := node.OpenOfflineDatabase(context.String(config.DataDirFlag.Name), "idenachain", context.String(config.DbBackendFlag.Name))
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		head := database.NewRepo(db).ReadHead()
+		if head == nil {
+			return errors.New("head is not found")
+		}
+		height := head.Height()
+		if context.IsSet(heightFlag.Name) {
+			height = context.Uint64(heightFlag.Name)
+			if height > head.Height() {
+				return errors.Errorf("height %v is above the head %v", height, head.Height())
+			}
+		}
+
+		appState, err := appstate.NewAppState(db, eventbus.New())
+		if err != nil {
+			return err
+		}
+		if err := appState.Initialize(height); err != nil {
+			return errors.Wrapf(err, "state at height %v is not available", height)
+		}
+
+		var writeErr error
+		var count int
+		write := func(r *row) {
+			if writeErr != nil || !rowFilter(r) {
+				return
+			}
+			if writeErr = writer.Write(r); writeErr == nil {
+				count++
+			}
+		}
+
+		if err := writer.WriteHeader(); err != nil {
+			return err
+		}
+		switch kind {
+		case kindAccounts:
+			appState.State.IterateOverAccounts(func(addr common.Address, account state.Account) {
+				write(&row{addr: addr, account: &account, balance: account.Balance})
+			})
+		case kindIdentities:
+			appState.State.IterateOverIdentities(func(addr common.Address, identity state.Identity) {
+				write(&row{addr: addr, identity: &identity, balance: appState.State.GetBalance(addr)})
+			})
+		case kindContracts:
+			appState.State.IterateContractValues(func(key []byte, value []byte) bool {
+				// key is prefix + contract address + storage key
+				addr := common.BytesToAddress(key[1 : common.AddressLength+1])
+				write(&row{addr: addr, key: key[common.AddressLength+1:], value: value})
+				return writeErr != nil
+			})
+		}
+		if writeErr != nil {
+			return writeErr
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		log.Info("State exported", "height", height, "kind", kind, "rows", count)
+		return nil
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func selectColumns(kind string, names string) ([]column, error) {
+	var all []column
+	switch kind {
+	case kindAccounts:
+		all = accountColumns
+	case kindIdentities:
+		all = identityColumns
+	case kindContracts:
+		all = contractColumns
+	default:
+		return nil, errors.Errorf("unknown kind %v", kind)
+	}
+	if names == "" {
+		return all, nil
+	}
+	var result []column
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, c := range all {
+			if strings.EqualFold(c.name, name) {
+				result = append(result, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("unknown column %v for %v", name, kind)
+		}
+	}
+	return result, nil
+}
+
+func newRowFilter(kind string, states string, minBalance string) (func(r *row) bool, error) {
+	var allowedStates map[state.IdentityState]struct{}
+	if states != "" {
+		if kind != kindIdentities {
+			return nil, errors.New("state filter is applicable to identities only")
+		}
+		allowedStates = make(map[state.IdentityState]struct{})
+		for _, name := range strings.Split(states, ",") {
+			identityState, ok := state.ParseIdentityState(strings.TrimSpace(name))
+			if !ok {
+				return nil, errors.Errorf("unknown identity state %v", name)
+			}
+			allowedStates[identityState] = struct{}{}
+		}
+	}
+	var minBalanceInt *big.Int
+	if minBalance != "" {
+		if kind == kindContracts {
+			return nil, errors.New("balance filter is not applicable to contracts")
+		}
+		value, err := decimal.NewFromString(minBalance)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid minimal balance")
+		}
+		minBalanceInt = blockchain.ConvertToInt(value)
+	}
+	return func(r *row) bool {
+		if allowedStates != nil {
+			if _, ok := allowedStates[r.identity.State]; !ok {
+				return false
+			}
+		}
+		if minBalanceInt != nil {
+			balance := r.balance
+			if balance == nil {
+				balance = common.Big0
+			}
+			if balance.Cmp(minBalanceInt) < 0 {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+type rowWriter interface {
+	WriteHeader() error
+	Write(r *row) error
+	Flush() error
+}
+
+type jsonWriter struct {
+	w       io.Writer
+	columns []column
+}
+
+func newJsonWriter(w io.Writer, columns []column) *jsonWriter {
+	return &jsonWriter{w: w, columns: columns}
+}
+
+func (w *jsonWriter) WriteHeader() error {
+	return nil
+}
+
+// Write writes the row as a json object, keys keep the order of columns
+func (w *jsonWriter) Write(r *row) error {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, c := range w.columns {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		key, _ := json.Marshal(c.name)
+		value, err := json.Marshal(c.value(r))
+		if err != nil {
+			return err
+		}
+		sb.Write(key)
+		sb.WriteByte(':')
+		sb.Write(value)
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w.w, sb.String())
+	return err
+}
+
+func (w *jsonWriter) Flush() error {
+	return nil
+}
+
+type csvWriter struct {
+	w       *csv.Writer
+	columns []column
+}
+
+func newCsvWriter(w io.Writer, columns []column) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w), columns: columns}
+}
+
+func (w *csvWriter) WriteHeader() error {
+	header := make([]string, 0, len(w.columns))
+	for _, c := range w.columns {
+		header = append(header, c.name)
+	}
+	return w.w.Write(header)
+}
+
+func (w *csvWriter) Write(r *row) error {
+	record := make([]string, 0, len(w.columns))
+	for _, c := range w.columns {
+		switch value := c.value(r).(type) {
+		case nil:
+			record = append(record, "")
+		case []string:
+			record = append(record, strings.Join(value, ";"))
+		default:
+			record = append(record, fmt.Sprint(value))
+		}
+	}
+	return w.w.Write(record)
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func hexOrNil(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return common.ToHex(data)
+}
@@ -9,15 +9,12 @@ import (
 	"github.com/idena-network/idena-go/core/state"
 	"github.com/idena-network/idena-go/database"
 	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
 	models "github.com/idena-network/idena-go/protobuf"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 	"os"
 	"runtime"
-
-	"github.com/syndtr/goleveldb/leveldb/filter"
-	"github.com/syndtr/goleveldb/leveldb/opt"
-	"github.com/tendermint/tm-db"
 )
 
 func main() {
@@ -25,6 +22,7 @@ func main() {
 
 	app.Flags = []cli.Flag{
 		config.DataDirFlag,
+		config.DbBackendFlag,
 		config.VerbosityFlag,
 	}
 
@@ -43,7 +41,7 @@ func main() {
 			return errors.New("datadir option is required")
 		}
 
-		db, err := OpenDatabase(context.String(config.DataDirFlag.Name), "idenachain", 16, 16)
+		db, err := node.OpenOfflineDatabase(context.String(config.DataDirFlag.Name), "idenachain", context.String(config.DbBackendFlag.Name))
 		if err != nil {
 			return err
 		}
@@ -246,12 +244,3 @@ func main() {
 		log.Error(err.Error())
 	}
 }
-
-func OpenDatabase(datadir string, name string, cache int, handles int) (db.DB, error) {
-	return db.NewGoLevelDBWithOpts(name, datadir, &opt.Options{
-		OpenFilesCacheCapacity: handles,
-		BlockCacheCapacity:     cache / 2 * opt.MiB,
-		WriteBuffer:            cache / 4 * opt.MiB,
-		Filter:                 filter.NewBloomFilter(10),
-	})
-}
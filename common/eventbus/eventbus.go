@@ -44,10 +44,16 @@ type Bus interface {
 	BusPublisher
 }
 
+// BusStats provides numbers of published events which handlers are still being executed
+type BusStats interface {
+	PendingEvents() map[EventID]int
+}
+
 // New returns new event bus
 func New() Bus {
 	b := &bus{
-		infos: make(map[EventID]subscriptionInfoList),
+		infos:   make(map[EventID]subscriptionInfoList),
+		pending: make(map[EventID]int),
 	}
 	return b
 }
@@ -60,9 +66,10 @@ type subscriptionInfo struct {
 type subscriptionInfoList []*subscriptionInfo
 
 type bus struct {
-	lock   sync.Mutex
-	nextID uint64
-	infos  map[EventID]subscriptionInfoList
+	lock    sync.Mutex
+	nextID  uint64
+	infos   map[EventID]subscriptionInfoList
+	pending map[EventID]int
 }
 
 func (bus *bus) Subscribe(eventID EventID, cb EventHandler) Subscription {
@@ -102,11 +109,32 @@ func (bus *bus) Unsubscribe(subscription Subscription) {
 
 func (bus *bus) Publish(event Event) {
 	infos := bus.copySubscriptions(event.EventID())
+	if len(infos) == 0 {
+		return
+	}
+	bus.changePending(event.EventID(), 1)
+	defer bus.changePending(event.EventID(), -1)
 	for _, sub := range infos {
 		sub.cb(event)
 	}
 }
 
+func (bus *bus) changePending(eventID EventID, delta int) {
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+	bus.pending[eventID] += delta
+}
+
+func (bus *bus) PendingEvents() map[EventID]int {
+	bus.lock.Lock()
+	defer bus.lock.Unlock()
+	result := make(map[EventID]int, len(bus.pending))
+	for eventID, cnt := range bus.pending {
+		result[eventID] = cnt
+	}
+	return result
+}
+
 func (bus *bus) copySubscriptions(eventID EventID) subscriptionInfoList {
 	// External code may subscribe/unsubscribe during iteration over callbacks,
 	//  so we need to copy subscribers to invoke callbacks.
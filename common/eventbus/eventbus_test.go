@@ -167,3 +167,17 @@ func TestBus_PublishRecursive(t *testing.T) {
 
 	assert.Equal(t, moonEventCount, 5)
 }
+
+func TestBus_PendingEvents(t *testing.T) {
+	bus := New()
+	stats := bus.(BusStats)
+
+	var pendingInHandler int
+	bus.Subscribe(eventMoonEclipse, func(e Event) {
+		pendingInHandler = stats.PendingEvents()[eventMoonEclipse]
+	})
+	bus.Publish(&moonEclipseEvent{})
+
+	assert.Equal(t, 1, pendingInHandler)
+	assert.Equal(t, 0, stats.PendingEvents()[eventMoonEclipse])
+}
@@ -126,6 +126,21 @@ func CalculateIdentityScores(scores []byte, totalShortPoints float32, totalShort
 	return sumPoints, sumFlips
 }
 
+// CalculateNewTotalScore returns the total score of the identity after the validation with the given short session results
+func CalculateNewTotalScore(scores []byte, shortPoints float32, shortFlipsCount uint32, totalShortPoints float32, totalShortFlipsCount uint32) (totalScore float32, totalFlips uint32) {
+	newScores := make([]byte, len(scores))
+	copy(newScores, scores)
+	newScores = append(newScores, EncodeScore(shortPoints, shortFlipsCount))
+
+	if len(newScores) > LastScoresCount {
+		newScores = newScores[len(newScores)-LastScoresCount:]
+	}
+
+	resPoints, resFlips := CalculateIdentityScores(newScores, totalShortPoints, totalShortFlipsCount)
+
+	return resPoints / float32(resFlips), resFlips
+}
+
 func HashToFloat(hash [32]byte, modifier int64) *big.Float {
 	v := new(big.Float).SetInt(new(big.Int).SetBytes(hash[:]))
 
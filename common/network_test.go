@@ -115,3 +115,75 @@ func Test_Scores(t *testing.T) {
 		require.Equal(t, b, y)
 	}
 }
+
+func TestCalculateNewTotalScore(t *testing.T) {
+	var a float32
+	var b uint32
+
+	a, b = CalculateNewTotalScore([]byte{}, 4, 6, 0, 0)
+	require.Equal(t, float32(4)/6, a)
+	require.Equal(t, uint32(6), b)
+
+	a, b = CalculateNewTotalScore([]byte{}, 4, 6, 140, 145)
+	require.Equal(t, float32(130)/137, a)
+	require.Equal(t, uint32(137), b)
+
+	a, b = CalculateNewTotalScore([]byte{EncodeScore(3, 5)}, 5, 6, 150, 163)
+	require.Equal(t, float32(128)/141, a)
+	require.Equal(t, uint32(141), b)
+
+	a, b = CalculateNewTotalScore([]byte{EncodeScore(4, 6)}, 3.5, 6, 237.5, 255)
+	require.Equal(t, float32(197.5)/216, a)
+	require.Equal(t, uint32(216), b)
+
+	a, b = CalculateNewTotalScore([]byte{
+		EncodeScore(4, 6),
+		EncodeScore(3.5, 6),
+		EncodeScore(5, 6),
+		EncodeScore(6, 6),
+	}, 4, 5, 237.5, 255)
+	require.Equal(t, float32(141.25)/157, a)
+	require.Equal(t, uint32(157), b)
+
+	a, b = CalculateNewTotalScore([]byte{
+		EncodeScore(4, 6),
+		EncodeScore(3.5, 6),
+		EncodeScore(5, 6),
+		EncodeScore(6, 6),
+		EncodeScore(4, 5),
+		EncodeScore(6, 6),
+		EncodeScore(5, 6),
+		EncodeScore(4, 5),
+	}, 4, 6, 237.5, 255)
+	require.Equal(t, float32(65.25)/78, a)
+	require.Equal(t, uint32(78), b)
+
+	a, b = CalculateNewTotalScore([]byte{
+		EncodeScore(4, 6),
+		EncodeScore(3.5, 6),
+		EncodeScore(5, 6),
+		EncodeScore(6, 6),
+		EncodeScore(4, 5),
+		EncodeScore(6, 6),
+		EncodeScore(5, 6),
+		EncodeScore(4, 5),
+		EncodeScore(4, 6),
+	}, 6, 6, 237.5, 255)
+	require.Equal(t, float32(47.5)/58, a)
+	require.Equal(t, uint32(58), b)
+
+	a, b = CalculateNewTotalScore([]byte{
+		EncodeScore(4, 6),
+		EncodeScore(3.5, 6),
+		EncodeScore(5, 6),
+		EncodeScore(6, 6),
+		EncodeScore(4, 5),
+		EncodeScore(6, 6),
+		EncodeScore(5, 6),
+		EncodeScore(4, 5),
+		EncodeScore(4, 6),
+		EncodeScore(6, 6),
+	}, 5, 5, 237.5, 255)
+	require.Equal(t, float32(48.5)/57, a)
+	require.Equal(t, uint32(57), b)
+}
@@ -4,4 +4,7 @@ type BlockchainConfig struct {
 	// distance between blocks with permanent certificates
 	StoreCertRange uint64
 	BurnTxRange    uint64
+	// number of finished epochs whose block bodies, tx indexes and receipts are kept by prune command, 0 disables pruning,
+	// the running node doesn't prune, so it is applied only when the stopped node is pruned by the command
+	RetainEpochs uint32
 }
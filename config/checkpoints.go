@@ -0,0 +1,51 @@
+package config
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"github.com/pkg/errors"
+	"sort"
+)
+
+// Checkpoint is a trusted block which every synced chain must contain
+type Checkpoint struct {
+	Height       uint64
+	Hash         common.Hash
+	IdentityRoot *common.Hash `json:",omitempty"`
+	// optional state root, allows to check snapshot manifests at the checkpoint height
+	Root *common.Hash `json:",omitempty"`
+}
+
+// Checkpoints returns trusted checkpoints from genesis and sync configs sorted by height
+func (c *Config) Checkpoints() ([]Checkpoint, error) {
+	byHeight := make(map[uint64]Checkpoint)
+	add := func(list []Checkpoint) error {
+		for _, checkpoint := range list {
+			if checkpoint.Height == 0 {
+				return errors.New("checkpoint height should be positive")
+			}
+			if existing, ok := byHeight[checkpoint.Height]; ok && existing.Hash != checkpoint.Hash {
+				return errors.Errorf("conflicting checkpoints at height %v", checkpoint.Height)
+			}
+			byHeight[checkpoint.Height] = checkpoint
+		}
+		return nil
+	}
+	if c.GenesisConf != nil {
+		if err := add(c.GenesisConf.Checkpoints); err != nil {
+			return nil, err
+		}
+	}
+	if c.Sync != nil {
+		if err := add(c.Sync.Checkpoints); err != nil {
+			return nil, err
+		}
+	}
+	result := make([]Checkpoint, 0, len(byHeight))
+	for _, checkpoint := range byHeight {
+		result = append(result, checkpoint)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Height < result[j].Height
+	})
+	return result, nil
+}
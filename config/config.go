@@ -40,6 +40,8 @@ type Config struct {
 	OfflineDetection *OfflineDetectionConfig
 	Blockchain       *BlockchainConfig
 	Mempool          *Mempool
+	Metrics          *MetricsConfig
+	Database         *DatabaseConfig
 }
 
 func (c *Config) ProvideNodeKey(key string, password string, withBackup bool) error {
@@ -185,6 +187,8 @@ func MakeConfig(ctx *cli.Context, cfgTransform func(cfg *Config)) (*Config, erro
 	if ctx.IsSet(DataDirFlag.Name) {
 		cfg.DataDir = ctx.String(DataDirFlag.Name)
 	}
+	// the transformation reads the database, so its backend has to be known before
+	applyDatabaseFlags(ctx, cfg)
 	cfgTransform(cfg)
 	applyFlags(ctx, cfg)
 	return cfg, nil
@@ -263,7 +267,9 @@ func getDefaultConfig(dataDir string) *Config {
 			StoreCertRange: DefaultStoreCertRange,
 			BurnTxRange:    DefaultBurntTxRange,
 		},
-		Mempool: GetDefaultMempoolConfig(),
+		Mempool:  GetDefaultMempoolConfig(),
+		Metrics:  GetDefaultMetricsConfig(),
+		Database: GetDefaultDatabaseConfig(),
 	}
 }
 
@@ -277,6 +283,7 @@ func applyFlags(ctx *cli.Context, cfg *Config) {
 	applyIpfsFlags(ctx, cfg)
 	applyValidationFlags(ctx, cfg)
 	applySyncFlags(ctx, cfg)
+	applyMetricsFlags(ctx, cfg)
 }
 
 func applyCommonFlags(ctx *cli.Context, cfg *Config) {
@@ -292,6 +299,9 @@ func applySyncFlags(ctx *cli.Context, cfg *Config) {
 	if ctx.IsSet(ForceFullSyncFlag.Name) {
 		cfg.Sync.ForceFullSync = ctx.Uint64(ForceFullSyncFlag.Name)
 	}
+	if ctx.IsSet(LightModeFlag.Name) {
+		cfg.Sync.LightMode = ctx.Bool(LightModeFlag.Name)
+	}
 }
 
 func applyP2PFlags(ctx *cli.Context, cfg *Config) {
@@ -318,6 +328,24 @@ func applyRpcFlags(ctx *cli.Context, cfg *Config) {
 	}
 }
 
+func applyMetricsFlags(ctx *cli.Context, cfg *Config) {
+	if ctx.IsSet(MetricsFlag.Name) {
+		cfg.Metrics.Enabled = ctx.Bool(MetricsFlag.Name)
+	}
+	if ctx.IsSet(MetricsAddrFlag.Name) {
+		cfg.Metrics.HTTPHost = ctx.String(MetricsAddrFlag.Name)
+	}
+	if ctx.IsSet(MetricsPortFlag.Name) {
+		cfg.Metrics.HTTPPort = ctx.Int(MetricsPortFlag.Name)
+	}
+}
+
+func applyDatabaseFlags(ctx *cli.Context, cfg *Config) {
+	if ctx.IsSet(DbBackendFlag.Name) {
+		cfg.Database.Backend = ctx.String(DbBackendFlag.Name)
+	}
+}
+
 func applyGenesisFlags(ctx *cli.Context, cfg *Config) {
 	if ctx.IsSet(GodAddressFlag.Name) {
 		cfg.GenesisConf.GodAddress = common.HexToAddress(ctx.String(GodAddressFlag.Name))
@@ -0,0 +1,26 @@
+package config
+
+const (
+	GoLevelDbBackend = "goleveldb"
+	BoltDbBackend    = "boltdb"
+)
+
+// DatabaseBackends lists backends which the chain database can be stored with
+var DatabaseBackends = []string{GoLevelDbBackend, BoltDbBackend}
+
+type DatabaseConfig struct {
+	// Backend is a key-value store of the chain database, boltdb is available only in builds with boltdb tag
+	Backend string
+	// Cache is a memory budget in MiB for block cache and write buffer of goleveldb
+	Cache int
+	// Handles is a number of open files which goleveldb keeps cached
+	Handles int
+}
+
+func GetDefaultDatabaseConfig() *DatabaseConfig {
+	return &DatabaseConfig{
+		Backend: GoLevelDbBackend,
+		Cache:   16,
+		Handles: 16,
+	}
+}
@@ -8,6 +8,8 @@ const (
 	DefaultRpcHost            = "localhost"
 	DefaultRpcPort            = 9009
 	DefaultRpcPortBuiltInNode = 9119
+	DefaultMetricsHost        = "localhost"
+	DefaultMetricsPort        = 9010
 	DefaultIpfsDataDir        = "ipfs"
 	DefaultIpfsPort           = 40405
 	DefaultGodAddress         = "0x4d60dc6a2cba8c3ef1ba5e1eba5c12c54cee6b61"
@@ -106,6 +108,10 @@ var (
 		Name:  "forcefullsync",
 		Usage: "Force full sync on last blocks",
 	}
+	LightModeFlag = cli.BoolFlag{
+		Name:  "light",
+		Usage: "Sync block headers only and fetch state on demand",
+	}
 	ProfileFlag = cli.StringFlag{
 		Name:  "profile",
 		Usage: "Configuration profile",
@@ -131,4 +137,20 @@ var (
 		Name:  "autoonline",
 		Usage: "Node will automatically turn on online mining status",
 	}
+	MetricsFlag = cli.BoolFlag{
+		Name:  "metrics",
+		Usage: "Enable metrics HTTP endpoint",
+	}
+	MetricsAddrFlag = cli.StringFlag{
+		Name:  "metricsaddr",
+		Usage: "Metrics HTTP endpoint listening address",
+	}
+	MetricsPortFlag = cli.IntFlag{
+		Name:  "metricsport",
+		Usage: "Metrics HTTP endpoint listening port",
+	}
+	DbBackendFlag = cli.StringFlag{
+		Name:  "dbbackend",
+		Usage: "Chain database backend: goleveldb or boltdb",
+	}
 )
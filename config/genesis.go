@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/hexutil"
 	"math/big"
 )
 
@@ -9,6 +10,10 @@ type GenesisAllocation struct {
 	Balance *big.Int
 	Stake   *big.Int
 	State   uint8
+	// public key of the identity, it's required for identities which should take part in the first validation
+	PubKey hexutil.Bytes
+	// flips which the identity should make for the first validation
+	RequiredFlips uint8
 }
 
 type GenesisConf struct {
@@ -16,4 +21,5 @@ type GenesisConf struct {
 	GodAddress        common.Address
 	FirstCeremonyTime int64
 	GodAddressInvites uint16
+	Checkpoints       []Checkpoint
 }
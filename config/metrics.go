@@ -0,0 +1,23 @@
+package config
+
+import "fmt"
+
+type MetricsConfig struct {
+	Enabled  bool
+	HTTPHost string
+	HTTPPort int
+}
+
+func GetDefaultMetricsConfig() *MetricsConfig {
+	return &MetricsConfig{
+		HTTPHost: DefaultMetricsHost,
+		HTTPPort: DefaultMetricsPort,
+	}
+}
+
+func (c *MetricsConfig) HTTPEndpoint() string {
+	if !c.Enabled || c.HTTPHost == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", c.HTTPHost, c.HTTPPort)
+}
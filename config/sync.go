@@ -7,4 +7,6 @@ type SyncConfig struct {
 	ForceFullSync       uint64
 	LoadAllFlips        bool
 	AllFlipsLoadingTime time.Duration
+	Checkpoints         []Checkpoint
+	LightMode           bool
 }
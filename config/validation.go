@@ -11,6 +11,15 @@ const (
 	AfterLongSession = 1 * time.Minute
 )
 
+const (
+	// AutoAnswerKnown answers flips with the answers which their authors wrote to KnownAnswersFile
+	AutoAnswerKnown = "known"
+	// AutoAnswerRandom answers flips randomly
+	AutoAnswerRandom = "random"
+	// AutoAnswerAbstain submits empty answers
+	AutoAnswerAbstain = "abstain"
+)
+
 type ValidationConfig struct {
 	// Do not use directly
 	ValidationInterval time.Duration
@@ -20,6 +29,15 @@ type ValidationConfig struct {
 	ShortSessionDuration time.Duration
 	// Do not use directly
 	LongSessionDuration time.Duration
+	// Strategy to answer flips automatically during validation ceremony, intended for test networks only.
+	// Ignored in the main network.
+	AutoAnswer string
+	// KnownAnswersFile is shared by nodes of the test network, flip authors write correct answers to their flips there
+	KnownAnswersFile string
+	// KeepFinishedEpochDb keeps the epoch db of the finished epoch until the next epoch is finished, so the ceremony
+	// replay can use it as an additional source of data besides blocks. Flips, answers and evidence maps of one more
+	// epoch are kept on disk.
+	KeepFinishedEpochDb bool
 }
 
 func (cfg *ValidationConfig) GetNextValidationTime(validationTime time.Time, networkSize int) time.Time {
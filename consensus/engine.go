@@ -65,6 +65,7 @@ type Engine struct {
 	upgrader          *upgrade.Upgrader
 	eventBus          eventbus.Bus
 	statsCollector    collector.StatsCollector
+	rounds            *roundsHistory
 }
 
 func NewEngine(chain *blockchain.Blockchain, gossipHandler *protocol.IdenaGossipHandler, proposals *pengings.Proposals, config *config.Config,
@@ -94,6 +95,7 @@ func NewEngine(chain *blockchain.Blockchain, gossipHandler *protocol.IdenaGossip
 		ipfsProxy:         ipfsProxy,
 		eventBus:          eventBus,
 		statsCollector:    statsCollector,
+		rounds:            newRoundsHistory(MaxStoredRounds),
 	}
 }
 
@@ -110,6 +112,16 @@ func (engine *Engine) GetProcess() string {
 	return engine.process
 }
 
+// GetRounds returns data of up to count last completed consensus rounds, the most recent round goes first
+func (engine *Engine) GetRounds(count int) []*RoundInfo {
+	return engine.rounds.last(count)
+}
+
+// GetCurrentRound returns data collected for the round in progress, nil if there is no such round
+func (engine *Engine) GetCurrentRound() *RoundInfo {
+	return engine.rounds.currentRound()
+}
+
 func (engine *Engine) ReadonlyAppState() (*appstate.AppState, error) {
 	return engine.appState.Readonly(engine.chain.Head.Height())
 }
@@ -183,6 +195,13 @@ func (engine *Engine) loop() {
 			continue
 		}
 
+		if engine.cfg.Sync.LightMode {
+			// light node does not take part in consensus, it just follows the headers chain
+			engine.synced = true
+			time.Sleep(time.Second * 10)
+			continue
+		}
+
 		if !engine.cfg.Consensus.Automine && !engine.pm.HasPeers() {
 			time.Sleep(time.Second * 5)
 			engine.synced = false
@@ -208,6 +227,7 @@ func (engine *Engine) loop() {
 
 		engine.prevRoundDuration = 0
 		roundStart := time.Now().UTC()
+		engine.rounds.startRound(round)
 
 		shardId, _ := engine.chain.CoinbaseShard()
 		engine.log.Info("Start loop", "round", round, "head", head.Hash().Hex(), "shardId", shardId, "p2p-shardId", engine.pm.OwnPeeringShardId(), "total-peers",
@@ -215,6 +235,7 @@ func (engine *Engine) loop() {
 			"network", engine.appState.ValidatorsCache.NetworkSize())
 
 		engine.process = "Check if I'm proposer"
+		engine.rounds.startPhase(PhaseProposal)
 
 		isProposer, proposerProof := engine.chain.GetProposerSortition()
 
@@ -226,14 +247,25 @@ func (engine *Engine) loop() {
 				engine.log.Info("Selected as proposer", "block", block.Hash().Hex(), "round", round, "thresholdVrf", engine.appState.State.VrfProposerThreshold())
 			}
 		}
+		engine.rounds.update(func(info *RoundInfo) {
+			info.IsProposer = isProposer
+			if block != nil {
+				info.ProposedBlock = block.Hash()
+			}
+		})
 
 		engine.process = "Calculating highest-priority pubkey"
+		engine.rounds.startPhase(PhaseWaitProof)
 
 		proposerPubKey := engine.getHighestProposerPubKey(round)
 		engine.calculateTimeDiff(round, roundStart)
 		proposer := engine.fmtProposer(proposerPubKey)
 
 		engine.log.Info("Selected proposer", "proposer", proposer)
+		engine.rounds.update(func(info *RoundInfo) {
+			info.ProposerPubKey = proposerPubKey
+			info.ProposerProofs = engine.proposals.ProofsCount(round)
+		})
 		emptyBlock := engine.chain.GenerateEmptyBlock()
 
 		var extraDelayForReductionOne time.Duration
@@ -243,6 +275,7 @@ func (engine *Engine) loop() {
 		} else {
 
 			engine.process = "Waiting for block from proposer"
+			engine.rounds.startPhase(PhaseWaitBlock)
 			block, extraDelayForReductionOne = engine.waitForBlock(proposerPubKey)
 
 			if block == nil {
@@ -250,10 +283,16 @@ func (engine *Engine) loop() {
 			}
 		}
 
+		engine.rounds.startPhase(PhaseReduction)
 		blockHash := engine.reduction(round, block, extraDelayForReductionOne)
+		engine.rounds.update(func(info *RoundInfo) {
+			info.ReductionBlock = blockHash
+		})
+		engine.rounds.startPhase(PhaseBinaryBa)
 		blockHash, cert, err := engine.binaryBa(blockHash)
 		if err != nil {
 			engine.log.Info("Binary Ba is failed", "err", err)
+			engine.rounds.completeRound(common.Hash{}, RoundResultFailed, err)
 
 			if err == ForkDetected {
 				if revertedTxs, err := engine.forkResolver.ApplyFork(); err != nil {
@@ -267,6 +306,7 @@ func (engine *Engine) loop() {
 			continue
 		}
 		engine.process = "Count final votes"
+		engine.rounds.startPhase(PhaseFinalVotes)
 		var hash common.Hash
 		var finalCert *types.FullBlockCert
 		if blockHash != emptyBlock.Hash() {
@@ -276,31 +316,39 @@ func (engine *Engine) loop() {
 				blockHash = hash
 			}
 		}
+		engine.rounds.startPhase(PhaseBlockApplied)
 		if blockHash == emptyBlock.Hash() {
 			if err := engine.chain.AddBlock(emptyBlock, nil, engine.statsCollector); err != nil {
 				engine.log.Error("Add empty block", "err", err)
+				engine.rounds.completeRound(blockHash, RoundResultFailed, err)
 				continue
 			}
 
 			engine.chain.WriteCertificate(blockHash, cert.Compress(), engine.chain.IsPermanentCert(emptyBlock.Header))
 			engine.log.Info("Reached consensus on empty block")
+			engine.rounds.completeRound(blockHash, RoundResultEmpty, nil)
 		} else {
 			block, err := engine.getBlockByHash(round, blockHash)
 			if err == nil {
 				if err := engine.chain.AddBlock(block, nil, engine.statsCollector); err != nil {
 					engine.log.Error("Add block", "err", err)
+					engine.rounds.completeRound(blockHash, RoundResultFailed, err)
 					continue
 				}
+				result := RoundResultTentative
 				if hash == blockHash {
 					engine.log.Info("Reached FINAL", "block", blockHash.Hex(), "txs", len(block.Body.Transactions))
 					engine.chain.WriteFinalConsensus(blockHash)
 					cert = finalCert
+					result = RoundResultFinal
 				} else {
 					engine.log.Info("Reached TENTATIVE", "block", blockHash.Hex(), "txs", len(block.Body.Transactions))
 				}
 				engine.chain.WriteCertificate(blockHash, cert.Compress(), engine.chain.IsPermanentCert(block.Header))
+				engine.rounds.completeRound(blockHash, result, nil)
 			} else {
 				engine.log.Warn("Confirmed block is not found", "block", blockHash.Hex())
+				engine.rounds.completeRound(blockHash, RoundResultFailed, err)
 			}
 		}
 		engine.prevRoundDuration = time.Now().UTC().Sub(roundStart)
@@ -498,10 +546,25 @@ func (engine *Engine) countVotes(round uint64, step uint8, parentHash common.Has
 	defer engine.log.Debug("Finish count votes", "step", step)
 
 	byBlock := make(map[common.Hash]map[common.Address]*types.Vote)
+	stepInfo := &RoundStep{
+		Step: step,
+	}
+	countingStart := time.Now()
+	defer func() {
+		stepInfo.Duration = time.Since(countingStart)
+		stepInfo.Votes = make(map[common.Hash]int)
+		for hash, votes := range byBlock {
+			if len(votes) > 0 {
+				stepInfo.Votes[hash] = len(votes)
+			}
+		}
+		engine.rounds.addStep(round, stepInfo)
+	}()
 	validators := engine.appState.ValidatorsCache.GetOnlineValidators(engine.chain.Head.Seed(), round, step, engine.chain.GetCommitteeSize(engine.appState.ValidatorsCache, step == types.Final))
 	if validators == nil {
 		hash := common.Hash{}
 		err := errors.Errorf("validators were not setup, step=%v", step)
+		stepInfo.Err = err.Error()
 		engine.statsCollector.SubmitVoteCountingResult(round, step, validators, hash, nil, err)
 		return hash, nil, err
 	}
@@ -509,6 +572,7 @@ func (engine *Engine) countVotes(round uint64, step uint8, parentHash common.Has
 	engine.offlineDetector.PushValidators(round, step, validators)
 
 	necessaryVotesCount -= validators.VotesCountSubtrahend(engine.cfg.Consensus.AgreementThreshold)
+	stepInfo.NecessaryVotes = necessaryVotesCount
 
 	for start := time.Now(); time.Since(start) < timeout; {
 		m := engine.votes.GetVotesOfRound(round)
@@ -560,8 +624,10 @@ func (engine *Engine) countVotes(round uint64, step uint8, parentHash common.Has
 			})
 
 			engine.statsCollector.SubmitVoteCountingStepResult(round, step, byBlock, necessaryVotesCount, checkedRoundVotes)
+			stepInfo.CheckedVotes = checkedRoundVotes
 
 			if found {
+				stepInfo.ConsensusBlockHash = bestHash
 				engine.statsCollector.SubmitVoteCountingResult(round, step, validators, bestHash, &cert, nil)
 				return bestHash, &cert, nil
 			}
@@ -570,6 +636,7 @@ func (engine *Engine) countVotes(round uint64, step uint8, parentHash common.Has
 	}
 	hash := common.Hash{}
 	err := errors.New(fmt.Sprintf("votes for step is not received, step=%v", step))
+	stepInfo.Err = err.Error()
 	engine.statsCollector.SubmitVoteCountingResult(round, step, validators, hash, nil, err)
 	return hash, nil, err
 }
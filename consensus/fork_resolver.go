@@ -86,6 +86,11 @@ func (resolver *ForkResolver) processBlocks(blocks chan types.BlockBundle, peerI
 
 	resolver.log.Info("common block is found", "peerId", peerId)
 	forkBlocks = sortBlocks(forkBlocks)
+	for _, bundle := range forkBlocks {
+		if err := resolver.chain.ValidateCheckpoint(bundle.Block.Header); err != nil {
+			return errors.Errorf("unacceptable fork, peerId=%v, err=%v", peerId, err)
+		}
+	}
 	if err := resolver.checkForkSize(forkBlocks); err == nil {
 		commonHeight := forkBlocks[0].Block.Height() - 1
 		if err := resolver.chain.ValidateSubChain(commonHeight, forkBlocks); err != nil {
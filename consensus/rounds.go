@@ -0,0 +1,178 @@
+package consensus
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"sync"
+	"time"
+)
+
+const (
+	MaxStoredRounds = 100
+)
+
+const (
+	RoundResultFinal     = "final"
+	RoundResultTentative = "tentative"
+	RoundResultEmpty     = "empty"
+	RoundResultFailed    = "failed"
+)
+
+const (
+	PhaseProposal     = "proposal"
+	PhaseWaitProof    = "waitProof"
+	PhaseWaitBlock    = "waitBlock"
+	PhaseReduction    = "reduction"
+	PhaseBinaryBa     = "binaryBa"
+	PhaseFinalVotes   = "finalVotes"
+	PhaseBlockApplied = "blockApplied"
+)
+
+type RoundStep struct {
+	Step uint8
+	// number of valid votes per voted block hash
+	Votes              map[common.Hash]int
+	CheckedVotes       int
+	NecessaryVotes     int
+	ConsensusBlockHash common.Hash
+	Err                string
+	Duration           time.Duration
+}
+
+type RoundPhase struct {
+	Name     string
+	Duration time.Duration
+}
+
+type RoundInfo struct {
+	Round          uint64
+	Start          time.Time
+	Finish         time.Time
+	IsProposer     bool
+	ProposerProofs int
+	ProposerPubKey []byte
+	ProposedBlock  common.Hash
+	ReductionBlock common.Hash
+	Block          common.Hash
+	Result         string
+	Err            string
+	Steps          []*RoundStep
+	Phases         []*RoundPhase
+
+	phaseStart time.Time
+}
+
+func (info *RoundInfo) copy() *RoundInfo {
+	res := *info
+	res.Steps = make([]*RoundStep, len(info.Steps))
+	copy(res.Steps, info.Steps)
+	res.Phases = make([]*RoundPhase, 0, len(info.Phases))
+	for _, phase := range info.Phases {
+		p := *phase
+		res.Phases = append(res.Phases, &p)
+	}
+	return &res
+}
+
+// roundsHistory keeps data about the last consensus rounds, current round is written by the consensus loop only
+type roundsHistory struct {
+	mutex   sync.RWMutex
+	rounds  []*RoundInfo
+	current *RoundInfo
+	limit   int
+}
+
+func newRoundsHistory(limit int) *roundsHistory {
+	return &roundsHistory{
+		limit: limit,
+	}
+}
+
+func (h *roundsHistory) startRound(round uint64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	now := time.Now().UTC()
+	h.current = &RoundInfo{
+		Round:      round,
+		Start:      now,
+		phaseStart: now,
+	}
+}
+
+// startPhase completes the previous phase of the current round and starts a new one
+func (h *roundsHistory) startPhase(name string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.current == nil {
+		return
+	}
+	h.completePhase()
+	h.current.Phases = append(h.current.Phases, &RoundPhase{Name: name})
+}
+
+func (h *roundsHistory) completePhase() {
+	now := time.Now().UTC()
+	if len(h.current.Phases) > 0 {
+		h.current.Phases[len(h.current.Phases)-1].Duration = now.Sub(h.current.phaseStart)
+	}
+	h.current.phaseStart = now
+}
+
+func (h *roundsHistory) update(f func(info *RoundInfo)) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.current == nil {
+		return
+	}
+	f(h.current)
+}
+
+func (h *roundsHistory) addStep(round uint64, step *RoundStep) {
+	h.update(func(info *RoundInfo) {
+		if info.Round == round {
+			info.Steps = append(info.Steps, step)
+		}
+	})
+}
+
+func (h *roundsHistory) completeRound(block common.Hash, result string, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.current == nil {
+		return
+	}
+	h.completePhase()
+	h.current.Finish = time.Now().UTC()
+	h.current.Block = block
+	h.current.Result = result
+	if err != nil {
+		h.current.Err = err.Error()
+	}
+	h.rounds = append(h.rounds, h.current)
+	if len(h.rounds) > h.limit {
+		h.rounds = h.rounds[len(h.rounds)-h.limit:]
+	}
+	h.current = nil
+}
+
+// last returns up to count completed rounds starting from the most recent one
+func (h *roundsHistory) last(count int) []*RoundInfo {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	if count <= 0 || count > len(h.rounds) {
+		count = len(h.rounds)
+	}
+	result := make([]*RoundInfo, 0, count)
+	for i := len(h.rounds) - 1; i >= len(h.rounds)-count; i-- {
+		result = append(result, h.rounds[i].copy())
+	}
+	return result
+}
+
+func (h *roundsHistory) currentRound() *RoundInfo {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	if h.current == nil {
+		return nil
+	}
+	return h.current.copy()
+}
@@ -0,0 +1,45 @@
+package consensus
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRoundsHistory(t *testing.T) {
+	h := newRoundsHistory(3)
+
+	require.Nil(t, h.currentRound())
+	require.Empty(t, h.last(0))
+
+	for round := uint64(1); round <= 5; round++ {
+		h.startRound(round)
+		h.startPhase(PhaseProposal)
+		h.startPhase(PhaseReduction)
+		h.addStep(round, &RoundStep{Step: 1, Votes: map[common.Hash]int{{0x1}: 5}})
+		h.addStep(round+1, &RoundStep{Step: 2})
+		require.Equal(t, round, h.currentRound().Round)
+		h.completeRound(common.Hash{byte(round)}, RoundResultFinal, nil)
+	}
+	h.startRound(6)
+	h.completeRound(common.Hash{}, RoundResultFailed, errors.New("no consensus"))
+
+	require.Nil(t, h.currentRound())
+
+	rounds := h.last(0)
+	require.Len(t, rounds, 3)
+	require.Equal(t, uint64(6), rounds[0].Round)
+	require.Equal(t, RoundResultFailed, rounds[0].Result)
+	require.Equal(t, "no consensus", rounds[0].Err)
+	require.Equal(t, uint64(5), rounds[1].Round)
+	require.Equal(t, common.Hash{0x5}, rounds[1].Block)
+	require.Len(t, rounds[1].Phases, 2)
+	require.Equal(t, PhaseProposal, rounds[1].Phases[0].Name)
+	require.Len(t, rounds[1].Steps, 1)
+	require.Equal(t, 5, rounds[1].Steps[0].Votes[common.Hash{0x1}])
+
+	rounds = h.last(1)
+	require.Len(t, rounds, 1)
+	require.Equal(t, uint64(6), rounds[0].Round)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"os"
+)
+
+var (
+	contractAddressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "Address of the contract",
+	}
+	contractHeightFlag = cli.Uint64Flag{
+		Name:  "height",
+		Usage: "Height of the state, head is used by default",
+	}
+
+	contractDumpCommand = cli.Command{
+		Name:   "contract-dump",
+		Usage:  "Print storage of the contract of the stopped node as JSON, known keys of embedded contracts are decoded",
+		Flags:  []cli.Flag{contractAddressFlag, contractHeightFlag},
+		Action: contractDump,
+	}
+)
+
+func contractDump(context *cli.Context) error {
+	if !context.IsSet(contractAddressFlag.Name) {
+		return errors.New("address is required")
+	}
+	var contract common.Address
+	if err := contract.UnmarshalText([]byte(context.String(contractAddressFlag.Name))); err != nil {
+		return errors.Wrap(err, "invalid address")
+	}
+	cfg, err := makeCommandConfig(context)
+	if err != nil {
+		return err
+	}
+	dump, err := node.DumpContract(cfg, contract, context.Uint64(contractHeightFlag.Name))
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(dump)
+}
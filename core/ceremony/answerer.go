@@ -0,0 +1,184 @@
+package ceremony
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common/hexutil"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/log"
+	"github.com/pkg/errors"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+)
+
+// FlipAnswerer chooses answers to flips when the node takes part in validation ceremony automatically
+type FlipAnswerer interface {
+	Answer(cid []byte) types.Answer
+}
+
+// knownFlipAnswer is a line of the known answers file
+type knownFlipAnswer struct {
+	Cid    hexutil.Bytes `json:"cid"`
+	Answer types.Answer  `json:"answer"`
+}
+
+// WriteKnownFlipAnswer appends the correct answer of the flip author to the known answers file. Nodes of the test
+// network may share the file, each answer is written by a single append, so concurrent writers don't mix lines.
+func WriteKnownFlipAnswer(file string, cid []byte, answer types.Answer) error {
+	if answer != types.Left && answer != types.Right {
+		return errors.New("known answer should be left or right")
+	}
+	data, err := json.Marshal(&knownFlipAnswer{Cid: cid, Answer: answer})
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readKnownFlipAnswers(file string) (map[string]types.Answer, error) {
+	result := make(map[string]types.Answer)
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var item knownFlipAnswer
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			continue
+		}
+		result[string(item.Cid)] = item.Answer
+	}
+	return result, scanner.Err()
+}
+
+// knownAnswerer answers flips with the answers of their authors, flips without known answers are not answered
+type knownAnswerer struct {
+	file string
+}
+
+func (a knownAnswerer) Answer(cid []byte) types.Answer {
+	answers, err := readKnownFlipAnswers(a.file)
+	if err != nil {
+		log.Warn("Failed to read known flip answers", "file", a.file, "err", err)
+		return types.None
+	}
+	return answers[string(cid)]
+}
+
+type randomAnswerer struct {
+	rnd   *rand.Rand
+	mutex sync.Mutex
+}
+
+func (a *randomAnswerer) Answer(cid []byte) types.Answer {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return types.Answer(a.rnd.Intn(2) + 1)
+}
+
+type abstainAnswerer struct{}
+
+func (abstainAnswerer) Answer(cid []byte) types.Answer {
+	return types.None
+}
+
+// NewFlipAnswerer creates answerer by the strategy from config, nil is returned if automatic answering is disabled.
+// Automatic answering is not allowed in the default networks.
+func NewFlipAnswerer(cfg *config.Config) (FlipAnswerer, error) {
+	if cfg.Validation == nil || cfg.Validation.AutoAnswer == "" {
+		return nil, nil
+	}
+	if cfg.Network == blockchain.Mainnet || cfg.Network == blockchain.Testnet {
+		return nil, errors.New("automatic flip answering is not allowed in the default networks")
+	}
+	switch cfg.Validation.AutoAnswer {
+	case config.AutoAnswerKnown:
+		if cfg.Validation.KnownAnswersFile == "" {
+			return nil, errors.New("known answers file is required by the known answers strategy")
+		}
+		return knownAnswerer{file: cfg.Validation.KnownAnswersFile}, nil
+	case config.AutoAnswerRandom:
+		return &randomAnswerer{rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}, nil
+	case config.AutoAnswerAbstain:
+		return abstainAnswerer{}, nil
+	default:
+		return nil, errors.Errorf("unknown flip answering strategy %v", cfg.Validation.AutoAnswer)
+	}
+}
+
+// KnownFlipAnswersEnabled returns true if the node shares answers of flip authors with other nodes of the test network
+func (vc *ValidationCeremony) KnownFlipAnswersEnabled() bool {
+	return vc.config.Validation.KnownAnswersFile != "" && vc.config.Network != blockchain.Mainnet && vc.config.Network != blockchain.Testnet
+}
+
+// RegisterKnownFlipAnswer shares the correct answer of the flip author with other nodes of the test network
+func (vc *ValidationCeremony) RegisterKnownFlipAnswer(cid []byte, answer types.Answer) error {
+	if !vc.KnownFlipAnswersEnabled() {
+		return errors.New("known flip answers are not enabled")
+	}
+	return WriteKnownFlipAnswer(vc.config.Validation.KnownAnswersFile, cid, answer)
+}
+
+func prepareAutoAnswers(answerer FlipAnswerer, flips [][]byte) *types.Answers {
+	answers := types.NewAnswers(uint(len(flips)))
+	for i, cid := range flips {
+		switch answerer.Answer(cid) {
+		case types.Left:
+			answers.Left(uint(i))
+		case types.Right:
+			answers.Right(uint(i))
+		}
+	}
+	return answers
+}
+
+func (vc *ValidationCeremony) autoSubmitShortAnswers() {
+	if vc.answerer == nil || vc.autoShortAnswersSent || !vc.shouldInteractWithNetwork() || !vc.isParticipant() {
+		return
+	}
+	coinbase := vc.secStore.GetAddress()
+	identity := vc.appState.State.GetIdentity(coinbase)
+	flips := vc.GetShortFlipsToSolve(coinbase, identity.ShiftedShardId())
+	if _, err := vc.SubmitShortAnswers(prepareAutoAnswers(vc.answerer, flips)); err != nil {
+		vc.log.Warn("Failed to submit short answers automatically", "err", err)
+		return
+	}
+	vc.autoShortAnswersSent = true
+	vc.log.Info("Short answers submitted automatically", "flips", len(flips))
+}
+
+func (vc *ValidationCeremony) autoSubmitLongAnswers() {
+	if vc.answerer == nil || vc.autoLongAnswersSent || !vc.shouldInteractWithNetwork() || !vc.isParticipant() {
+		return
+	}
+	// long answers are useless without short ones
+	if vc.epochDb.ReadOwnShortAnswersBits() == nil {
+		return
+	}
+	coinbase := vc.secStore.GetAddress()
+	identity := vc.appState.State.GetIdentity(coinbase)
+	flips := vc.GetLongFlipsToSolve(coinbase, identity.ShiftedShardId())
+	if _, err := vc.SubmitLongAnswers(prepareAutoAnswers(vc.answerer, flips)); err != nil {
+		vc.log.Warn("Failed to submit long answers automatically", "err", err)
+		return
+	}
+	vc.autoLongAnswersSent = true
+	vc.log.Info("Long answers submitted automatically", "flips", len(flips))
+}
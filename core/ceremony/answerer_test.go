@@ -0,0 +1,64 @@
+package ceremony
+
+import (
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/config"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewFlipAnswerer(t *testing.T) {
+	newConfig := func(network uint32, strategy string) *config.Config {
+		return &config.Config{Network: network, Validation: &config.ValidationConfig{AutoAnswer: strategy}}
+	}
+
+	answerer, err := NewFlipAnswerer(newConfig(100, ""))
+	require.NoError(t, err)
+	require.Nil(t, answerer)
+
+	_, err = NewFlipAnswerer(newConfig(blockchain.Mainnet, config.AutoAnswerRandom))
+	require.Error(t, err)
+	_, err = NewFlipAnswerer(newConfig(blockchain.Testnet, config.AutoAnswerRandom))
+	require.Error(t, err)
+	_, err = NewFlipAnswerer(newConfig(100, "unknown"))
+	require.Error(t, err)
+	// known answers strategy needs the file with answers
+	_, err = NewFlipAnswerer(newConfig(100, config.AutoAnswerKnown))
+	require.Error(t, err)
+
+	for _, strategy := range []string{config.AutoAnswerRandom, config.AutoAnswerAbstain} {
+		answerer, err := NewFlipAnswerer(newConfig(100, strategy))
+		require.NoError(t, err)
+		require.NotNil(t, answerer)
+	}
+	cfg := newConfig(100, config.AutoAnswerKnown)
+	cfg.Validation.KnownAnswersFile = filepath.Join(t.TempDir(), "answers")
+	answerer, err = NewFlipAnswerer(cfg)
+	require.NoError(t, err)
+	require.Equal(t, knownAnswerer{file: cfg.Validation.KnownAnswersFile}, answerer)
+}
+
+func TestPrepareAutoAnswers(t *testing.T) {
+	flips := [][]byte{{0x1}, {0x2}, {0x3}}
+	answerer := knownAnswerer{file: filepath.Join(t.TempDir(), "answers")}
+	require.Equal(t, types.None, answerer.Answer(flips[0]))
+	require.NoError(t, WriteKnownFlipAnswer(answerer.file, flips[0], types.Right))
+	require.NoError(t, WriteKnownFlipAnswer(answerer.file, flips[1], types.Left))
+	require.Error(t, WriteKnownFlipAnswer(answerer.file, flips[2], types.None))
+
+	answers := prepareAutoAnswers(answerer, flips)
+	answer, _ := answers.Answer(0)
+	require.Equal(t, types.Right, answer)
+	answer, _ = answers.Answer(1)
+	require.Equal(t, types.Left, answer)
+	answer, _ = answers.Answer(2)
+	require.Equal(t, types.None, answer)
+
+	answers = prepareAutoAnswers(abstainAnswerer{}, flips)
+	for i := range flips {
+		answer, _ := answers.Answer(uint(i))
+		require.Equal(t, types.None, answer)
+	}
+}
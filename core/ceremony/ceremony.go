@@ -51,6 +51,7 @@ const (
 type ValidationCeremony struct {
 	bus                      eventbus.Bus
 	db                       dbm.DB
+	repo                     *database.Repo
 	appState                 *appstate.AppState
 	flipper                  *flip.Flipper
 	secStore                 *secstore.SecStore
@@ -83,6 +84,9 @@ type ValidationCeremony struct {
 	newTxQueue               chan *types.Transaction
 	lottery                  *lottery
 	allFlipsIsLoading        bool
+	answerer                 FlipAnswerer
+	autoShortAnswersSent     bool
+	autoLongAnswersSent      bool
 }
 
 type flipWordsInfo struct {
@@ -96,6 +100,7 @@ type epochApplyingCache struct {
 	validationFailed    bool
 	validationResults   map[common.ShardId]*types.ValidationResults
 	pools               map[common.Address]struct{}
+	reports             map[common.Address]*types.ValidationReport
 }
 
 type cacheValue struct {
@@ -128,6 +133,7 @@ func NewValidationCeremony(appState *appstate.AppState, bus eventbus.Bus, flippe
 		log:                logger,
 		throttlingLogger:   throttlingLogger,
 		db:                 db,
+		repo:               database.NewRepo(db),
 		mempool:            mempool,
 		keysPool:           keysPool,
 		epochApplyingCache: make(map[uint64]epochApplyingCache),
@@ -146,6 +152,12 @@ func NewValidationCeremony(appState *appstate.AppState, bus eventbus.Bus, flippe
 		state.LongSessionPeriod:      vc.handleLongSessionPeriod,
 		state.AfterLongSessionPeriod: vc.handleAfterLongSessionPeriod,
 	}
+	if answerer, err := NewFlipAnswerer(config); err != nil {
+		logger.Error("Automatic flip answering is disabled", "err", err)
+	} else if answerer != nil {
+		logger.Warn("Flips will be answered automatically", "strategy", config.Validation.AutoAnswer)
+		vc.answerer = answerer
+	}
 	return vc
 }
 
@@ -206,12 +218,28 @@ func (vc *ValidationCeremony) addBlock(block *types.Block) {
 
 	// completeEpoch if finished
 	if block.Header.Flags().HasFlag(types.ValidationFinished) {
+		vc.persistValidationReports(block.Height())
 		vc.completeEpoch()
 		vc.startValidationShortSessionTimer()
 		vc.generateFlipKeyWordPairs(vc.appState.State.FlipWordsSeed().Bytes())
 	}
 }
 
+func (vc *ValidationCeremony) persistValidationReports(height uint64) {
+	vc.applyEpochMutex.Lock()
+	applyingCache, ok := vc.epochApplyingCache[height]
+	vc.applyEpochMutex.Unlock()
+	if !ok || len(applyingCache.reports) == 0 {
+		return
+	}
+	vc.repo.WriteValidationReports(vc.epoch, applyingCache.reports)
+}
+
+// GetValidationReport returns explanation of the identity validation result, nil if there is no report for the epoch
+func (vc *ValidationCeremony) GetValidationReport(address common.Address, epoch uint16) *types.ValidationReport {
+	return vc.repo.ReadValidationReport(epoch, address)
+}
+
 func (vc *ValidationCeremony) isParticipant() bool {
 	identity := vc.appState.State.GetIdentity(vc.secStore.GetAddress())
 	return state.IsCeremonyCandidate(identity)
@@ -394,9 +422,19 @@ func (vc *ValidationCeremony) startValidationShortSessionTimer() {
 func (vc *ValidationCeremony) completeEpoch() {
 	if vc.epoch != vc.appState.State.Epoch() {
 		edb := vc.epochDb
+		clearedEdb := edb
+		if vc.config.Validation.KeepFinishedEpochDb {
+			// db of the finished epoch is kept until the next epoch is finished as an additional source of the ceremony replay
+			clearedEdb = nil
+			if vc.epoch > 0 {
+				clearedEdb = database.NewEpochDb(vc.db, vc.epoch-1)
+			}
+		}
 		go func() {
 			vc.dropFlips(edb)
-			edb.Clear()
+			if clearedEdb != nil {
+				clearedEdb.Clear()
+			}
 		}()
 	}
 	vc.epochDb = database.NewEpochDb(vc.db, vc.appState.State.Epoch())
@@ -414,6 +452,8 @@ func (vc *ValidationCeremony) completeEpoch() {
 	vc.candidateIndexes = nil
 	vc.publicKeySent = false
 	vc.privateKeysSent = false
+	vc.autoShortAnswersSent = false
+	vc.autoLongAnswersSent = false
 	vc.shortAnswersSent = false
 	vc.evidenceSent = false
 	vc.shortSessionStarted = false
@@ -434,13 +474,7 @@ func (vc *ValidationCeremony) handleFlipLotteryPeriod(block *types.Block) {
 	if block.Header.Flags().HasFlag(types.FlipLotteryStarted) {
 		vc.logInfoWithInteraction("Flip lottery started")
 
-		seedHeight := uint64(0)
-		if block.Height() > LotterySeedLag {
-			seedHeight = block.Height() - LotterySeedLag
-		}
-
-		seedHeight = math.Max(vc.chain.GenesisInfo().Genesis.Height()+1, seedHeight)
-		seedBlock := vc.chain.GetBlockHeaderByHeight(seedHeight)
+		seedBlock := vc.chain.GetBlockHeaderByHeight(LotterySeedHeight(block.Height(), vc.chain.GenesisInfo().Genesis.Height()))
 
 		vc.epochDb.WriteLotterySeed(seedBlock.Seed().Bytes())
 
@@ -480,6 +514,9 @@ func (vc *ValidationCeremony) handleShortSessionPeriod(block *types.Block) {
 	}
 	vc.broadcastPrivateFlipKeysPackage(vc.appState)
 	vc.broadcastPublicFipKey(vc.appState)
+	if vc.shortSessionStarted {
+		vc.autoSubmitShortAnswers()
+	}
 	vc.processCeremonyTxs(block)
 }
 
@@ -507,6 +544,7 @@ func (vc *ValidationCeremony) handleLongSessionPeriod(block *types.Block) {
 
 	vc.processCeremonyTxs(block)
 	vc.broadcastPublicFipKey(vc.appState)
+	vc.autoSubmitLongAnswers()
 
 	// attempt to broadcast short answers since MaxShortAnswersBroadcastDelaySec seconds after long session has started
 	shortAnswersBroadcastTime := vc.appState.State.NextValidationTime().Add(vc.config.Validation.GetShortSessionDuration()).Add(MaxShortAnswersBroadcastDelaySec * time.Second)
@@ -534,14 +572,20 @@ func (vc *ValidationCeremony) handleAfterLongSessionPeriod(block *types.Block) {
 	}
 }
 
-func (vc *ValidationCeremony) calculateCeremonyCandidates(restore bool) {
-	if vc.shardCandidates != nil {
-		return
+// LotterySeedHeight returns height of the block which seed is the lottery seed of the flip lottery started at the given height
+func LotterySeedHeight(lotteryHeight uint64, genesisHeight uint64) uint64 {
+	seedHeight := uint64(0)
+	if lotteryHeight > LotterySeedLag {
+		seedHeight = lotteryHeight - LotterySeedLag
 	}
+	return math.Max(genesisHeight+1, seedHeight)
+}
 
+// distributeFlips returns false if the lottery seed is not written yet
+func (vc *ValidationCeremony) distributeFlips(restore bool) bool {
 	seed := vc.epochDb.ReadLotterySeed()
 	if seed == nil {
-		return
+		return false
 	}
 
 	vc.shardCandidates = vc.getCandidatesAndFlips(restore)
@@ -562,6 +606,17 @@ func (vc *ValidationCeremony) calculateCeremonyCandidates(restore bool) {
 		shard := vc.shardCandidates[shardId]
 		shard.shortFlipsPerCandidate, shard.longFlipsPerCandidate = GetFlipsDistribution(len(shard.candidates), vc.shardLotteries[shardId].authorsPerCandidate, shard.flipsPerAuthor, shard.flips, seed, shortFlipsCount)
 	}
+	return true
+}
+
+func (vc *ValidationCeremony) calculateCeremonyCandidates(restore bool) {
+	if vc.shardCandidates != nil {
+		return
+	}
+
+	if !vc.distributeFlips(restore) {
+		return
+	}
 
 	vc.lottery.finished = true
 
@@ -709,23 +764,6 @@ func (vc *ValidationCeremony) getCandidatesAndFlips(restore bool) map[common.Sha
 		}
 	}
 
-	toDbLotteryIdentity := func(addr common.Address, data state.Identity) database.DbLotteryIdentity {
-		res := database.DbLotteryIdentity{
-			Address:                 addr,
-			ShiftedShardId:          data.ShiftedShardId(),
-			PubKey:                  data.PubKey,
-			State:                   uint8(data.State),
-			HasDoneAllRequiredFlips: data.HasDoneAllRequiredFlips(),
-		}
-		if len(data.Flips) > 0 {
-			res.FlipCids = make([][]byte, 0, len(data.Flips))
-			for _, identityFlip := range data.Flips {
-				res.FlipCids = append(res.FlipCids, identityFlip.Cid)
-			}
-		}
-		return res
-	}
-
 	handleIdentity := func(identity database.DbLotteryIdentity) {
 		shard := candidatesDistibution[identity.ShiftedShardId]
 		addr := identity.Address
@@ -747,31 +785,52 @@ func (vc *ValidationCeremony) getCandidatesAndFlips(restore bool) map[common.Sha
 		lotteryIdentities = vc.epochDb.ReadLotteryIdentities()
 	}
 	if len(lotteryIdentities) == 0 {
-		vc.appState.State.IterateIdentities(func(key []byte, value []byte) bool {
-			if key == nil {
-				return true
-			}
-			addr := common.Address{}
-			addr.SetBytes(key[1:])
-			var data state.Identity
-			if err := data.FromBytes(value); err != nil {
-				return false
-			}
-			lotteryIdentity := toDbLotteryIdentity(addr, data)
-			handleIdentity(lotteryIdentity)
-			lotteryIdentities = append(lotteryIdentities, lotteryIdentity)
-			return false
-		})
+		lotteryIdentities = LotteryIdentities(vc.appState)
 		vc.epochDb.WriteLotteryIdentities(lotteryIdentities)
-	} else {
-		for _, identity := range lotteryIdentities {
-			handleIdentity(identity)
-		}
+	}
+	for _, identity := range lotteryIdentities {
+		handleIdentity(identity)
 	}
 
 	return candidatesDistibution
 }
 
+// LotteryIdentities returns identities of the state in the form they take part in the flip lottery
+func LotteryIdentities(appState *appstate.AppState) []database.DbLotteryIdentity {
+	var lotteryIdentities []database.DbLotteryIdentity
+	appState.State.IterateIdentities(func(key []byte, value []byte) bool {
+		if key == nil {
+			return true
+		}
+		addr := common.Address{}
+		addr.SetBytes(key[1:])
+		var data state.Identity
+		if err := data.FromBytes(value); err != nil {
+			return false
+		}
+		lotteryIdentities = append(lotteryIdentities, toDbLotteryIdentity(addr, data))
+		return false
+	})
+	return lotteryIdentities
+}
+
+func toDbLotteryIdentity(addr common.Address, data state.Identity) database.DbLotteryIdentity {
+	res := database.DbLotteryIdentity{
+		Address:                 addr,
+		ShiftedShardId:          data.ShiftedShardId(),
+		PubKey:                  data.PubKey,
+		State:                   uint8(data.State),
+		HasDoneAllRequiredFlips: data.HasDoneAllRequiredFlips(),
+	}
+	if len(data.Flips) > 0 {
+		res.FlipCids = make([][]byte, 0, len(data.Flips))
+		for _, identityFlip := range data.Flips {
+			res.FlipCids = append(res.FlipCids, identityFlip.Cid)
+		}
+	}
+	return res
+}
+
 func (vc *ValidationCeremony) getCandidatesAddresses(shardId common.ShardId) []common.Address {
 	var result []common.Address
 	for _, p := range vc.shardCandidates[shardId].candidates {
@@ -1044,6 +1103,7 @@ func (vc *ValidationCeremony) ApplyNewEpoch(height uint64, appState *appstate.Ap
 				ShardResults:    applyingCache.validationResults,
 				Pools:           applyingCache.pools,
 				Failed:          false,
+				Reports:         applyingCache.reports,
 			}
 		}
 	}
@@ -1054,6 +1114,7 @@ func (vc *ValidationCeremony) ApplyNewEpoch(height uint64, appState *appstate.Ap
 
 	intermediateIdentitiesCount := 0
 	epochApplyingValues := make(map[common.Address]cacheValue)
+	reports := make(map[common.Address]*types.ValidationReport)
 	validationResults := map[common.ShardId]*types.ValidationResults{}
 	god := appState.State.GodAddress()
 	allGoodInviters := make(map[common.Address]*types.InviterValidationResult)
@@ -1123,10 +1184,10 @@ func (vc *ValidationCeremony) ApplyNewEpoch(height uint64, appState *appstate.Ap
 				longScore = longFlipPoint / float32(longQualifiedFlipsCount)
 			}
 
-			totalScore, totalFlips = calculateNewTotalScore(appState.State.GetScores(addr), shortFlipPoint, shortQualifiedFlipsCount, totalFlipPoints, totalQualifiedFlipsCount)
+			totalScore, totalFlips = common.CalculateNewTotalScore(appState.State.GetScores(addr), shortFlipPoint, shortQualifiedFlipsCount, totalFlipPoints, totalQualifiedFlipsCount)
 
 			identity := appState.State.GetIdentity(addr)
-			newIdentityState := determineNewIdentityState(identity, shortScore, longScore, totalScore,
+			newIdentityState := state.DetermineNewIdentityState(identity, shortScore, longScore, totalScore,
 				totalFlips, missed, noQualShort, noQualLong, vc.epoch >= 93)
 			identityBirthday := determineIdentityBirthday(vc.epoch, identity, newIdentityState)
 
@@ -1149,6 +1210,28 @@ func (vc *ValidationCeremony) ApplyNewEpoch(height uint64, appState *appstate.Ap
 
 			epochApplyingValues[addr] = value
 
+			reports[addr] = &types.ValidationReport{
+				Epoch:                 vc.epoch,
+				Candidate:             true,
+				PrevState:             uint8(identity.State),
+				NewState:              uint8(newIdentityState),
+				Approved:              approved,
+				Missed:                missed,
+				MissedReason:          determineMissedReason(approved, noAnswersShort, noAnswersLong),
+				ShortFlipsToSolve:     uint32(len(shortFlipsToSolve)),
+				LongFlipsToSolve:      uint32(len(longFlipsToSolve)),
+				ShortPoint:            shortFlipPoint,
+				ShortQualifiedFlips:   shortQualifiedFlipsCount,
+				LongPoint:             longFlipPoint,
+				LongQualifiedFlips:    longQualifiedFlipsCount,
+				ShortScore:            shortScore,
+				LongScore:             longScore,
+				TotalScore:            totalScore,
+				TotalQualifiedFlips:   totalFlips,
+				NoQualifiedShortFlips: noQualShort,
+				NoQualifiedLongFlips:  noQualLong,
+			}
+
 			stats.IdentitiesPerAddr[addr] = &statsTypes.IdentityStats{
 				ShortPoint:        shortFlipPoint,
 				ShortFlips:        shortQualifiedFlipsCount,
@@ -1166,16 +1249,22 @@ func (vc *ValidationCeremony) ApplyNewEpoch(height uint64, appState *appstate.Ap
 		}
 		shardValidationResults.ReportersToRewardByFlip = reportersToReward.getReportersByFlipMap()
 		validationResults[shardId] = shardValidationResults
+		addAuthorsAndReportersToReports(reports, shardValidationResults)
 	}
 	pools := make(map[common.Address]struct{})
 	if intermediateIdentitiesCount == 0 {
 		vc.log.Warn("validation failed, nobody is validated, identities remains the same")
 		vc.validationStats.Failed = true
+		for _, report := range reports {
+			report.Failed = true
+			report.NewState = report.PrevState
+		}
 		vc.epochApplyingCache[height] = epochApplyingCache{
 			epochApplyingResult: epochApplyingValues,
 			validationResults:   validationResults,
 			validationFailed:    true,
 			pools:               pools,
+			reports:             reports,
 		}
 		return types.TotalValidationResult{
 			IdentitiesCount: vc.appState.ValidatorsCache.NetworkSize(),
@@ -1200,7 +1289,7 @@ func (vc *ValidationCeremony) ApplyNewEpoch(height uint64, appState *appstate.Ap
 	for _, shard := range vc.shardCandidates {
 		for _, addr := range shard.nonCandidates {
 			identity := appState.State.GetIdentity(addr)
-			newIdentityState := determineNewIdentityState(identity, 0, 0, 0, 0, true, false, false, vc.epoch >= 93)
+			newIdentityState := state.DetermineNewIdentityState(identity, 0, 0, 0, 0, true, false, false, vc.epoch >= 93)
 			identityBirthday := determineIdentityBirthday(vc.epoch, identity, newIdentityState)
 
 			value := cacheValue{
@@ -1214,14 +1303,23 @@ func (vc *ValidationCeremony) ApplyNewEpoch(height uint64, appState *appstate.Ap
 			}
 			epochApplyingValues[addr] = value
 			applyOnState(vc.config.Consensus, appState, vc.epoch, statsCollector, addr, value)
+			reports[addr] = &types.ValidationReport{
+				Epoch:        vc.epoch,
+				PrevState:    uint8(identity.State),
+				NewState:     uint8(newIdentityState),
+				Missed:       true,
+				MissedReason: types.NotCandidateMissedReason,
+			}
 		}
 	}
+	setReportPenalties(appState, reports)
 
 	vc.epochApplyingCache[height] = epochApplyingCache{
 		epochApplyingResult: epochApplyingValues,
 		validationResults:   validationResults,
 		validationFailed:    false,
 		pools:               pools,
+		reports:             reports,
 	}
 
 	return types.TotalValidationResult{
@@ -1229,21 +1327,54 @@ func (vc *ValidationCeremony) ApplyNewEpoch(height uint64, appState *appstate.Ap
 		ShardResults:    validationResults,
 		Pools:           pools,
 		Failed:          false,
+		Reports:         reports,
 	}
 }
 
-func calculateNewTotalScore(scores []byte, shortPoints float32, shortFlipsCount uint32, totalShortPoints float32, totalShortFlipsCount uint32) (totalScore float32, totalFlips uint32) {
-	newScores := make([]byte, len(scores))
-	copy(newScores, scores)
-	newScores = append(newScores, common.EncodeScore(shortPoints, shortFlipsCount))
-
-	if len(newScores) > common.LastScoresCount {
-		newScores = newScores[len(newScores)-common.LastScoresCount:]
+func determineMissedReason(approved, noAnswersShort, noAnswersLong bool) types.ValidationMissedReason {
+	switch {
+	case !approved:
+		return types.NotApprovedMissedReason
+	case noAnswersShort:
+		return types.NoShortAnswersMissedReason
+	case noAnswersLong:
+		return types.NoLongAnswersMissedReason
+	default:
+		return types.NotMissed
 	}
+}
 
-	resPoints, resFlips := common.CalculateIdentityScores(newScores, totalShortPoints, totalShortFlipsCount)
+// setReportPenalties writes burnt stakes of killed identities to their reports, results should be applied to the state
+func setReportPenalties(appState *appstate.AppState, reports map[common.Address]*types.ValidationReport) {
+	for addr, report := range reports {
+		if state.IdentityState(report.NewState) != state.Killed {
+			continue
+		}
+		if stake := appState.State.GetStakeBalance(addr); stake.Sign() > 0 {
+			report.Penalty = new(big.Int).Set(stake)
+		}
+	}
+}
 
-	return resPoints / float32(resFlips), resFlips
+func addAuthorsAndReportersToReports(reports map[common.Address]*types.ValidationReport, validationResults *types.ValidationResults) {
+	for addr, reason := range validationResults.BadAuthors {
+		if report, ok := reports[addr]; ok {
+			report.BadAuthor = true
+			report.BadAuthorReason = reason
+		}
+	}
+	for addr, result := range validationResults.GoodAuthors {
+		if report, ok := reports[addr]; ok {
+			report.RewardedFlips = uint32(len(result.FlipsToReward))
+		}
+	}
+	for _, reporters := range validationResults.ReportersToRewardByFlip {
+		for addr := range reporters {
+			if report, ok := reports[addr]; ok {
+				report.RewardedReports++
+			}
+		}
+	}
 }
 
 func setValidationResultToGoodAuthor(address common.Address, newState state.IdentityState, missed bool, validationResults *types.ValidationResults) {
@@ -1450,119 +1581,6 @@ func determineIdentityBirthday(currentEpoch uint16, identity state.Identity, new
 	return 0
 }
 
-func determineNewIdentityState(identity state.Identity, shortScore, longScore, totalScore float32, totalQualifiedFlips uint32, missed, noQualShort, nonQualLong bool, candidateToNewbieFixEnabled bool) state.IdentityState {
-
-	if !identity.HasDoneAllRequiredFlips() {
-		switch identity.State {
-		case state.Verified, state.Human:
-			return state.Suspended
-		default:
-			return state.Killed
-		}
-	}
-
-	prevState := identity.State
-
-	switch prevState {
-	case state.Undefined:
-		return state.Undefined
-	case state.Invite:
-		return state.Killed
-	case state.Candidate:
-		if missed {
-			return state.Killed
-		}
-		if noQualShort || nonQualLong && shortScore >= common.MinShortScore {
-			if candidateToNewbieFixEnabled {
-				return state.Newbie
-			}
-			return state.Candidate
-		}
-		if shortScore < common.MinShortScore || longScore < common.MinLongScore {
-			return state.Killed
-		}
-		return state.Newbie
-	case state.Newbie:
-		if missed {
-			return state.Killed
-		}
-		if noQualShort ||
-			nonQualLong && totalQualifiedFlips >= common.MinFlipsForVerified && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore ||
-			nonQualLong && totalQualifiedFlips < common.MinFlipsForVerified && shortScore >= common.MinShortScore {
-			return state.Newbie
-		}
-		if totalQualifiedFlips >= common.MinFlipsForVerified && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
-			return state.Verified
-		}
-		if totalQualifiedFlips < common.MinFlipsForVerified && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
-			return state.Newbie
-		}
-		return state.Killed
-	case state.Verified:
-		if missed {
-			return state.Suspended
-		}
-		if noQualShort || nonQualLong && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore {
-			return state.Verified
-		}
-		if totalQualifiedFlips >= common.MinFlipsForHuman && totalScore >= common.MinHumanTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
-			return state.Human
-		}
-		if totalQualifiedFlips >= common.MinFlipsForVerified && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
-			return state.Verified
-		}
-		return state.Killed
-	case state.Suspended:
-		if missed {
-			return state.Zombie
-		}
-		if noQualShort || nonQualLong && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore {
-			return state.Suspended
-		}
-		if totalQualifiedFlips >= common.MinFlipsForHuman && totalScore >= common.MinHumanTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
-			return state.Human
-		}
-		if totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
-			return state.Verified
-		}
-		return state.Killed
-	case state.Zombie:
-		if missed {
-			return state.Killed
-		}
-		if noQualShort || nonQualLong && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore {
-			return state.Zombie
-		}
-		if totalQualifiedFlips >= common.MinFlipsForHuman && totalScore >= common.MinHumanTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
-			return state.Human
-		}
-		if totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore {
-			return state.Verified
-		}
-		return state.Killed
-	case state.Human:
-		if missed {
-			return state.Suspended
-		}
-		if noQualShort || nonQualLong && totalScore >= common.MinHumanTotalScore && shortScore >= common.MinShortScore {
-			return state.Human
-		}
-		if nonQualLong {
-			return state.Suspended
-		}
-		if totalScore >= common.MinHumanTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
-			return state.Human
-		}
-		if totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
-			return state.Verified
-		}
-		return state.Suspended
-	case state.Killed:
-		return state.Killed
-	}
-	return state.Undefined
-}
-
 func (vc *ValidationCeremony) FlipKeyWordPairs() []int {
 	return vc.flipWordsInfo.pairs
 }
@@ -81,250 +81,6 @@ func getParticipants(myKey *ecdsa.PrivateKey, myIndex int, length int) []*candid
 	return participants
 }
 
-func Test_determineNewIdentityState(t *testing.T) {
-
-	type data struct {
-		prev                state.IdentityState
-		shortScore          float32
-		longScore           float32
-		totalScore          float32
-		totalQualifiedFlips uint32
-		missed              bool
-		expected            state.IdentityState
-		noQualShort         bool
-		noQualLong          bool
-	}
-
-	cases := []data{
-		{
-			state.Killed,
-			0, 0, 0, 0, true,
-			state.Killed, false, false,
-		},
-		{
-			state.Invite,
-			1, 1, 1, 110, false,
-			state.Killed, false, false,
-		},
-		{
-			state.Candidate,
-			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 11, false,
-			state.Newbie, false, false,
-		},
-		{
-			state.Candidate,
-			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 11, true,
-			state.Killed, false, false,
-		},
-		{
-			state.Newbie,
-			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 11, false,
-			state.Newbie, false, false,
-		},
-		{
-			state.Newbie,
-			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 13, false,
-			state.Verified, false, false,
-		},
-		{
-			state.Newbie,
-			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 10, false,
-			state.Newbie, false, false,
-		},
-		{
-			state.Newbie,
-			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 11, true,
-			state.Killed, false, false,
-		},
-		{
-			state.Newbie,
-			0.4, 0.8, 1, 11, false,
-			state.Killed, false, false,
-		},
-		{
-			state.Newbie,
-			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 8, false,
-			state.Newbie, false, false,
-		},
-		{
-			state.Verified,
-			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 10, false,
-			state.Killed, false, false,
-		},
-		{
-			state.Verified,
-			0, 0, 0, 0, true,
-			state.Suspended, false, false,
-		},
-		{
-			state.Verified,
-			0, 0, 0, 0, false,
-			state.Killed, false, false,
-		},
-		{
-			state.Suspended,
-			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 10, false,
-			state.Verified, false, false,
-		},
-		{
-			state.Suspended,
-			1, 0.8, 0, 10, true,
-			state.Zombie, false, false,
-		},
-		{
-			state.Zombie,
-			common.MinShortScore, 0, common.MinTotalScore, 10, false,
-			state.Verified, false, false,
-		},
-		{
-			state.Zombie,
-			1, 0, 0, 10, true,
-			state.Killed, false, false,
-		},
-		{
-			state.Candidate,
-			0, 0, 0, 5, false,
-			state.Newbie, true, false,
-		},
-		{
-			state.Candidate,
-			common.MinShortScore, 0, 0, 5, false,
-			state.Newbie, false, true,
-		},
-		{
-			state.Candidate,
-			0, 0, 0, 5, false,
-			state.Killed, false, true,
-		},
-		{
-			state.Candidate,
-			common.MinShortScore - 0.1, 0, 0, 5, false,
-			state.Killed, false, true,
-		},
-		{
-			state.Newbie,
-			common.MinShortScore, 0, 0.1, 5, false,
-			state.Newbie, true, false,
-		},
-		{
-			state.Newbie,
-			common.MinShortScore, 0, 0.1, 5, false,
-			state.Newbie, false, true,
-		},
-		{
-			state.Newbie,
-			common.MinShortScore, 0, 0.1, 13, false,
-			state.Killed, false, true,
-		},
-		{
-			state.Newbie,
-			common.MinShortScore - 0.1, 0, 0.1, 9, false,
-			state.Killed, false, true,
-		},
-		{
-			state.Verified,
-			common.MinShortScore - 0.1, 0, 0.1, 10, false,
-			state.Verified, true, false,
-		},
-		{
-			state.Verified,
-			common.MinShortScore - 0.1, 0, 1.1, 10, false,
-			state.Killed, false, true,
-		},
-		{
-			state.Suspended,
-			common.MinShortScore - 0.1, 0, 0.1, 10, false,
-			state.Suspended, true, false,
-		},
-		{
-			state.Suspended,
-			common.MinShortScore - 0.1, 0, 1.1, 10, false,
-			state.Killed, false, true,
-		},
-		{
-			state.Zombie,
-			common.MinShortScore - 0.1, 0, 0.1, 10, false,
-			state.Zombie, true, false,
-		},
-		{
-			state.Zombie,
-			common.MinShortScore, 0, 0.1, 10, false,
-			state.Killed, false, true,
-		},
-		{
-			state.Suspended,
-			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, false,
-			state.Human, false, false,
-		},
-		{
-			state.Suspended,
-			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 23, false,
-			state.Verified, false, false,
-		},
-		{
-			state.Zombie,
-			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, false,
-			state.Human, false, false,
-		},
-		{
-			state.Zombie,
-			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 23, false,
-			state.Verified, false, false,
-		},
-		{
-			state.Human,
-			0.1, common.MinLongScore, common.MinHumanTotalScore, 24, false,
-			state.Suspended, false, false,
-		},
-		{
-			state.Human,
-			common.MinShortScore, 0.1, common.MinHumanTotalScore, 24, false,
-			state.Suspended, false, false,
-		},
-		{
-			state.Human,
-			0.1, 0.1, common.MinHumanTotalScore, 24, false,
-			state.Suspended, false, true,
-		},
-		{
-			state.Human,
-			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, false,
-			state.Human, false, true,
-		},
-		{
-			state.Human,
-			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, true,
-			state.Suspended, false, false,
-		},
-		{
-			state.Human,
-			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, false,
-			state.Human, true, true,
-		},
-		{
-			state.Verified,
-			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, false,
-			state.Human, false, false,
-		},
-		{
-			state.Human,
-			0, 0, common.MinHumanTotalScore, 24, false,
-			state.Suspended, false, false,
-		},
-
-		{
-			state.Human,
-			0, 0, 0.74, 24, false,
-			state.Suspended, false, false,
-		},
-	}
-
-	require := require.New(t)
-	for i, c := range cases {
-		require.Equal(c.expected, determineNewIdentityState(state.Identity{State: c.prev}, c.shortScore, c.longScore, c.totalScore, c.totalQualifiedFlips, c.missed, c.noQualShort, c.noQualLong, true), "index = %v", i)
-	}
-}
-
 func Test_getNotApprovedFlips(t *testing.T) {
 	// given
 	vc := ValidationCeremony{}
@@ -1119,78 +875,6 @@ func Test_applyOnState_saveStake(t *testing.T) {
 	require.Zero(t, appState.State.GetStakeBalance(addr).Cmp(big.NewInt(234)))
 }
 
-func Test_calculateNewTotalScore(t *testing.T) {
-	var a float32
-	var b uint32
-
-	a, b = calculateNewTotalScore([]byte{}, 4, 6, 0, 0)
-	require.Equal(t, float32(4)/6, a)
-	require.Equal(t, uint32(6), b)
-
-	a, b = calculateNewTotalScore([]byte{}, 4, 6, 140, 145)
-	require.Equal(t, float32(130)/137, a)
-	require.Equal(t, uint32(137), b)
-
-	a, b = calculateNewTotalScore([]byte{common.EncodeScore(3, 5)}, 5, 6, 150, 163)
-	require.Equal(t, float32(128)/141, a)
-	require.Equal(t, uint32(141), b)
-
-	a, b = calculateNewTotalScore([]byte{common.EncodeScore(4, 6)}, 3.5, 6, 237.5, 255)
-	require.Equal(t, float32(197.5)/216, a)
-	require.Equal(t, uint32(216), b)
-
-	a, b = calculateNewTotalScore([]byte{
-		common.EncodeScore(4, 6),
-		common.EncodeScore(3.5, 6),
-		common.EncodeScore(5, 6),
-		common.EncodeScore(6, 6),
-	}, 4, 5, 237.5, 255)
-	require.Equal(t, float32(141.25)/157, a)
-	require.Equal(t, uint32(157), b)
-
-	a, b = calculateNewTotalScore([]byte{
-		common.EncodeScore(4, 6),
-		common.EncodeScore(3.5, 6),
-		common.EncodeScore(5, 6),
-		common.EncodeScore(6, 6),
-		common.EncodeScore(4, 5),
-		common.EncodeScore(6, 6),
-		common.EncodeScore(5, 6),
-		common.EncodeScore(4, 5),
-	}, 4, 6, 237.5, 255)
-	require.Equal(t, float32(65.25)/78, a)
-	require.Equal(t, uint32(78), b)
-
-	a, b = calculateNewTotalScore([]byte{
-		common.EncodeScore(4, 6),
-		common.EncodeScore(3.5, 6),
-		common.EncodeScore(5, 6),
-		common.EncodeScore(6, 6),
-		common.EncodeScore(4, 5),
-		common.EncodeScore(6, 6),
-		common.EncodeScore(5, 6),
-		common.EncodeScore(4, 5),
-		common.EncodeScore(4, 6),
-	}, 6, 6, 237.5, 255)
-	require.Equal(t, float32(47.5)/58, a)
-	require.Equal(t, uint32(58), b)
-
-	a, b = calculateNewTotalScore([]byte{
-		common.EncodeScore(4, 6),
-		common.EncodeScore(3.5, 6),
-		common.EncodeScore(5, 6),
-		common.EncodeScore(6, 6),
-		common.EncodeScore(4, 5),
-		common.EncodeScore(6, 6),
-		common.EncodeScore(5, 6),
-		common.EncodeScore(4, 5),
-		common.EncodeScore(4, 6),
-		common.EncodeScore(6, 6),
-	}, 5, 5, 237.5, 255)
-	require.Equal(t, float32(48.5)/57, a)
-	require.Equal(t, uint32(57), b)
-}
-
 func Test_determineStakeShareToBurn(t *testing.T) {
 	require.Equal(t, 100, determineStakeShareToBurn(state.Candidate, 1, 14))
 	require.Equal(t, 100, determineStakeShareToBurn(state.Candidate, 10, 14))
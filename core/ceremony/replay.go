@@ -0,0 +1,249 @@
+package ceremony
+
+import (
+	"bytes"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/log"
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+	"sort"
+	"sync"
+)
+
+type ReplayedIdentity struct {
+	Address           common.Address
+	ShardId           common.ShardId
+	Candidate         bool
+	PrevState         state.IdentityState
+	NewState          state.IdentityState
+	Birthday          uint16
+	ShortFlipsToSolve int
+	LongFlipsToSolve  int
+	ShortPoint        float32
+	ShortFlips        uint32
+	LongPoint         float32
+	LongFlips         uint32
+	Approved          bool
+	Missed            bool
+	BadAuthor         bool
+	BadAuthorReason   types.BadAuthorReason
+}
+
+type CeremonyReplay struct {
+	Epoch      uint16
+	Failed     bool
+	Identities []*ReplayedIdentity
+}
+
+// CeremonyData is the input of the validation ceremony which is recorded on chain
+type CeremonyData struct {
+	LotteryHeight     uint64
+	Seed              []byte
+	LotteryIdentities []database.DbLotteryIdentity
+	ShortAnswers      map[common.Address][]byte
+	LongAnswers       map[common.Address][]byte
+	EvidenceMaps      map[common.Address][]byte
+}
+
+func NewCeremonyData() *CeremonyData {
+	return &CeremonyData{
+		ShortAnswers: make(map[common.Address][]byte),
+		LongAnswers:  make(map[common.Address][]byte),
+		EvidenceMaps: make(map[common.Address][]byte),
+	}
+}
+
+// AddTxs collects ceremony txs, only the first tx of the sender is taken like the running ceremony does
+func (data *CeremonyData) AddTxs(txs []*types.Transaction) {
+	for _, tx := range txs {
+		var m map[common.Address][]byte
+		switch tx.Type {
+		case types.SubmitShortAnswersTx:
+			m = data.ShortAnswers
+		case types.SubmitLongAnswersTx:
+			m = data.LongAnswers
+		case types.EvidenceTx:
+			m = data.EvidenceMaps
+		default:
+			continue
+		}
+		sender, _ := types.Sender(tx)
+		if _, ok := m[sender]; !ok {
+			m[sender] = tx.Payload
+		}
+	}
+}
+
+// ReadCeremonyData collects the lottery seed and ceremony txs of the epoch from canonical blocks which follow
+// the epoch block up to the validation block at the given height, block bodies are read from ipfs.
+// Lottery identities are not collected, they are read from the state at LotteryHeight.
+func ReadCeremonyData(repo *database.Repo, ipfsProxy ipfs.Proxy, genesisHeight, epochBlock, height uint64) (*CeremonyData, error) {
+	data := NewCeremonyData()
+	shortSessionStarted := false
+	// txs of the validation block are applied after the ceremony results
+	for h := epochBlock + 1; h < height; h++ {
+		header := repo.ReadBlockHeader(repo.ReadCanonicalHash(h))
+		if header == nil {
+			return nil, errors.Errorf("block %v is not found", h)
+		}
+		if header.Flags().HasFlag(types.FlipLotteryStarted) {
+			seedHeight := LotterySeedHeight(h, genesisHeight)
+			seedHeader := repo.ReadBlockHeader(repo.ReadCanonicalHash(seedHeight))
+			if seedHeader == nil {
+				return nil, errors.Errorf("lottery seed block %v is not found", seedHeight)
+			}
+			data.LotteryHeight = h
+			data.Seed = seedHeader.Seed().Bytes()
+		}
+		// ceremony txs are not valid before the short session
+		if header.Flags().HasFlag(types.ShortSessionStarted) {
+			shortSessionStarted = true
+		}
+		if !shortSessionStarted || header.EmptyBlockHeader != nil {
+			continue
+		}
+		bodyBytes, err := ipfsProxy.Get(header.ProposedHeader.IpfsHash, ipfs.Block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "body of block %v is not available", h)
+		}
+		body := &types.Body{}
+		body.FromBytes(bodyBytes)
+		data.AddTxs(body.Transactions)
+	}
+	if data.Seed == nil {
+		return nil, errors.Errorf("flip lottery block is not found between blocks %v and %v", epochBlock, height)
+	}
+	return data, nil
+}
+
+type offlineSyncer struct {
+}
+
+func (offlineSyncer) IsSyncing() bool {
+	return false
+}
+
+// newOfflineCeremony creates ceremony which is able to calculate validation results only, it has no access to chain and network
+func newOfflineCeremony(cfg *config.Config, appState *appstate.AppState, epochDb *database.EpochDb) *ValidationCeremony {
+	return &ValidationCeremony{
+		appState:           appState,
+		log:                log.New(),
+		epochDb:            epochDb,
+		qualification:      NewQualification(cfg, epochDb),
+		syncer:             offlineSyncer{},
+		config:             cfg,
+		epoch:              appState.State.Epoch(),
+		epochApplyingCache: make(map[uint64]epochApplyingCache),
+		flipWordsInfo:      &flipWordsInfo{pool: &sync.Map{}},
+		lottery:            &lottery{},
+	}
+}
+
+// ReplayCeremony recalculates results of the validation ceremony finished at the given height.
+// appState should be initialized at the previous height, it will be modified by the replay.
+// The ceremony input is taken from data collected from the chain, epochDb is an optional source of the input
+// which is absent in data, e.g. the retained epoch db of the node. The replay writes to epochDb, so it should be
+// backed by a scratch db, a memory one is used if epochDb is nil.
+func ReplayCeremony(cfg *config.Config, appState *appstate.AppState, data *CeremonyData, epochDb *database.EpochDb, height uint64) (*CeremonyReplay, error) {
+	if appState.State.ValidationPeriod() != state.AfterLongSessionPeriod {
+		return nil, errors.Errorf("unexpected validation period %v", appState.State.ValidationPeriod())
+	}
+	if epochDb == nil {
+		epochDb = database.NewEpochDb(dbm.NewMemDB(), appState.State.Epoch())
+	}
+	vc := newOfflineCeremony(cfg, appState, epochDb)
+	vc.qualification.restore()
+	if data != nil {
+		if data.Seed != nil {
+			epochDb.WriteLotterySeed(data.Seed)
+		}
+		if len(data.LotteryIdentities) > 0 {
+			epochDb.WriteLotteryIdentities(data.LotteryIdentities)
+		}
+		for addr, evidenceMap := range data.EvidenceMaps {
+			epochDb.WriteEvidenceMap(addr, evidenceMap)
+		}
+		for addr, answers := range data.ShortAnswers {
+			vc.qualification.shortAnswers[addr] = answers
+		}
+		for addr, answers := range data.LongAnswers {
+			vc.qualification.longAnswers[addr] = answers
+		}
+	}
+	if !vc.distributeFlips(true) {
+		return nil, errors.New("lottery seed is found neither in the chain data nor in the epoch db")
+	}
+
+	prevStates := make(map[common.Address]state.IdentityState)
+	for _, shard := range vc.shardCandidates {
+		for _, addr := range shard.nonCandidates {
+			prevStates[addr] = appState.State.GetIdentityState(addr)
+		}
+	}
+
+	result := vc.ApplyNewEpoch(height, appState, nil)
+
+	replay := &CeremonyReplay{
+		Epoch:  vc.epoch,
+		Failed: result.Failed,
+	}
+	applyingResult := vc.epochApplyingCache[height].epochApplyingResult
+	for shardId, shard := range vc.shardCandidates {
+		var badAuthors map[common.Address]types.BadAuthorReason
+		if shardResults, ok := result.ShardResults[shardId]; ok {
+			badAuthors = shardResults.BadAuthors
+		}
+		stats := vc.validationStats.Shards[shardId]
+		for _, c := range shard.candidates {
+			value := applyingResult[c.Address]
+			identity := &ReplayedIdentity{
+				Address:   c.Address,
+				ShardId:   shardId,
+				Candidate: true,
+				PrevState: value.prevState,
+				NewState:  value.state,
+				Birthday:  value.birthday,
+			}
+			if identityStats, ok := stats.IdentitiesPerAddr[c.Address]; ok {
+				identity.ShortFlipsToSolve = len(identityStats.ShortFlipsToSolve)
+				identity.LongFlipsToSolve = len(identityStats.LongFlipsToSolve)
+				identity.ShortPoint = identityStats.ShortPoint
+				identity.ShortFlips = identityStats.ShortFlips
+				identity.LongPoint = identityStats.LongPoint
+				identity.LongFlips = identityStats.LongFlips
+				identity.Approved = identityStats.Approved
+				identity.Missed = identityStats.Missed
+			}
+			identity.BadAuthorReason, identity.BadAuthor = badAuthors[c.Address]
+			if result.Failed {
+				identity.NewState = identity.PrevState
+			}
+			replay.Identities = append(replay.Identities, identity)
+		}
+		for _, addr := range shard.nonCandidates {
+			value, ok := applyingResult[addr]
+			identity := &ReplayedIdentity{
+				Address:   addr,
+				ShardId:   shardId,
+				PrevState: prevStates[addr],
+				Missed:    true,
+			}
+			identity.NewState = identity.PrevState
+			if ok {
+				identity.NewState = value.state
+				identity.Birthday = value.birthday
+			}
+			replay.Identities = append(replay.Identities, identity)
+		}
+	}
+	sort.Slice(replay.Identities, func(i, j int) bool {
+		return bytes.Compare(replay.Identities[i].Address[:], replay.Identities[j].Address[:]) < 0
+	})
+	return replay, nil
+}
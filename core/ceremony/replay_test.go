@@ -0,0 +1,150 @@
+package ceremony
+
+import (
+	"crypto/ecdsa"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+	"testing"
+)
+
+func TestReplayCeremony(t *testing.T) {
+	db := dbm.NewMemDB()
+	appState, _ := appstate.NewAppState(db, eventbus.New())
+	appState.State.SetShardsNum(1)
+	appState.State.SetValidationPeriod(state.AfterLongSessionPeriod)
+
+	verified, candidate, suspended := common.Address{0x1}, common.Address{0x2}, common.Address{0x3}
+	appState.State.SetState(verified, state.Verified)
+	appState.State.SetState(candidate, state.Candidate)
+	appState.State.SetState(suspended, state.Suspended)
+	appState.State.SetRequiredFlips(suspended, 3)
+	for _, addr := range []common.Address{verified, candidate, suspended} {
+		appState.State.SetShardId(addr, 1)
+	}
+	require.NoError(t, appState.Commit(nil))
+	require.NoError(t, appState.Initialize(1))
+
+	cfg := &config.Config{
+		Consensus:  blockchain.GetDefaultConsensusConfig(),
+		Validation: &config.ValidationConfig{},
+	}
+
+	epochDb := database.NewEpochDb(db, 0)
+	_, err := ReplayCeremony(cfg, appState, nil, epochDb, 10)
+	require.Error(t, err)
+
+	// the seed of the retained epoch db is used if the chain data has none
+	epochDb.WriteLotterySeed(common.Hash{0x1, 0x2}.Bytes())
+	replay, err := ReplayCeremony(cfg, appState, NewCeremonyData(), epochDb, 10)
+	require.NoError(t, err)
+
+	// nobody has sent answers so validation should fail
+	require.True(t, replay.Failed)
+	require.Len(t, replay.Identities, 3)
+	require.Equal(t, verified, replay.Identities[0].Address)
+	require.True(t, replay.Identities[0].Candidate)
+	require.True(t, replay.Identities[0].Missed)
+	require.False(t, replay.Identities[0].Approved)
+	require.Equal(t, state.Verified, replay.Identities[0].NewState)
+	require.Equal(t, candidate, replay.Identities[1].Address)
+	require.True(t, replay.Identities[1].Candidate)
+	require.Equal(t, suspended, replay.Identities[2].Address)
+	require.False(t, replay.Identities[2].Candidate)
+	require.Equal(t, state.Suspended, replay.Identities[2].PrevState)
+
+	require.Len(t, epochDb.ReadLotteryIdentities(), 3)
+}
+
+func TestReplayCeremony_chainData(t *testing.T) {
+	db := dbm.NewMemDB()
+	appState, _ := appstate.NewAppState(db, eventbus.New())
+	appState.State.SetShardsNum(1)
+	appState.State.SetValidationPeriod(state.AfterLongSessionPeriod)
+	verified, killed := common.Address{0x1}, common.Address{0x2}
+	appState.State.SetState(verified, state.Verified)
+	appState.State.SetShardId(verified, 1)
+	require.NoError(t, appState.Commit(nil))
+	require.NoError(t, appState.Initialize(1))
+
+	cfg := &config.Config{
+		Consensus:  blockchain.GetDefaultConsensusConfig(),
+		Validation: &config.ValidationConfig{},
+	}
+	data := NewCeremonyData()
+	data.Seed = common.Hash{0x1, 0x2}.Bytes()
+	// the identity which took part in the lottery was killed before the validation
+	data.LotteryIdentities = []database.DbLotteryIdentity{
+		{Address: verified, ShiftedShardId: 1, State: uint8(state.Verified), HasDoneAllRequiredFlips: true},
+		{Address: killed, ShiftedShardId: 1, State: uint8(state.Verified), HasDoneAllRequiredFlips: true},
+	}
+	replay, err := ReplayCeremony(cfg, appState, data, nil, 10)
+	require.NoError(t, err)
+	require.True(t, replay.Failed)
+	require.Len(t, replay.Identities, 2)
+	require.True(t, replay.Identities[0].Candidate)
+	require.Equal(t, killed, replay.Identities[1].Address)
+	require.True(t, replay.Identities[1].Candidate)
+
+	// nothing is written to the node database
+	require.Nil(t, database.NewEpochDb(db, 0).ReadLotterySeed())
+}
+
+func TestReadCeremonyData(t *testing.T) {
+	repo := database.NewRepo(dbm.NewMemDB())
+	ipfsProxy := ipfs.NewMemoryIpfsProxy()
+	key, _ := crypto.GenerateKey()
+	otherKey, _ := crypto.GenerateKey()
+	sender, otherSender := crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(otherKey.PublicKey)
+	signTx := func(key *ecdsa.PrivateKey, txType types.TxType, payload byte) *types.Transaction {
+		tx, err := types.SignTx(&types.Transaction{Type: txType, Payload: []byte{payload}}, key)
+		require.NoError(t, err)
+		return tx
+	}
+	flags := map[uint64]types.BlockFlag{
+		5:  types.FlipLotteryStarted,
+		7:  types.ShortSessionStarted,
+		11: types.ValidationFinished,
+	}
+	txs := map[uint64][]*types.Transaction{
+		// answers are not valid before the short session
+		6: {signTx(otherKey, types.SubmitShortAnswersTx, 0x6)},
+		8: {signTx(key, types.SubmitShortAnswersTx, 0x1), signTx(key, types.SubmitLongAnswersTx, 0x2)},
+		9: {signTx(key, types.SubmitShortAnswersTx, 0x3), signTx(otherKey, types.EvidenceTx, 0x4), signTx(otherKey, types.SendTx, 0x5)},
+		// txs of the validation block are applied after the ceremony results
+		11: {signTx(otherKey, types.SubmitLongAnswersTx, 0x7)},
+	}
+	for h := uint64(1); h <= 11; h++ {
+		var header *types.Header
+		if h == 10 {
+			header = &types.Header{EmptyBlockHeader: &types.EmptyBlockHeader{Height: h, BlockSeed: types.Seed{byte(h)}}}
+		} else {
+			c, err := ipfsProxy.Add((&types.Body{Transactions: txs[h]}).ToBytes(), true)
+			require.NoError(t, err)
+			header = &types.Header{ProposedHeader: &types.ProposedHeader{Height: h, IpfsHash: c.Bytes(), Flags: flags[h], BlockSeed: types.Seed{byte(h)}}}
+		}
+		repo.WriteBlockHeader(header)
+		repo.WriteCanonicalHash(h, header.Hash())
+	}
+
+	data, err := ReadCeremonyData(repo, ipfsProxy, 1, 1, 11)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), data.LotteryHeight)
+	// lottery seed is the seed of the block after genesis since the chain is shorter than the lag
+	require.Equal(t, types.Seed{0x2}.Bytes(), data.Seed)
+	require.Equal(t, map[common.Address][]byte{sender: {0x1}}, data.ShortAnswers)
+	require.Equal(t, map[common.Address][]byte{sender: {0x2}}, data.LongAnswers)
+	require.Equal(t, map[common.Address][]byte{otherSender: {0x4}}, data.EvidenceMaps)
+
+	_, err = ReadCeremonyData(repo, ipfsProxy, 1, 5, 11)
+	require.Error(t, err)
+}
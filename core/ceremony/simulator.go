@@ -0,0 +1,322 @@
+package ceremony
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/attachments"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/crypto/ecies"
+	"github.com/idena-network/idena-go/crypto/vrf/p256"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+	"math/big"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultSimulatedEpochDuration = 30000
+	simulatedValidationHeight     = 2
+)
+
+// AnswerModel describes behaviour of simulated identities during validation ceremony
+type AnswerModel struct {
+	// probability to send short answers and evidence map, identities which don't do it are missed
+	ShortSessionRate float64
+	// probability to send long answers if short answers have been sent
+	LongSessionRate float64
+	// probability to give the right answer to a good flip, the wrong one is given otherwise
+	Accuracy float64
+	// probability to leave a flip without answer
+	SkipRate float64
+	// probability to report a bad flip in long session
+	ReportRate float64
+}
+
+// SimulatedGroup is a set of synthetic identities with the same state and behaviour
+type SimulatedGroup struct {
+	State state.IdentityState
+	Count int
+	// number of flips made by every identity of the group
+	Flips int
+	// share of flips made by the group which can't be solved and should be reported
+	BadFlipsRate float64
+	// number of previous validations with the same answer accuracy
+	History int
+	Age     uint16
+	Stake   *big.Int
+	Model   AnswerModel
+}
+
+type SimulatorConfig struct {
+	Seed  int64
+	Epoch uint16
+	// default consensus config is used if it's not set
+	Consensus *config.ConsensusConf
+	// epoch duration in blocks which is used to calculate rewards
+	EpochDuration uint32
+	Groups        []*SimulatedGroup
+}
+
+type SimulatedIdentity struct {
+	Address       common.Address
+	Group         int
+	PrevState     state.IdentityState
+	NewState      state.IdentityState
+	Report        *types.ValidationReport
+	BalanceChange *big.Int
+	StakeChange   *big.Int
+}
+
+type SimulationResult struct {
+	Failed     bool
+	Identities []*SimulatedIdentity
+	// number of identities per previous and new state
+	Transitions        map[state.IdentityState]map[state.IdentityState]int
+	TotalBalanceChange *big.Int
+	TotalStakeChange   *big.Int
+}
+
+type simulatedIdentity struct {
+	*SimulatedIdentity
+	key   *ecies.PrivateKey
+	model AnswerModel
+}
+
+// Simulate runs validation ceremony for synthetic identities in memory and calculates its results and rewards.
+// The same config always gives the same result.
+func Simulate(simConfig *SimulatorConfig) (*SimulationResult, error) {
+	if len(simConfig.Groups) == 0 {
+		return nil, errors.New("at least one group of identities is required")
+	}
+	consensusConf := simConfig.Consensus
+	if consensusConf == nil {
+		conf := *config.GetDefaultConsensusConfig()
+		consensusConf = &conf
+	}
+	epochDuration := simConfig.EpochDuration
+	if epochDuration == 0 {
+		epochDuration = defaultSimulatedEpochDuration
+	}
+	rnd := rand.New(rand.NewSource(simConfig.Seed))
+
+	db := dbm.NewMemDB()
+	appState, err := appstate.NewAppState(db, eventbus.New())
+	if err != nil {
+		return nil, err
+	}
+	appState.State.SetShardsNum(1)
+	appState.State.SetGlobalEpoch(simConfig.Epoch)
+	appState.State.SetValidationPeriod(state.AfterLongSessionPeriod)
+
+	flipsStorage := ipfs.NewMemoryIpfsProxy()
+	identities := make(map[common.Address]*simulatedIdentity)
+	var ordered []*simulatedIdentity
+	badFlips := make(map[string]struct{})
+	for groupIdx, group := range simConfig.Groups {
+		for i := 0; i < group.Count; i++ {
+			key, err := simulatedKey(simConfig.Seed, len(ordered))
+			if err != nil {
+				return nil, err
+			}
+			addr := crypto.PubkeyToAddress(key.PublicKey)
+			appState.State.SetState(addr, group.State)
+			appState.State.SetPubKey(addr, crypto.FromECDSAPub(&key.PublicKey))
+			appState.State.SetShardId(addr, 1)
+			appState.State.SetRequiredFlips(addr, uint8(group.Flips))
+			if simConfig.Epoch > group.Age {
+				appState.State.SetBirthday(addr, simConfig.Epoch-group.Age)
+			}
+			if group.Stake != nil {
+				appState.State.AddStake(addr, group.Stake)
+			}
+			for h := 0; h < group.History; h++ {
+				flipsCount := uint32(common.ShortSessionFlipsCount())
+				appState.State.AddNewScore(addr, common.EncodeScore(float32(flipsCount)*float32(group.Model.Accuracy), flipsCount))
+			}
+			for j := 0; j < group.Flips; j++ {
+				data := make([]byte, 32)
+				rnd.Read(data)
+				cid, err := flipsStorage.Add(data, false)
+				if err != nil {
+					return nil, err
+				}
+				appState.State.AddFlip(addr, cid.Bytes(), uint8(j))
+				if rnd.Float64() < group.BadFlipsRate {
+					badFlips[string(cid.Bytes())] = struct{}{}
+				}
+			}
+			identity := &simulatedIdentity{
+				SimulatedIdentity: &SimulatedIdentity{
+					Address:   addr,
+					Group:     groupIdx,
+					PrevState: group.State,
+				},
+				key:   ecies.ImportECDSA(key),
+				model: group.Model,
+			}
+			identities[addr] = identity
+			ordered = append(ordered, identity)
+		}
+	}
+	if err := appState.Commit(nil); err != nil {
+		return nil, err
+	}
+	if err := appState.Initialize(simulatedValidationHeight - 1); err != nil {
+		return nil, err
+	}
+
+	balances := make(map[common.Address][2]*big.Int, len(ordered))
+	for _, identity := range ordered {
+		balances[identity.Address] = [2]*big.Int{
+			appState.State.GetBalance(identity.Address),
+			appState.State.GetStakeBalance(identity.Address),
+		}
+	}
+
+	epochDb := database.NewEpochDb(db, simConfig.Epoch)
+	seed := make([]byte, common.HashLength)
+	rnd.Read(seed)
+	epochDb.WriteLotterySeed(seed)
+
+	vc := newOfflineCeremony(&config.Config{Consensus: consensusConf, Validation: &config.ValidationConfig{}}, appState, epochDb)
+	if !vc.distributeFlips(false) {
+		return nil, errors.New("failed to distribute flips")
+	}
+	for _, shard := range vc.shardCandidates {
+		if err := simulateAnswers(vc, shard, identities, badFlips, seed, rnd); err != nil {
+			return nil, err
+		}
+	}
+
+	validationResult := vc.ApplyNewEpoch(simulatedValidationHeight, appState, nil)
+	if !validationResult.Failed {
+		blockchain.RewardValidIdentities(appState, consensusConf, validationResult.ShardResults, []uint32{epochDuration})
+	}
+
+	result := &SimulationResult{
+		Failed:             validationResult.Failed,
+		Transitions:        make(map[state.IdentityState]map[state.IdentityState]int),
+		TotalBalanceChange: big.NewInt(0),
+		TotalStakeChange:   big.NewInt(0),
+	}
+	reports := vc.epochApplyingCache[simulatedValidationHeight].reports
+	for _, identity := range ordered {
+		addr := identity.Address
+		identity.NewState = appState.State.GetIdentityState(addr)
+		identity.Report = reports[addr]
+		prev := balances[addr]
+		identity.BalanceChange = new(big.Int).Sub(appState.State.GetBalance(addr), prev[0])
+		identity.StakeChange = new(big.Int).Sub(appState.State.GetStakeBalance(addr), prev[1])
+		result.TotalBalanceChange.Add(result.TotalBalanceChange, identity.BalanceChange)
+		result.TotalStakeChange.Add(result.TotalStakeChange, identity.StakeChange)
+		if _, ok := result.Transitions[identity.PrevState]; !ok {
+			result.Transitions[identity.PrevState] = make(map[state.IdentityState]int)
+		}
+		result.Transitions[identity.PrevState][identity.NewState]++
+		result.Identities = append(result.Identities, identity.SimulatedIdentity)
+	}
+	return result, nil
+}
+
+func simulatedKey(seed int64, idx int) (*ecdsa.PrivateKey, error) {
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data, uint64(seed))
+	binary.LittleEndian.PutUint64(data[8:], uint64(idx))
+	hash := crypto.Hash(data)
+	return crypto.ToECDSA(hash[:])
+}
+
+func simulateAnswers(vc *ValidationCeremony, shard *candidatesOfShard, identities map[common.Address]*simulatedIdentity,
+	badFlips map[string]struct{}, seed []byte, rnd *rand.Rand) error {
+	if len(shard.flips) == 0 {
+		return nil
+	}
+	rightAnswers := make([]types.Answer, len(shard.flips))
+	for i := range rightAnswers {
+		rightAnswers[i] = types.Answer(rnd.Intn(2) + 1)
+	}
+	isBad := func(flipIdx int) bool {
+		_, ok := badFlips[string(shard.flips[flipIdx])]
+		return ok
+	}
+	answer := func(answers *types.Answers, i int, flipIdx int, model AnswerModel) {
+		if rnd.Float64() < model.SkipRate {
+			return
+		}
+		var value types.Answer
+		switch {
+		case isBad(flipIdx):
+			value = types.Answer(rnd.Intn(2) + 1)
+		case rnd.Float64() < model.Accuracy:
+			value = rightAnswers[flipIdx]
+		default:
+			value = types.Left + types.Right - rightAnswers[flipIdx]
+		}
+		if value == types.Left {
+			answers.Left(uint(i))
+		} else {
+			answers.Right(uint(i))
+		}
+	}
+
+	var participants []int
+	for idx, c := range shard.candidates {
+		identity := identities[c.Address]
+		if identity == nil || rnd.Float64() >= identity.model.ShortSessionRate {
+			continue
+		}
+		participants = append(participants, idx)
+
+		shortFlips := shard.shortFlipsPerCandidate[idx]
+		short := types.NewAnswers(uint(len(shortFlips)))
+		for i := 0; i < len(shortFlips) && i < int(common.ShortSessionFlipsCount()); i++ {
+			answer(short, i, shortFlips[i]%len(shard.flips), identity.model)
+		}
+		signer, err := p256.NewVRFSigner(identity.key.ExportECDSA())
+		if err != nil {
+			return err
+		}
+		h, proof := signer.Evaluate(seed)
+		salt := crypto.Hash(append(c.Address.Bytes(), seed...))
+		vc.epochDb.WriteAnswerHash(c.Address, crypto.Hash(append(short.Bytes(), salt[:]...)), time.Unix(0, 0))
+		vc.qualification.addAnswers(true, c.Address, attachments.CreateShortAnswerAttachment(short.Bytes(), getWordsRnd(h), ClientTypeDesktop))
+
+		if rnd.Float64() >= identity.model.LongSessionRate {
+			continue
+		}
+		longFlips := shard.longFlipsPerCandidate[idx]
+		long := types.NewAnswers(uint(len(longFlips)))
+		for i, flipIdx := range longFlips {
+			flipIdx = flipIdx % len(shard.flips)
+			if isBad(flipIdx) && rnd.Float64() < identity.model.ReportRate {
+				long.Grade(uint(i), types.GradeReported)
+				continue
+			}
+			answer(long, i, flipIdx, identity.model)
+		}
+		vc.qualification.addAnswers(false, c.Address, attachments.CreateLongAnswerAttachment(long.Bytes(), proof, salt[:], identity.key))
+	}
+
+	// every participant approves all other participants
+	bitmap := common.NewBitmap(uint32(len(shard.candidates)))
+	for _, idx := range participants {
+		bitmap.Add(uint32(idx))
+	}
+	buf := new(bytes.Buffer)
+	bitmap.WriteTo(buf)
+	for _, idx := range participants {
+		vc.epochDb.WriteEvidenceMap(shard.candidates[idx].Address, buf.Bytes())
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package ceremony
+
+import (
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+)
+
+func TestSimulate(t *testing.T) {
+	newConfig := func() *SimulatorConfig {
+		return &SimulatorConfig{
+			Seed:  1,
+			Epoch: 10,
+			Groups: []*SimulatedGroup{
+				{
+					State:   state.Verified,
+					Count:   12,
+					Flips:   3,
+					History: 3,
+					Age:     5,
+					Stake:   big.NewInt(1000),
+					Model: AnswerModel{
+						ShortSessionRate: 1,
+						LongSessionRate:  1,
+						Accuracy:         1,
+					},
+				},
+				{
+					State: state.Verified,
+					Count: 2,
+					Flips: 3,
+					Age:   5,
+				},
+			},
+		}
+	}
+
+	result, err := Simulate(newConfig())
+	require.NoError(t, err)
+	require.False(t, result.Failed)
+	require.Len(t, result.Identities, 14)
+
+	for _, identity := range result.Identities {
+		require.Equal(t, state.Verified, identity.PrevState)
+		require.NotNil(t, identity.Report)
+		if identity.Group == 0 {
+			require.True(t, identity.NewState == state.Verified || identity.NewState == state.Human)
+			require.False(t, identity.Report.Missed)
+			require.True(t, identity.BalanceChange.Sign() > 0)
+		} else {
+			require.Equal(t, state.Suspended, identity.NewState)
+			require.True(t, identity.Report.Missed)
+			require.Zero(t, identity.BalanceChange.Sign())
+		}
+	}
+	require.Equal(t, 12, result.Transitions[state.Verified][state.Verified]+result.Transitions[state.Verified][state.Human])
+	require.Equal(t, 2, result.Transitions[state.Verified][state.Suspended])
+	require.True(t, result.TotalBalanceChange.Sign() > 0)
+
+	again, err := Simulate(newConfig())
+	require.NoError(t, err)
+	require.Equal(t, result.TotalBalanceChange, again.TotalBalanceChange)
+	require.Equal(t, result.TotalStakeChange, again.TotalStakeChange)
+	for i := range result.Identities {
+		require.Equal(t, result.Identities[i].Address, again.Identities[i].Address)
+		require.Equal(t, result.Identities[i].NewState, again.Identities[i].NewState)
+		require.Equal(t, result.Identities[i].BalanceChange, again.Identities[i].BalanceChange)
+	}
+}
+
+func TestSimulate_NoParticipants(t *testing.T) {
+	result, err := Simulate(&SimulatorConfig{
+		Seed:  2,
+		Epoch: 3,
+		Groups: []*SimulatedGroup{
+			{State: state.Newbie, Count: 3, Flips: 3, Age: 1},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Failed)
+	require.Zero(t, result.TotalBalanceChange.Sign())
+	for _, identity := range result.Identities {
+		require.Equal(t, state.Newbie, identity.NewState)
+	}
+}
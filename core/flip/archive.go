@@ -0,0 +1,122 @@
+package flip
+
+import (
+	"bytes"
+	"github.com/golang/protobuf/proto"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/crypto/ecies"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	models "github.com/idena-network/idena-go/protobuf"
+	"github.com/pkg/errors"
+)
+
+// ExportArchive builds encrypted archive which allows to move the node to another machine in the middle of the epoch.
+// It contains own flips of the current epoch with their data, flip encryption keys, own epoch records and given deferred txs.
+func (fp *Flipper) ExportArchive(deferredTxs []byte, password string) ([]byte, error) {
+	if password == "" {
+		return nil, errors.New("password should not be empty")
+	}
+	epochDb := fp.currentEpochDb()
+	coinbase := fp.secStore.GetAddress()
+	archive := &models.ProtoFlipArchive{
+		Epoch:          uint32(fp.appState.State.Epoch()),
+		Address:        coinbase.Bytes(),
+		FlipPublicKey:  crypto.FromECDSA(fp.GetFlipPublicEncryptionKey().ExportECDSA()),
+		FlipPrivateKey: crypto.FromECDSA(fp.GetFlipPrivateEncryptionKey().ExportECDSA()),
+		DeferredTxs:    deferredTxs,
+	}
+
+	fp.submissionsMutex.Lock()
+	records := epochDb.ReadOwnRecords()
+	submissions := fp.readSubmissions(epochDb)
+	fp.submissionsMutex.Unlock()
+
+	for _, record := range records {
+		archive.Records = append(archive.Records, &models.ProtoFlipArchive_Record{Key: record.Key, Value: record.Value})
+	}
+
+	var cids [][]byte
+	for _, f := range fp.appState.State.GetIdentity(coinbase).Flips {
+		cids = append(cids, f.Cid)
+	}
+	for _, submission := range submissions {
+		cids = append(cids, submission.Cid)
+	}
+	exported := make(map[string]struct{})
+	for _, key := range cids {
+		if _, ok := exported[string(key)]; ok {
+			continue
+		}
+		exported[string(key)] = struct{}{}
+		data, err := fp.ipfsProxy.Get(key, ipfs.Flip)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read flip %v", cidString(key))
+		}
+		archive.Flips = append(archive.Flips, &models.ProtoFlipArchive_Flip{Cid: key, Data: data})
+	}
+
+	data, err := proto.Marshal(archive)
+	if err != nil {
+		return nil, err
+	}
+	fp.log.Info("Flip archive exported", "flips", len(archive.Flips), "records", len(archive.Records))
+	return crypto.Encrypt(data, password)
+}
+
+// ImportArchive restores flips, flip keys and own epoch records from the archive created by ExportArchive,
+// deferred txs of the archive are returned to be added by the caller.
+func (fp *Flipper) ImportArchive(encrypted []byte, password string) (deferredTxs []byte, err error) {
+	data, err := crypto.Decrypt(encrypted, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt archive")
+	}
+	archive := new(models.ProtoFlipArchive)
+	if err := proto.Unmarshal(data, archive); err != nil {
+		return nil, errors.Wrap(err, "failed to decode archive")
+	}
+	if coinbase := fp.secStore.GetAddress(); common.BytesToAddress(archive.Address) != coinbase {
+		return nil, errors.Errorf("archive belongs to %v, node address is %v", common.BytesToAddress(archive.Address).Hex(), coinbase.Hex())
+	}
+	if epoch := fp.appState.State.Epoch(); uint16(archive.Epoch) != epoch {
+		return nil, errors.Errorf("archive is created for epoch %v, current epoch is %v", archive.Epoch, epoch)
+	}
+	publicKey, err := crypto.ToECDSA(archive.FlipPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid flip public key")
+	}
+	privateKey, err := crypto.ToECDSA(archive.FlipPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid flip private key")
+	}
+
+	for _, f := range archive.Flips {
+		c, err := fp.ipfsProxy.Add(f.Data, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to add flip %v", cidString(f.Cid))
+		}
+		if !bytes.Equal(c.Bytes(), f.Cid) {
+			return nil, errors.Errorf("flip cid mismatch, expected %v, actual %v", cidString(f.Cid), c.String())
+		}
+	}
+
+	records := make([]database.DbRecord, 0, len(archive.Records))
+	for _, record := range archive.Records {
+		records = append(records, database.DbRecord{Key: record.Key, Value: record.Value})
+	}
+	fp.submissionsMutex.Lock()
+	err = fp.currentEpochDb().WriteOwnRecords(records)
+	fp.submissionsMutex.Unlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to write epoch records")
+	}
+
+	fp.mutex.Lock()
+	fp.flipPublicKey = ecies.ImportECDSA(publicKey)
+	fp.flipPrivateKey = ecies.ImportECDSA(privateKey)
+	fp.mutex.Unlock()
+
+	fp.log.Info("Flip archive imported", "flips", len(archive.Flips), "records", len(archive.Records))
+	return archive.DeferredTxs, nil
+}
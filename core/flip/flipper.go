@@ -25,6 +25,7 @@ import (
 	"github.com/pkg/errors"
 	dbm "github.com/tendermint/tm-db"
 	"sync"
+	"time"
 )
 
 var (
@@ -50,6 +51,9 @@ type Flipper struct {
 	flipsQueue       chan *types.Flip
 	flipPublicKey    *ecies.PrivateKey
 	flipPrivateKey   *ecies.PrivateKey
+	submissionsMutex sync.Mutex
+	// clock is used to schedule submission retries
+	clock func() time.Time
 }
 
 type IpfsFlip struct {
@@ -93,8 +97,10 @@ func NewFlipper(db dbm.DB, ipfsProxy ipfs.Proxy, keyspool *mempool.KeysPool, txp
 		cancelLoadingCtx: cancel,
 		bus:              bus,
 		flipsQueue:       make(chan *types.Flip, 1000),
+		clock:            time.Now,
 	}
 	go fp.writeLoop()
+	go fp.submissionsLoop()
 	return fp
 }
 
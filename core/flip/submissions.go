@@ -0,0 +1,341 @@
+package flip
+
+import (
+	"bytes"
+	"github.com/golang/protobuf/proto"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/attachments"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/events"
+	"github.com/idena-network/idena-go/ipfs"
+	models "github.com/idena-network/idena-go/protobuf"
+	"github.com/ipfs/go-cid"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type SubmissionStatus = byte
+
+const (
+	SubmissionPrepared SubmissionStatus = 0
+	SubmissionPinned   SubmissionStatus = 1
+	SubmissionTxSent   SubmissionStatus = 2
+	SubmissionTxMined  SubmissionStatus = 3
+	SubmissionFailed   SubmissionStatus = 4
+)
+
+const (
+	submissionsCheckInterval = time.Second * 10
+	submissionMinRetryDelay  = time.Second * 10
+	submissionMaxRetryDelay  = time.Minute * 5
+	submissionMaxAttempts    = 20
+)
+
+var (
+	lateSubmissionError = errors.New("flip submission is not allowed after the flip lottery start")
+)
+
+// FlipSubmission tracks the local flip from preparing to mining of its tx
+type FlipSubmission struct {
+	Cid         []byte
+	Tx          *types.Transaction
+	PublicPart  []byte
+	PrivatePart []byte
+	PairId      uint8
+	Status      SubmissionStatus
+	Attempts    uint32
+	LastError   string
+	NextAttempt time.Time
+	Created     time.Time
+	Updated     time.Time
+}
+
+func (s *FlipSubmission) ToBytes() ([]byte, error) {
+	protoSubmission := &models.ProtoFlipSubmission{
+		Cid:         s.Cid,
+		PublicPart:  s.PublicPart,
+		PrivatePart: s.PrivatePart,
+		PairId:      uint32(s.PairId),
+		Status:      uint32(s.Status),
+		Attempts:    s.Attempts,
+		LastError:   s.LastError,
+		NextAttempt: timeToUnix(s.NextAttempt),
+		Created:     timeToUnix(s.Created),
+		Updated:     timeToUnix(s.Updated),
+	}
+	if s.Tx != nil {
+		txBytes, err := s.Tx.ToBytes()
+		if err != nil {
+			return nil, err
+		}
+		protoSubmission.Tx = txBytes
+	}
+	return proto.Marshal(protoSubmission)
+}
+
+func (s *FlipSubmission) FromBytes(data []byte) error {
+	protoSubmission := new(models.ProtoFlipSubmission)
+	if err := proto.Unmarshal(data, protoSubmission); err != nil {
+		return err
+	}
+	s.Cid = protoSubmission.Cid
+	s.PublicPart = protoSubmission.PublicPart
+	s.PrivatePart = protoSubmission.PrivatePart
+	s.PairId = uint8(protoSubmission.PairId)
+	s.Status = SubmissionStatus(protoSubmission.Status)
+	s.Attempts = protoSubmission.Attempts
+	s.LastError = protoSubmission.LastError
+	s.NextAttempt = timeFromUnix(protoSubmission.NextAttempt)
+	s.Created = timeFromUnix(protoSubmission.Created)
+	s.Updated = timeFromUnix(protoSubmission.Updated)
+	if len(protoSubmission.Tx) > 0 {
+		tx := new(types.Transaction)
+		if err := tx.FromBytes(protoSubmission.Tx); err != nil {
+			return err
+		}
+		s.Tx = tx
+	}
+	return nil
+}
+
+func timeToUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func timeFromUnix(value int64) time.Time {
+	if value == 0 {
+		return time.Time{}
+	}
+	return time.Unix(value, 0)
+}
+
+func (s *FlipSubmission) ipfsData(pubKey []byte) []byte {
+	ipf := &IpfsFlip{
+		PublicPart:  s.PublicPart,
+		PrivatePart: s.PrivatePart,
+		PubKey:      pubKey,
+	}
+	data, _ := ipf.ToBytes()
+	return data
+}
+
+func (fp *Flipper) currentEpochDb() *database.EpochDb {
+	fp.mutex.RLock()
+	defer fp.mutex.RUnlock()
+	return fp.epochDb
+}
+
+// SubmitFlip encrypts the flip and puts it to the persistent submission queue.
+// The first submission attempt is made immediately, failed steps are retried in background.
+func (fp *Flipper) SubmitFlip(publicPart []byte, privatePart []byte, pairId uint8) (*FlipSubmission, error) {
+	if fp.appState.State.ValidationPeriod() >= state.FlipLotteryPeriod {
+		return nil, lateSubmissionError
+	}
+	c, encryptedPublic, encryptedPrivate, err := fp.PrepareFlip(publicPart, privatePart)
+	if err != nil {
+		return nil, err
+	}
+
+	fp.submissionsMutex.Lock()
+	defer fp.submissionsMutex.Unlock()
+
+	epochDb := fp.currentEpochDb()
+	if data := epochDb.ReadFlipSubmission(c.Bytes()); data != nil {
+		existing := new(FlipSubmission)
+		if err := existing.FromBytes(data); err == nil && existing.Status != SubmissionFailed {
+			return nil, DuplicateFlipError
+		}
+	}
+	now := fp.clock()
+	submission := &FlipSubmission{
+		Cid:         c.Bytes(),
+		PublicPart:  encryptedPublic,
+		PrivatePart: encryptedPrivate,
+		PairId:      pairId,
+		Status:      SubmissionPrepared,
+		Created:     now,
+		Updated:     now,
+	}
+	fp.processSubmission(submission, now)
+	fp.writeSubmission(epochDb, submission)
+	return submission, nil
+}
+
+// GetFlipSubmissions returns all flip submissions of the current epoch
+func (fp *Flipper) GetFlipSubmissions() []*FlipSubmission {
+	fp.submissionsMutex.Lock()
+	defer fp.submissionsMutex.Unlock()
+	return fp.readSubmissions(fp.currentEpochDb())
+}
+
+func (fp *Flipper) readSubmissions(epochDb *database.EpochDb) []*FlipSubmission {
+	var result []*FlipSubmission
+	epochDb.IterateOverFlipSubmissions(func(cid []byte, data []byte) {
+		submission := new(FlipSubmission)
+		if err := submission.FromBytes(data); err != nil {
+			fp.log.Warn("Failed to decode flip submission", "err", err)
+			return
+		}
+		result = append(result, submission)
+	})
+	return result
+}
+
+func (fp *Flipper) writeSubmission(epochDb *database.EpochDb, submission *FlipSubmission) {
+	data, err := submission.ToBytes()
+	if err != nil {
+		fp.log.Error("Failed to encode flip submission", "err", err)
+		return
+	}
+	epochDb.WriteFlipSubmission(submission.Cid, data)
+}
+
+func (fp *Flipper) submissionsLoop() {
+	ticker := time.NewTicker(submissionsCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		fp.processSubmissions()
+	}
+}
+
+func (fp *Flipper) processSubmissions() {
+	fp.submissionsMutex.Lock()
+	defer fp.submissionsMutex.Unlock()
+
+	epochDb := fp.currentEpochDb()
+	if epochDb == nil {
+		return
+	}
+	now := fp.clock()
+	for _, submission := range fp.readSubmissions(epochDb) {
+		if submission.Status == SubmissionTxMined || submission.Status == SubmissionFailed || now.Before(submission.NextAttempt) {
+			continue
+		}
+		fp.processSubmission(submission, now)
+		fp.writeSubmission(epochDb, submission)
+	}
+}
+
+// processSubmission moves the submission through its steps until the tx is sent or an error occurs
+func (fp *Flipper) processSubmission(submission *FlipSubmission, now time.Time) {
+	prevStatus := submission.Status
+	err := fp.advanceSubmission(submission)
+	if err == nil {
+		if submission.Status != prevStatus {
+			submission.Updated = now
+		}
+		submission.LastError = ""
+		submission.NextAttempt = time.Time{}
+		return
+	}
+	submission.Updated = now
+	submission.Attempts++
+	submission.LastError = err.Error()
+	if err == lateSubmissionError || submission.Attempts >= submissionMaxAttempts {
+		submission.Status = SubmissionFailed
+		fp.log.Warn("Flip submission failed", "cid", cidString(submission.Cid), "attempts", submission.Attempts, "err", err)
+		return
+	}
+	delay := submissionMinRetryDelay << (submission.Attempts - 1)
+	if delay > submissionMaxRetryDelay || delay <= 0 {
+		delay = submissionMaxRetryDelay
+	}
+	submission.NextAttempt = now.Add(delay)
+	fp.log.Warn("Flip submission step failed, will retry", "cid", cidString(submission.Cid), "status", submission.Status,
+		"attempts", submission.Attempts, "next", submission.NextAttempt, "err", err)
+}
+
+func (fp *Flipper) advanceSubmission(submission *FlipSubmission) error {
+	if submission.Status == SubmissionTxSent {
+		if fp.isFlipMined(submission.Cid) {
+			submission.Status = SubmissionTxMined
+			return nil
+		}
+		// tx is still waiting in mempool
+		if fp.txpool.GetTx(submission.Tx.Hash()) != nil {
+			return nil
+		}
+		fp.log.Info("Flip tx is dropped from mempool, resending", "cid", cidString(submission.Cid), "hash", submission.Tx.Hash().Hex())
+		submission.Status = SubmissionPinned
+	}
+	if fp.appState.State.ValidationPeriod() >= state.FlipLotteryPeriod {
+		return lateSubmissionError
+	}
+	data := submission.ipfsData(fp.secStore.GetPubKey())
+	if submission.Status == SubmissionPrepared {
+		c, err := fp.ipfsProxy.Add(data, true)
+		if err != nil {
+			return errors.Wrap(err, "failed to pin flip")
+		}
+		if !bytes.Equal(c.Bytes(), submission.Cid) {
+			return errors.New("pinned flip cid mismatch")
+		}
+		submission.Status = SubmissionPinned
+	}
+	if submission.Status == SubmissionPinned {
+		stored, err := fp.ipfsProxy.Get(submission.Cid, ipfs.Flip)
+		if err != nil {
+			return errors.Wrap(err, "pinned flip is not readable")
+		}
+		if !bytes.Equal(stored, data) {
+			return errors.New("pinned flip differs from the submitted one")
+		}
+		// the local copy doesn't make the flip retrievable by peers, they find it by the provider record
+		if err := fp.ipfsProxy.Provide(submission.Cid); err != nil {
+			return errors.Wrap(err, "flip provider record is not announced to peers")
+		}
+		tx, err := fp.buildFlipTx(submission)
+		if err != nil {
+			return err
+		}
+		if err := fp.sendFlipTx(&types.Flip{Tx: tx, PublicPart: submission.PublicPart, PrivatePart: submission.PrivatePart}); err != nil {
+			return errors.Wrap(err, "failed to send flip tx")
+		}
+		submission.Tx = tx
+		submission.Status = SubmissionTxSent
+	}
+	return nil
+}
+
+func (fp *Flipper) buildFlipTx(submission *FlipSubmission) (*types.Transaction, error) {
+	addr := fp.secStore.GetAddress()
+	payload := attachments.CreateFlipSubmitAttachment(submission.Cid, submission.PairId)
+	tx := blockchain.BuildTxWithFeeEstimating(fp.appState, addr, nil, types.SubmitFlipTx, decimal.Zero, decimal.Zero, decimal.Zero, 0, 0, payload)
+	return fp.secStore.SignTx(tx)
+}
+
+func (fp *Flipper) sendFlipTx(flip *types.Flip) error {
+	err := fp.addNewFlip(flip, true)
+	if err != DuplicateFlipError {
+		return err
+	}
+	// flip cid is already known, only the tx should be resent
+	if err := fp.txpool.AddInternalTx(flip.Tx); err != nil {
+		return err
+	}
+	fp.bus.Publish(&events.NewFlipEvent{Flip: flip})
+	return nil
+}
+
+func (fp *Flipper) isFlipMined(cid []byte) bool {
+	for _, f := range fp.appState.State.GetIdentity(fp.secStore.GetAddress()).Flips {
+		if bytes.Equal(f.Cid, cid) {
+			return true
+		}
+	}
+	return false
+}
+
+func cidString(data []byte) string {
+	c, err := cid.Cast(data)
+	if err != nil {
+		return ""
+	}
+	return c.String()
+}
@@ -0,0 +1,228 @@
+package flip
+
+import (
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/core/mempool"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/secstore"
+	"github.com/idena-network/idena-go/stats/collector"
+	"github.com/ipfs/go-cid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	db "github.com/tendermint/tm-db"
+	"testing"
+	"time"
+)
+
+// faultyIpfs fails adding, getting or providing data when it is requested
+type faultyIpfs struct {
+	ipfs.Proxy
+	addErr, getErr, provideErr error
+}
+
+func (p *faultyIpfs) Add(data []byte, pin bool) (cid.Cid, error) {
+	if p.addErr != nil {
+		return cid.Cid{}, p.addErr
+	}
+	return p.Proxy.Add(data, pin)
+}
+
+func (p *faultyIpfs) Get(key []byte, dataType ipfs.DataType) ([]byte, error) {
+	if p.getErr != nil {
+		return nil, p.getErr
+	}
+	return p.Proxy.Get(key, dataType)
+}
+
+func (p *faultyIpfs) Provide(key []byte) error {
+	if p.provideErr != nil {
+		return p.provideErr
+	}
+	return p.Proxy.Provide(key)
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestFlipper(t *testing.T) (*Flipper, *faultyIpfs, *testClock) {
+	memDb := db.NewMemDB()
+	bus := eventbus.New()
+	appState, err := appstate.NewAppState(memDb, bus)
+	require.NoError(t, err)
+	require.NoError(t, appState.Initialize(0))
+
+	key, _ := crypto.GenerateKey()
+	secStore := secstore.NewSecStore()
+	secStore.AddKey(crypto.FromECDSA(key))
+
+	ipfsProxy := &faultyIpfs{Proxy: ipfs.NewMemoryIpfsProxy()}
+	clock := &testClock{now: time.Unix(1600000000, 0)}
+	fp := &Flipper{
+		db:        memDb,
+		log:       log.New(),
+		ipfsProxy: ipfsProxy,
+		secStore:  secStore,
+		appState:  appState,
+		txpool: mempool.NewTxPool(appState, bus, &config.Config{Mempool: config.GetDefaultMempoolConfig(),
+			Consensus: config.GetDefaultConsensusConfig()}, collector.NewStatsCollector()),
+		bus:   bus,
+		clock: clock.Now,
+	}
+	fp.epochDb = database.NewEpochDb(memDb, 0)
+	return fp, ipfsProxy, clock
+}
+
+func newTestSubmission(t *testing.T, fp *Flipper) *FlipSubmission {
+	submission := &FlipSubmission{
+		PublicPart:  []byte{0x1, 0x2},
+		PrivatePart: []byte{0x3},
+		Status:      SubmissionPrepared,
+	}
+	c, err := ipfs.NewMemoryIpfsProxy().Add(submission.ipfsData(fp.secStore.GetPubKey()), true)
+	require.NoError(t, err)
+	submission.Cid = c.Bytes()
+	return submission
+}
+
+func TestFlipper_processSubmission_retries(t *testing.T) {
+	fp, ipfsProxy, clock := newTestFlipper(t)
+	ipfsProxy.addErr = errors.New("ipfs is not available")
+	submission := newTestSubmission(t, fp)
+
+	expectedDelays := []time.Duration{
+		10 * time.Second,
+		20 * time.Second,
+		40 * time.Second,
+		80 * time.Second,
+		160 * time.Second,
+		submissionMaxRetryDelay,
+		submissionMaxRetryDelay,
+	}
+	for i, delay := range expectedDelays {
+		fp.processSubmission(submission, clock.now)
+		require.Equal(t, SubmissionPrepared, submission.Status)
+		require.Equal(t, uint32(i+1), submission.Attempts)
+		require.Equal(t, clock.now.Add(delay), submission.NextAttempt, "attempt %v", i+1)
+		require.Contains(t, submission.LastError, "ipfs is not available")
+		require.Equal(t, clock.now, submission.Updated)
+		clock.now = submission.NextAttempt
+	}
+
+	for submission.Attempts < submissionMaxAttempts-1 {
+		fp.processSubmission(submission, clock.now)
+		require.Equal(t, SubmissionPrepared, submission.Status)
+	}
+	fp.processSubmission(submission, clock.now)
+	require.Equal(t, SubmissionFailed, submission.Status)
+	require.Equal(t, uint32(submissionMaxAttempts), submission.Attempts)
+}
+
+func TestFlipper_processSubmissions_clock(t *testing.T) {
+	fp, ipfsProxy, clock := newTestFlipper(t)
+	ipfsProxy.addErr = errors.New("ipfs is not available")
+	submission := newTestSubmission(t, fp)
+	fp.processSubmission(submission, clock.now)
+	fp.writeSubmission(fp.epochDb, submission)
+
+	read := func() *FlipSubmission {
+		submissions := fp.GetFlipSubmissions()
+		require.Len(t, submissions, 1)
+		return submissions[0]
+	}
+
+	// next attempt is not reached
+	clock.now = clock.now.Add(submissionMinRetryDelay - time.Second)
+	fp.processSubmissions()
+	require.Equal(t, uint32(1), read().Attempts)
+
+	clock.now = clock.now.Add(time.Second)
+	fp.processSubmissions()
+	require.Equal(t, uint32(2), read().Attempts)
+	require.Equal(t, clock.now.Add(2*submissionMinRetryDelay), read().NextAttempt)
+
+	// failed submissions are not processed anymore
+	failed := read()
+	failed.Status = SubmissionFailed
+	fp.writeSubmission(fp.epochDb, failed)
+	clock.now = clock.now.Add(submissionMaxRetryDelay)
+	fp.processSubmissions()
+	require.Equal(t, uint32(2), read().Attempts)
+}
+
+func TestFlipper_processSubmission_statuses(t *testing.T) {
+	fp, ipfsProxy, clock := newTestFlipper(t)
+
+	// flip is pinned, but it can't be read
+	ipfsProxy.getErr = errors.New("not found")
+	submission := newTestSubmission(t, fp)
+	fp.processSubmission(submission, clock.now)
+	require.Equal(t, SubmissionPinned, submission.Status)
+	require.Equal(t, uint32(1), submission.Attempts)
+	require.Contains(t, submission.LastError, "pinned flip is not readable")
+
+	// flip is pinned, but peers can't find it
+	ipfsProxy.getErr = nil
+	ipfsProxy.provideErr = errors.New("failed to find any peer in table")
+	fp.processSubmission(submission, clock.now)
+	require.Equal(t, SubmissionPinned, submission.Status)
+	require.Equal(t, uint32(2), submission.Attempts)
+	require.Contains(t, submission.LastError, "flip provider record is not announced to peers")
+	require.Nil(t, submission.Tx)
+	ipfsProxy.provideErr = nil
+
+	// pinned flip with wrong cid
+	wrongCid := newTestSubmission(t, fp)
+	wrongCid.PublicPart = []byte{0x5}
+	fp.processSubmission(wrongCid, clock.now)
+	require.Equal(t, SubmissionPrepared, wrongCid.Status)
+	require.Equal(t, "pinned flip cid mismatch", wrongCid.LastError)
+
+	// mined flip completes the submission and resets the error
+	submission.Status = SubmissionTxSent
+	fp.appState.State.AddFlip(fp.secStore.GetAddress(), submission.Cid, 0)
+	clock.now = clock.now.Add(time.Minute)
+	fp.processSubmission(submission, clock.now)
+	require.Equal(t, SubmissionTxMined, submission.Status)
+	require.Empty(t, submission.LastError)
+	require.True(t, submission.NextAttempt.IsZero())
+	require.Equal(t, clock.now, submission.Updated)
+
+	// submission is failed without retries after the flip lottery start
+	late := newTestSubmission(t, fp)
+	fp.appState.State.SetValidationPeriod(state.FlipLotteryPeriod)
+	fp.processSubmission(late, clock.now)
+	require.Equal(t, SubmissionFailed, late.Status)
+	require.Equal(t, uint32(1), late.Attempts)
+	require.Equal(t, lateSubmissionError.Error(), late.LastError)
+}
+
+func TestFlipSubmission_ToBytes(t *testing.T) {
+	submission := &FlipSubmission{
+		Cid:         []byte{0x1},
+		PublicPart:  []byte{0x2},
+		PrivatePart: []byte{0x3},
+		PairId:      2,
+		Status:      SubmissionPinned,
+		Attempts:    3,
+		LastError:   "error",
+		NextAttempt: time.Unix(1600000100, 0),
+		Created:     time.Unix(1600000000, 0),
+		Updated:     time.Unix(1600000050, 0),
+	}
+	data, err := submission.ToBytes()
+	require.NoError(t, err)
+	restored := new(FlipSubmission)
+	require.NoError(t, restored.FromBytes(data))
+	require.Equal(t, submission, restored)
+}
@@ -399,6 +399,28 @@ func (p *KeysPool) GetEncryptedPrivateFlipKey(indexInPackage int, address common
 	return keysArray.Pairs[indexInPackage]
 }
 
+// HasFlipKeys checks whether public flip key and private flip keys package of the address are in the pool
+func (p *KeysPool) HasFlipKeys(address common.Address) (publicKey bool, privateKeysPackage bool) {
+	p.publicKeyMutex.RLock()
+	_, publicKey = p.flipKeys[address]
+	p.publicKeyMutex.RUnlock()
+	p.privateKeysMutex.RLock()
+	_, privateKeysPackage = p.flipKeyPackages[address]
+	p.privateKeysMutex.RUnlock()
+	return publicKey, privateKeysPackage
+}
+
+// Sizes returns numbers of public flip keys and private flip keys packages in the pool
+func (p *KeysPool) Sizes() (publicKeys int, privateKeysPackages int) {
+	p.publicKeyMutex.RLock()
+	publicKeys = len(p.flipKeys)
+	p.publicKeyMutex.RUnlock()
+	p.privateKeysMutex.RLock()
+	privateKeysPackages = len(p.flipKeyPackages)
+	p.privateKeysMutex.RUnlock()
+	return publicKeys, privateKeysPackages
+}
+
 func (p *KeysPool) Clear() {
 	p.privateKeysMutex.Lock()
 	p.publicKeyMutex.Lock()
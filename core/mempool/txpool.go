@@ -501,6 +501,19 @@ func (pool *TxPool) GetPendingByAddress(address common.Address) []*types.Transac
 	return list
 }
 
+// Sizes returns numbers of executable and pending (waiting for a nonce gap to be filled) transactions
+func (pool *TxPool) Sizes() (executable int, pending int) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	for _, txs := range pool.executableTxs {
+		executable += len(txs.txs)
+	}
+	for _, txs := range pool.pendingTxs {
+		pending += txs.Len()
+	}
+	return executable, pending
+}
+
 func (pool *TxPool) GetTx(hash common.Hash) *types.Transaction {
 	tx, ok := pool.all.Get(hash)
 	if ok {
@@ -806,6 +819,12 @@ func (m *txMap) Remove(hash common.Hash) {
 	delete(m.txs, hash)
 }
 
+func (m *txMap) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return len(m.txs)
+}
+
 func (m *txMap) Empty() bool {
 	return len(m.txs) == 0
 }
@@ -0,0 +1,328 @@
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/idena-network/idena-go/common"
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// prefixes of state keys which are compared by versions diff
+var diffPrefixes = [][]byte{addressPrefix, identityPrefix, contractStorePrefix}
+
+// StateValueDiff is a change of a single state key between two versions,
+// OldValue is nil for added keys and NewValue is nil for removed ones
+type StateValueDiff struct {
+	Key      []byte
+	OldValue []byte
+	NewValue []byte
+}
+
+type AccountDiff struct {
+	Address common.Address
+	Old     *Account
+	New     *Account
+}
+
+type IdentityDiff struct {
+	Address common.Address
+	Old     *Identity
+	New     *Identity
+}
+
+type ContractValueDiff struct {
+	Contract common.Address
+	Key      []byte
+	Old      []byte
+	New      []byte
+}
+
+type VersionsDiff struct {
+	Accounts       []*AccountDiff
+	Identities     []*IdentityDiff
+	ContractValues []*ContractValueDiff
+	// Truncated is set if the diff has more changes than the requested limit
+	Truncated bool
+}
+
+// IterateVersionsDiff calls fn for every account, identity and contract key which differs between two retained versions
+// of the state in key order, iteration stops when fn returns true.
+// Subtrees which have the same hash in both versions are skipped, so the cost depends on the number of changes
+// rather than on the state size.
+func (s *StateDB) IterateVersionsDiff(from, to int64, fn func(diff *StateValueDiff) bool) error {
+	fromTree, err := s.loadImmutableTree(from)
+	if err != nil {
+		return err
+	}
+	toTree, err := s.loadImmutableTree(to)
+	if err != nil {
+		return err
+	}
+	_, err = diffTrees(s.db, fromTree.tree.Hash(), toTree.tree.Hash(), func(diff *StateValueDiff) bool {
+		for _, prefix := range diffPrefixes {
+			if bytes.HasPrefix(diff.Key, prefix) {
+				return fn(diff)
+			}
+		}
+		return false
+	})
+	return err
+}
+
+// DiffVersions collects decoded changes between two retained versions of the state,
+// no more than limit changes are collected if limit is positive
+func (s *StateDB) DiffVersions(from, to int64, limit int) (*VersionsDiff, error) {
+	result := &VersionsDiff{}
+	var decodeErr error
+	count := 0
+	err := s.IterateVersionsDiff(from, to, func(diff *StateValueDiff) bool {
+		if limit > 0 && count == limit {
+			result.Truncated = true
+			return true
+		}
+		count++
+		if decodeErr = result.add(diff); decodeErr != nil {
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return result, nil
+}
+
+func (d *VersionsDiff) add(diff *StateValueDiff) error {
+	switch diff.Key[0] {
+	case addressPrefix[0]:
+		item := &AccountDiff{Address: common.BytesToAddress(diff.Key[1:])}
+		if diff.OldValue != nil {
+			item.Old = &Account{}
+			if err := item.Old.FromBytes(diff.OldValue); err != nil {
+				return errors.Wrapf(err, "failed to decode account %v", item.Address.Hex())
+			}
+		}
+		if diff.NewValue != nil {
+			item.New = &Account{}
+			if err := item.New.FromBytes(diff.NewValue); err != nil {
+				return errors.Wrapf(err, "failed to decode account %v", item.Address.Hex())
+			}
+		}
+		d.Accounts = append(d.Accounts, item)
+	case identityPrefix[0]:
+		item := &IdentityDiff{Address: common.BytesToAddress(diff.Key[1:])}
+		if diff.OldValue != nil {
+			item.Old = &Identity{}
+			if err := item.Old.FromBytes(diff.OldValue); err != nil {
+				return errors.Wrapf(err, "failed to decode identity %v", item.Address.Hex())
+			}
+		}
+		if diff.NewValue != nil {
+			item.New = &Identity{}
+			if err := item.New.FromBytes(diff.NewValue); err != nil {
+				return errors.Wrapf(err, "failed to decode identity %v", item.Address.Hex())
+			}
+		}
+		d.Identities = append(d.Identities, item)
+	case contractStorePrefix[0]:
+		d.ContractValues = append(d.ContractValues, &ContractValueDiff{
+			Contract: common.BytesToAddress(diff.Key[len(contractStorePrefix) : len(contractStorePrefix)+common.AddressLength]),
+			Key:      diff.Key[len(contractStorePrefix)+common.AddressLength:],
+			Old:      diff.OldValue,
+			New:      diff.NewValue,
+		})
+	}
+	return nil
+}
+
+func (s *StateDB) loadImmutableTree(version int64) (*ImmutableTree, error) {
+	// zero version means the latest one for iavl
+	if version <= 0 {
+		return nil, errors.Errorf("invalid state version %v", version)
+	}
+	tree := NewMutableTree(s.db)
+	if _, err := tree.LazyLoad(version); err != nil {
+		return nil, errors.Wrapf(err, "state version %v is not available", version)
+	}
+	return tree.GetImmutable(), nil
+}
+
+// diffNode is a node of the saved iavl tree, nodes are read from the db directly since iavl doesn't expose
+// hashes of child nodes
+type diffNode struct {
+	hash      []byte
+	height    int64
+	key       []byte
+	value     []byte
+	leftHash  []byte
+	rightHash []byte
+}
+
+// readDiffNode reads the node stored by iavl under "n" + hash key
+func readDiffNode(db dbm.DB, hash []byte) (*diffNode, error) {
+	data, err := db.Get(append([]byte{'n'}, hash...))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.Errorf("tree node %x is not found", hash)
+	}
+	node := &diffNode{hash: hash}
+	var n int
+	readVarint := func() int64 {
+		if err != nil {
+			return 0
+		}
+		v, read := binary.Varint(data[n:])
+		if read <= 0 {
+			err = errors.Errorf("invalid tree node %x", hash)
+			return 0
+		}
+		n += read
+		return v
+	}
+	readBytes := func() []byte {
+		if err != nil {
+			return nil
+		}
+		size, read := binary.Uvarint(data[n:])
+		if read <= 0 || uint64(len(data)-n-read) < size {
+			err = errors.Errorf("invalid tree node %x", hash)
+			return nil
+		}
+		n += read
+		value := data[n : n+int(size)]
+		n += int(size)
+		return value
+	}
+	// height, size, version, key and then either value or child hashes
+	node.height = readVarint()
+	readVarint()
+	readVarint()
+	node.key = readBytes()
+	if node.height == 0 {
+		node.value = readBytes()
+	} else {
+		node.leftHash = readBytes()
+		node.rightHash = readBytes()
+	}
+	return node, err
+}
+
+// diffCursor walks subtrees of the tree in key order, the next subtree is on the top of the stack
+type diffCursor struct {
+	db    dbm.DB
+	stack []*diffNode
+}
+
+func newDiffCursor(db dbm.DB, root []byte) (*diffCursor, error) {
+	c := &diffCursor{db: db}
+	if len(root) == 0 {
+		return c, nil
+	}
+	node, err := readDiffNode(db, root)
+	if err != nil {
+		return nil, err
+	}
+	c.stack = append(c.stack, node)
+	return c, nil
+}
+
+func (c *diffCursor) top() *diffNode {
+	if len(c.stack) == 0 {
+		return nil
+	}
+	return c.stack[len(c.stack)-1]
+}
+
+func (c *diffCursor) pop() {
+	c.stack = c.stack[:len(c.stack)-1]
+}
+
+// expand replaces the inner node on the top with its children
+func (c *diffCursor) expand() error {
+	node := c.top()
+	c.pop()
+	right, err := readDiffNode(c.db, node.rightHash)
+	if err != nil {
+		return err
+	}
+	left, err := readDiffNode(c.db, node.leftHash)
+	if err != nil {
+		return err
+	}
+	c.stack = append(c.stack, right, left)
+	return nil
+}
+
+// diffTrees walks two versions of the tree simultaneously. Subtrees with equal hashes have equal content, so they are
+// skipped without reading, the higher of different subtrees is split until leaves are compared.
+func diffTrees(db dbm.DB, fromRoot, toRoot []byte, fn func(diff *StateValueDiff) bool) (stopped bool, err error) {
+	fromCursor, err := newDiffCursor(db, fromRoot)
+	if err != nil {
+		return false, err
+	}
+	toCursor, err := newDiffCursor(db, toRoot)
+	if err != nil {
+		return false, err
+	}
+	for {
+		fromNode, toNode := fromCursor.top(), toCursor.top()
+		if fromNode == nil && toNode == nil {
+			return false, nil
+		}
+		if fromNode != nil && toNode != nil && bytes.Equal(fromNode.hash, toNode.hash) {
+			fromCursor.pop()
+			toCursor.pop()
+			continue
+		}
+		expandFrom := fromNode != nil && fromNode.height > 0 && (toNode == nil || fromNode.height >= toNode.height)
+		expandTo := toNode != nil && toNode.height > 0 && (fromNode == nil || toNode.height >= fromNode.height)
+		if expandFrom || expandTo {
+			if expandFrom {
+				if err := fromCursor.expand(); err != nil {
+					return false, err
+				}
+			}
+			if expandTo {
+				if err := toCursor.expand(); err != nil {
+					return false, err
+				}
+			}
+			continue
+		}
+
+		// both nodes are leaves, leaf hashes depend on versions, so values are compared
+		var diff *StateValueDiff
+		cmp := 0
+		switch {
+		case toNode == nil:
+			cmp = -1
+		case fromNode == nil:
+			cmp = 1
+		default:
+			cmp = bytes.Compare(fromNode.key, toNode.key)
+		}
+		switch {
+		case cmp < 0:
+			diff = &StateValueDiff{Key: fromNode.key, OldValue: fromNode.value}
+			fromCursor.pop()
+		case cmp > 0:
+			diff = &StateValueDiff{Key: toNode.key, NewValue: toNode.value}
+			toCursor.pop()
+		default:
+			if !bytes.Equal(fromNode.value, toNode.value) {
+				diff = &StateValueDiff{Key: toNode.key, OldValue: fromNode.value, NewValue: toNode.value}
+			}
+			fromCursor.pop()
+			toCursor.pop()
+		}
+		if diff != nil && fn(diff) {
+			return true, nil
+		}
+	}
+}
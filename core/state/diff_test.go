@@ -0,0 +1,130 @@
+package state
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"github.com/stretchr/testify/require"
+	db "github.com/tendermint/tm-db"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestStateDB_DiffVersions(t *testing.T) {
+	database := db.NewMemDB()
+	stateDb, _ := NewLazy(database)
+
+	stateDb.SetBalance(common.Address{0x1}, big.NewInt(10))
+	stateDb.SetBalance(common.Address{0x2}, big.NewInt(20))
+	stateDb.SetState(common.Address{0x3}, Candidate)
+	stateDb.SetContractValue(common.Address{0x4}, []byte{0x1}, []byte{0x1})
+	stateDb.SetContractValue(common.Address{0x4}, []byte{0x2}, []byte{0x2})
+	_, _, _, err := stateDb.Commit(true)
+	require.NoError(t, err)
+
+	stateDb.SetBalance(common.Address{0x1}, big.NewInt(15))
+	stateDb.ClearAccount(common.Address{0x2})
+	stateDb.SetBalance(common.Address{0x5}, big.NewInt(50))
+	stateDb.SetState(common.Address{0x3}, Verified)
+	stateDb.SetContractValue(common.Address{0x4}, []byte{0x1}, []byte{0x3})
+	stateDb.RemoveContractValue(common.Address{0x4}, []byte{0x2})
+	stateDb.SetContractValue(common.Address{0x4}, []byte{0x5}, []byte{0x5})
+	_, _, _, err = stateDb.Commit(true)
+	require.NoError(t, err)
+
+	diff, err := stateDb.DiffVersions(1, 2, 0)
+	require.NoError(t, err)
+	require.False(t, diff.Truncated)
+
+	require.Len(t, diff.Accounts, 3)
+	require.Equal(t, common.Address{0x1}, diff.Accounts[0].Address)
+	require.Equal(t, big.NewInt(10), diff.Accounts[0].Old.Balance)
+	require.Equal(t, big.NewInt(15), diff.Accounts[0].New.Balance)
+	require.Equal(t, common.Address{0x2}, diff.Accounts[1].Address)
+	require.NotNil(t, diff.Accounts[1].Old)
+	require.Nil(t, diff.Accounts[1].New)
+	require.Equal(t, common.Address{0x5}, diff.Accounts[2].Address)
+	require.Nil(t, diff.Accounts[2].Old)
+	require.Equal(t, big.NewInt(50), diff.Accounts[2].New.Balance)
+
+	require.Len(t, diff.Identities, 1)
+	require.Equal(t, Candidate, diff.Identities[0].Old.State)
+	require.Equal(t, Verified, diff.Identities[0].New.State)
+
+	require.Len(t, diff.ContractValues, 3)
+	require.Equal(t, &ContractValueDiff{Contract: common.Address{0x4}, Key: []byte{0x1}, Old: []byte{0x1}, New: []byte{0x3}}, diff.ContractValues[0])
+	require.Equal(t, &ContractValueDiff{Contract: common.Address{0x4}, Key: []byte{0x2}, Old: []byte{0x2}}, diff.ContractValues[1])
+	require.Equal(t, &ContractValueDiff{Contract: common.Address{0x4}, Key: []byte{0x5}, New: []byte{0x5}}, diff.ContractValues[2])
+
+	diff, err = stateDb.DiffVersions(1, 2, 2)
+	require.NoError(t, err)
+	require.True(t, diff.Truncated)
+	require.Len(t, diff.Accounts, 2)
+
+	diff, err = stateDb.DiffVersions(2, 2, 0)
+	require.NoError(t, err)
+	require.Empty(t, diff.Accounts)
+
+	_, err = stateDb.DiffVersions(1, 5, 0)
+	require.Error(t, err)
+	_, err = stateDb.DiffVersions(0, 2, 0)
+	require.Error(t, err)
+}
+
+// countingDb counts reads of tree nodes
+type countingDb struct {
+	db.DB
+	reads int
+}
+
+func (d *countingDb) Get(key []byte) ([]byte, error) {
+	d.reads++
+	return d.DB.Get(key)
+}
+
+func TestStateDB_IterateVersionsDiff(t *testing.T) {
+	database := &countingDb{DB: db.NewMemDB()}
+	stateDb, _ := NewLazy(database)
+
+	rnd := rand.New(rand.NewSource(1))
+	const accounts = 2000
+	for i := 0; i < accounts; i++ {
+		stateDb.SetBalance(common.BytesToAddress(big.NewInt(int64(i)).Bytes()), big.NewInt(int64(i)))
+	}
+	_, _, _, err := stateDb.Commit(true)
+	require.NoError(t, err)
+
+	changed := make(map[common.Address]struct{})
+	for i := 0; i < 10; i++ {
+		addr := common.BytesToAddress(big.NewInt(int64(rnd.Intn(accounts))).Bytes())
+		stateDb.SetBalance(addr, big.NewInt(-1))
+		changed[addr] = struct{}{}
+	}
+	stateDb.SetBalance(common.Address{0xff}, big.NewInt(1))
+	changed[common.Address{0xff}] = struct{}{}
+	_, _, _, err = stateDb.Commit(true)
+	require.NoError(t, err)
+
+	// the same value set again doesn't make a change
+	stateDb.SetBalance(common.Address{0xff}, big.NewInt(1))
+	_, _, _, err = stateDb.Commit(true)
+	require.NoError(t, err)
+
+	database.reads = 0
+	diff, err := stateDb.DiffVersions(1, 3, 0)
+	require.NoError(t, err)
+	require.Len(t, diff.Accounts, len(changed))
+	for _, item := range diff.Accounts {
+		require.Contains(t, changed, item.Address)
+	}
+	require.Less(t, database.reads, accounts/4)
+
+	diff, err = stateDb.DiffVersions(2, 3, 0)
+	require.NoError(t, err)
+	require.Empty(t, diff.Accounts)
+
+	// reversed diff
+	diff, err = stateDb.DiffVersions(3, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, diff.Accounts, len(changed))
+	require.Nil(t, diff.Accounts[len(diff.Accounts)-1].New)
+}
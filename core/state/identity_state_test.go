@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"crypto/rand"
 	"github.com/idena-network/idena-go/common"
 	"github.com/stretchr/testify/require"
@@ -166,3 +167,31 @@ func TestStateDB_Precommit(t *testing.T) {
 	require.True(t, diff.Values[1].Deleted)
 	require.False(t, diff.Values[0].Deleted)
 }
+
+func TestIdentityStateDB_RecoverPreliminarySnapshot(t *testing.T) {
+	stateDb := createStateDb()
+	expectedRoot := stateDb.Root()
+
+	buffer := new(bytes.Buffer)
+	root, err := stateDb.WriteSnapshot2(100, buffer)
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, root)
+	data := buffer.Bytes()
+
+	restored, _ := NewLazyIdentityState(db.NewMemDB())
+	restored.SetValidated(getRandAddr(), true)
+	restored.Commit(true)
+
+	require.Error(t, restored.RecoverPreliminarySnapshot(100, common.Hash{0x1}, bytes.NewReader(data)))
+	preliminaryPrefix, _ := IdentityStateDbKeys.ReadDbPrefix(restored.original, true)
+	require.Len(t, preliminaryPrefix, 0)
+
+	require.NoError(t, restored.RecoverPreliminarySnapshot(100, expectedRoot, bytes.NewReader(data)))
+	batch, dropDb, err := restored.SwitchToPreliminary(100)
+	require.NoError(t, err)
+	require.NoError(t, batch.WriteSync())
+	common.ClearDb(dropDb)
+
+	require.Equal(t, expectedRoot, restored.Root())
+	require.Equal(t, uint64(100), restored.Version())
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/golang/protobuf/proto"
 	"github.com/idena-network/idena-go/common"
@@ -460,6 +461,33 @@ func (s *IdentityStateDB) RecoverSnapshot2(height uint64, treeRoot common.Hash,
 	return ReadTreeFrom2(pdb, height, treeRoot, from)
 }
 
+func (s *IdentityStateDB) WriteSnapshot2(height uint64, to io.Writer) (root common.Hash, err error) {
+	return WriteTreeTo2(s.db, height, to)
+}
+
+// RecoverPreliminarySnapshot imports the tree of the snapshot into a new db and saves it as preliminary,
+// so it becomes current only after SwitchToPreliminary
+func (s *IdentityStateDB) RecoverPreliminarySnapshot(height uint64, treeRoot common.Hash, from io.Reader) error {
+	prefix := IdentityStateDbKeys.buildDbPrefix(height)
+	currentPrefix, err := IdentityStateDbKeys.LoadDbPrefix(s.original, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to load db prefix")
+	}
+	if bytes.Equal(prefix, currentPrefix) {
+		return errors.New("snapshot height conflicts with the current state")
+	}
+	pdb := dbm.NewPrefixDB(s.original, prefix)
+	common.ClearDb(pdb)
+	if err := ReadTreeFrom2(pdb, height, treeRoot, from); err != nil {
+		common.ClearDb(pdb)
+		return err
+	}
+	b := s.original.NewBatch()
+	defer b.Close()
+	IdentityStateDbKeys.SaveDbPrefix(b, prefix, true)
+	return b.WriteSync()
+}
+
 func (s *IdentityStateDB) CommitSnapshot(height uint64) (dropDb dbm.DB) {
 	pdb := dbm.NewPrefixDB(s.original, IdentityStateDbKeys.buildDbPrefix(height))
 	batch := s.original.NewBatch()
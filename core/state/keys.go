@@ -51,6 +51,17 @@ func (s *stateDbKeys) LoadDbPrefix(db dbm.DB) ([]byte, error) {
 	return p, nil
 }
 
+// ReadDbPrefix returns prefix of the current state tree or nil if it has not been initialized yet
+func (s *stateDbKeys) ReadDbPrefix(db dbm.DB) ([]byte, error) {
+	p, err := db.Get(currentStateDbPrefixKey)
+	return p, errors.Wrap(err, "failed to get value")
+}
+
+// StoredDbPrefixes returns prefixes of all state trees which have data in the db, including unused ones
+func (s *stateDbKeys) StoredDbPrefixes(db dbm.DB) ([][]byte, error) {
+	return storedDbPrefixes(db, stateDbPrefixBytes)
+}
+
 func (s *stateDbKeys) SaveDbPrefix(b dbm.Batch, prefix []byte) {
 	b.Set(currentStateDbPrefixKey, prefix)
 }
@@ -112,6 +123,22 @@ func (s *identityStateDbPrefix) LoadDbPrefix(db dbm.DB, preliminary bool) ([]byt
 	return p, nil
 }
 
+// ReadDbPrefix returns prefix of the current or preliminary identity state tree without initializing it,
+// nil or empty prefix means the tree is absent
+func (s *identityStateDbPrefix) ReadDbPrefix(db dbm.DB, preliminary bool) ([]byte, error) {
+	key := currentIdentityStateDbPrefixKey
+	if preliminary {
+		key = preliminaryIdentityStateDbPrefixKey
+	}
+	p, err := db.Get(key)
+	return p, errors.Wrap(err, "failed to get value")
+}
+
+// StoredDbPrefixes returns prefixes of all identity state trees which have data in the db, including unused ones
+func (s *identityStateDbPrefix) StoredDbPrefixes(db dbm.DB) ([][]byte, error) {
+	return storedDbPrefixes(db, identityStateDbPrefixBytes)
+}
+
 func (s *identityStateDbPrefix) buildDbPrefix(height uint64) []byte {
 	b := make([]byte, 8)
 	binary.LittleEndian.PutUint64(b, height)
@@ -125,3 +152,41 @@ func (s *identityStateDbPrefix) SaveDbPrefix(batch dbm.Batch, prefix []byte, pre
 	}
 	batch.Set(key, prefix)
 }
+
+// storedDbPrefixes finds prefixes of trees built by prefixBytes and height, it seeks to the next prefix instead of
+// iterating over all keys of a tree
+func storedDbPrefixes(db dbm.DB, prefixBytes []byte) ([][]byte, error) {
+	prefixLength := len(prefixBytes) + 8
+	start, end := prefixBytes, []byte{prefixBytes[0] + 1}
+	var result [][]byte
+	for start != nil {
+		it, err := db.Iterator(start, end)
+		if err != nil {
+			return nil, err
+		}
+		// global keys share the first byte with tree prefixes, they are shorter than prefixes
+		for ; it.Valid() && len(it.Key()) <= prefixLength; it.Next() {
+		}
+		if !it.Valid() {
+			it.Close()
+			break
+		}
+		prefix := common.CopyBytes(it.Key()[:prefixLength])
+		it.Close()
+		result = append(result, prefix)
+		start = nextPrefix(prefix)
+	}
+	return result, nil
+}
+
+// nextPrefix returns the smallest key which is greater than all keys starting with prefix, nil if there is no such key
+func nextPrefix(prefix []byte) []byte {
+	next := common.CopyBytes(prefix)
+	for i := len(next) - 1; i >= 0; i-- {
+		if next[i] < 0xff {
+			next[i]++
+			return next[:i+1]
+		}
+	}
+	return nil
+}
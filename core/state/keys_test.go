@@ -0,0 +1,38 @@
+package state
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"github.com/stretchr/testify/require"
+	db "github.com/tendermint/tm-db"
+	"testing"
+)
+
+func TestStateDbKeys_StoredDbPrefixes(t *testing.T) {
+	database := db.NewMemDB()
+	stateDb, _ := NewLazy(database)
+	stateDb.SetState(common.Address{0x1}, Verified)
+	stateDb.Commit(true)
+
+	identityStateDb, _ := NewLazyIdentityState(database)
+	identityStateDb.SetValidated(common.Address{0x1}, true)
+	identityStateDb.Commit(true)
+
+	orphan := db.NewPrefixDB(database, StateDbKeys.BuildDbPrefix(0xff))
+	orphan.Set([]byte{0x1}, []byte{0x1})
+
+	prefix, err := StateDbKeys.ReadDbPrefix(database)
+	require.NoError(t, err)
+	prefixes, err := StateDbKeys.StoredDbPrefixes(database)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{prefix, StateDbKeys.BuildDbPrefix(0xff)}, prefixes)
+
+	prefix, err = IdentityStateDbKeys.ReadDbPrefix(database, false)
+	require.NoError(t, err)
+	prefixes, err = IdentityStateDbKeys.StoredDbPrefixes(database)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{prefix}, prefixes)
+
+	prefix, err = IdentityStateDbKeys.ReadDbPrefix(database, true)
+	require.NoError(t, err)
+	require.Nil(t, prefix)
+}
@@ -2,6 +2,7 @@ package state
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/golang/protobuf/proto"
 	"github.com/idena-network/idena-go/blockchain/types"
 	"github.com/idena-network/idena-go/common"
@@ -10,6 +11,7 @@ import (
 	math2 "math"
 	"math/big"
 	"sort"
+	"strings"
 )
 
 type IdentityState uint8
@@ -47,6 +49,35 @@ func (f ApprovedIdentityFlag) HasFlag(flag ApprovedIdentityFlag) bool {
 	return f&flag != 0
 }
 
+var identityStateNames = map[IdentityState]string{
+	Undefined: "Undefined",
+	Invite:    "Invite",
+	Candidate: "Candidate",
+	Verified:  "Verified",
+	Suspended: "Suspended",
+	Killed:    "Killed",
+	Zombie:    "Zombie",
+	Newbie:    "Newbie",
+	Human:     "Human",
+}
+
+func (s IdentityState) String() string {
+	if name, ok := identityStateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("IdentityState(%d)", uint8(s))
+}
+
+// ParseIdentityState returns the identity state by its name, the name is case-insensitive
+func ParseIdentityState(name string) (IdentityState, bool) {
+	for identityState, stateName := range identityStateNames {
+		if strings.EqualFold(stateName, name) {
+			return identityState, true
+		}
+	}
+	return Undefined, false
+}
+
 func (s IdentityState) IsInShard() bool {
 	return s.NewbieOrBetter() || s == Candidate || s == Suspended || s == Zombie
 }
@@ -1485,6 +1516,120 @@ func IsCeremonyCandidateData(state IdentityState, hasDoneAllRequiredFlips bool)
 		state == Zombie) && hasDoneAllRequiredFlips
 }
 
+// DetermineNewIdentityState returns the state of the identity after the validation ceremony with the given results
+func DetermineNewIdentityState(identity Identity, shortScore, longScore, totalScore float32, totalQualifiedFlips uint32, missed, noQualShort, nonQualLong bool, candidateToNewbieFixEnabled bool) IdentityState {
+
+	if !identity.HasDoneAllRequiredFlips() {
+		switch identity.State {
+		case Verified, Human:
+			return Suspended
+		default:
+			return Killed
+		}
+	}
+
+	prevState := identity.State
+
+	switch prevState {
+	case Undefined:
+		return Undefined
+	case Invite:
+		return Killed
+	case Candidate:
+		if missed {
+			return Killed
+		}
+		if noQualShort || nonQualLong && shortScore >= common.MinShortScore {
+			if candidateToNewbieFixEnabled {
+				return Newbie
+			}
+			return Candidate
+		}
+		if shortScore < common.MinShortScore || longScore < common.MinLongScore {
+			return Killed
+		}
+		return Newbie
+	case Newbie:
+		if missed {
+			return Killed
+		}
+		if noQualShort ||
+			nonQualLong && totalQualifiedFlips >= common.MinFlipsForVerified && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore ||
+			nonQualLong && totalQualifiedFlips < common.MinFlipsForVerified && shortScore >= common.MinShortScore {
+			return Newbie
+		}
+		if totalQualifiedFlips >= common.MinFlipsForVerified && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
+			return Verified
+		}
+		if totalQualifiedFlips < common.MinFlipsForVerified && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
+			return Newbie
+		}
+		return Killed
+	case Verified:
+		if missed {
+			return Suspended
+		}
+		if noQualShort || nonQualLong && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore {
+			return Verified
+		}
+		if totalQualifiedFlips >= common.MinFlipsForHuman && totalScore >= common.MinHumanTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
+			return Human
+		}
+		if totalQualifiedFlips >= common.MinFlipsForVerified && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
+			return Verified
+		}
+		return Killed
+	case Suspended:
+		if missed {
+			return Zombie
+		}
+		if noQualShort || nonQualLong && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore {
+			return Suspended
+		}
+		if totalQualifiedFlips >= common.MinFlipsForHuman && totalScore >= common.MinHumanTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
+			return Human
+		}
+		if totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
+			return Verified
+		}
+		return Killed
+	case Zombie:
+		if missed {
+			return Killed
+		}
+		if noQualShort || nonQualLong && totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore {
+			return Zombie
+		}
+		if totalQualifiedFlips >= common.MinFlipsForHuman && totalScore >= common.MinHumanTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
+			return Human
+		}
+		if totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore {
+			return Verified
+		}
+		return Killed
+	case Human:
+		if missed {
+			return Suspended
+		}
+		if noQualShort || nonQualLong && totalScore >= common.MinHumanTotalScore && shortScore >= common.MinShortScore {
+			return Human
+		}
+		if nonQualLong {
+			return Suspended
+		}
+		if totalScore >= common.MinHumanTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
+			return Human
+		}
+		if totalScore >= common.MinTotalScore && shortScore >= common.MinShortScore && longScore >= common.MinLongScore {
+			return Verified
+		}
+		return Suspended
+	case Killed:
+		return Killed
+	}
+	return Undefined
+}
+
 func (s *stateStatusSwitch) empty() bool {
 	return len(s.data.Addresses) == 0
 }
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"github.com/idena-network/idena-go/common"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -14,3 +15,262 @@ func TestStateIdentity_SubPenaltySeconds(t *testing.T) {
 	identity.SubPenaltySeconds(1)
 	require.Zero(t, identity.GetPenaltySeconds())
 }
+
+func TestDetermineNewIdentityState(t *testing.T) {
+
+	type data struct {
+		prev                IdentityState
+		shortScore          float32
+		longScore           float32
+		totalScore          float32
+		totalQualifiedFlips uint32
+		missed              bool
+		expected            IdentityState
+		noQualShort         bool
+		noQualLong          bool
+	}
+
+	cases := []data{
+		{
+			Killed,
+			0, 0, 0, 0, true,
+			Killed, false, false,
+		},
+		{
+			Invite,
+			1, 1, 1, 110, false,
+			Killed, false, false,
+		},
+		{
+			Candidate,
+			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 11, false,
+			Newbie, false, false,
+		},
+		{
+			Candidate,
+			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 11, true,
+			Killed, false, false,
+		},
+		{
+			Newbie,
+			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 11, false,
+			Newbie, false, false,
+		},
+		{
+			Newbie,
+			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 13, false,
+			Verified, false, false,
+		},
+		{
+			Newbie,
+			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 10, false,
+			Newbie, false, false,
+		},
+		{
+			Newbie,
+			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 11, true,
+			Killed, false, false,
+		},
+		{
+			Newbie,
+			0.4, 0.8, 1, 11, false,
+			Killed, false, false,
+		},
+		{
+			Newbie,
+			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 8, false,
+			Newbie, false, false,
+		},
+		{
+			Verified,
+			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 10, false,
+			Killed, false, false,
+		},
+		{
+			Verified,
+			0, 0, 0, 0, true,
+			Suspended, false, false,
+		},
+		{
+			Verified,
+			0, 0, 0, 0, false,
+			Killed, false, false,
+		},
+		{
+			Suspended,
+			common.MinShortScore, common.MinLongScore, common.MinTotalScore, 10, false,
+			Verified, false, false,
+		},
+		{
+			Suspended,
+			1, 0.8, 0, 10, true,
+			Zombie, false, false,
+		},
+		{
+			Zombie,
+			common.MinShortScore, 0, common.MinTotalScore, 10, false,
+			Verified, false, false,
+		},
+		{
+			Zombie,
+			1, 0, 0, 10, true,
+			Killed, false, false,
+		},
+		{
+			Candidate,
+			0, 0, 0, 5, false,
+			Newbie, true, false,
+		},
+		{
+			Candidate,
+			common.MinShortScore, 0, 0, 5, false,
+			Newbie, false, true,
+		},
+		{
+			Candidate,
+			0, 0, 0, 5, false,
+			Killed, false, true,
+		},
+		{
+			Candidate,
+			common.MinShortScore - 0.1, 0, 0, 5, false,
+			Killed, false, true,
+		},
+		{
+			Newbie,
+			common.MinShortScore, 0, 0.1, 5, false,
+			Newbie, true, false,
+		},
+		{
+			Newbie,
+			common.MinShortScore, 0, 0.1, 5, false,
+			Newbie, false, true,
+		},
+		{
+			Newbie,
+			common.MinShortScore, 0, 0.1, 13, false,
+			Killed, false, true,
+		},
+		{
+			Newbie,
+			common.MinShortScore - 0.1, 0, 0.1, 9, false,
+			Killed, false, true,
+		},
+		{
+			Verified,
+			common.MinShortScore - 0.1, 0, 0.1, 10, false,
+			Verified, true, false,
+		},
+		{
+			Verified,
+			common.MinShortScore - 0.1, 0, 1.1, 10, false,
+			Killed, false, true,
+		},
+		{
+			Suspended,
+			common.MinShortScore - 0.1, 0, 0.1, 10, false,
+			Suspended, true, false,
+		},
+		{
+			Suspended,
+			common.MinShortScore - 0.1, 0, 1.1, 10, false,
+			Killed, false, true,
+		},
+		{
+			Zombie,
+			common.MinShortScore - 0.1, 0, 0.1, 10, false,
+			Zombie, true, false,
+		},
+		{
+			Zombie,
+			common.MinShortScore, 0, 0.1, 10, false,
+			Killed, false, true,
+		},
+		{
+			Suspended,
+			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, false,
+			Human, false, false,
+		},
+		{
+			Suspended,
+			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 23, false,
+			Verified, false, false,
+		},
+		{
+			Zombie,
+			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, false,
+			Human, false, false,
+		},
+		{
+			Zombie,
+			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 23, false,
+			Verified, false, false,
+		},
+		{
+			Human,
+			0.1, common.MinLongScore, common.MinHumanTotalScore, 24, false,
+			Suspended, false, false,
+		},
+		{
+			Human,
+			common.MinShortScore, 0.1, common.MinHumanTotalScore, 24, false,
+			Suspended, false, false,
+		},
+		{
+			Human,
+			0.1, 0.1, common.MinHumanTotalScore, 24, false,
+			Suspended, false, true,
+		},
+		{
+			Human,
+			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, false,
+			Human, false, true,
+		},
+		{
+			Human,
+			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, true,
+			Suspended, false, false,
+		},
+		{
+			Human,
+			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, false,
+			Human, true, true,
+		},
+		{
+			Verified,
+			common.MinShortScore, common.MinLongScore, common.MinHumanTotalScore, 24, false,
+			Human, false, false,
+		},
+		{
+			Human,
+			0, 0, common.MinHumanTotalScore, 24, false,
+			Suspended, false, false,
+		},
+
+		{
+			Human,
+			0, 0, 0.74, 24, false,
+			Suspended, false, false,
+		},
+	}
+
+	require := require.New(t)
+	for i, c := range cases {
+		require.Equal(c.expected, DetermineNewIdentityState(Identity{State: c.prev}, c.shortScore, c.longScore, c.totalScore, c.totalQualifiedFlips, c.missed, c.noQualShort, c.noQualLong, true), "index = %v", i)
+	}
+}
+
+func TestIdentityState_String(t *testing.T) {
+	for identityState := Undefined; identityState <= Human; identityState++ {
+		parsed, ok := ParseIdentityState(identityState.String())
+		require.True(t, ok)
+		require.Equal(t, identityState, parsed)
+	}
+	parsed, ok := ParseIdentityState("newbie")
+	require.True(t, ok)
+	require.Equal(t, Newbie, parsed)
+
+	_, ok = ParseIdentityState("Unknown")
+	require.False(t, ok)
+	require.Equal(t, "IdentityState(9)", IdentityState(9).String())
+}
@@ -1270,6 +1270,16 @@ func (s *StateDB) GetIdentityWithProof(addr common.Address) ([]byte, error) {
 	return s.tree.GetImmutable().GetWithProof(StateDbKeys.IdentityKey(addr))
 }
 
+// GetWithProof returns the raw value of the state key with a merkle proof against the state root, nil if the key is absent
+func (s *StateDB) GetWithProof(key []byte) ([]byte, error) {
+	return s.tree.GetImmutable().GetWithProof(key)
+}
+
+// GetAbsenceProof returns a proof of the state key absence against the state root, nil if the key exists
+func (s *StateDB) GetAbsenceProof(key []byte) ([]byte, error) {
+	return s.tree.GetImmutable().GetAbsenceProof(key)
+}
+
 func (s *StateDB) IterateOverIdentities(callback func(addr common.Address, identity Identity)) {
 	s.IterateIdentities(func(key []byte, value []byte) bool {
 		if key == nil {
@@ -195,6 +195,75 @@ func TestStateDB_GetOrNewIdentityObject(t *testing.T) {
 	require.Equal(t, Verified, fromDb.State())
 }
 
+func TestStateDB_GetWithProof(t *testing.T) {
+	database := db.NewMemDB()
+	stateDb, _ := NewLazy(database)
+
+	var addrs []common.Address
+	for i := 0; i < 10; i++ {
+		key, _ := crypto.GenerateKey()
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		stateDb.GetOrNewAccountObject(addr).SetBalance(big.NewInt(int64(i + 1)))
+		addrs = append(addrs, addr)
+	}
+	stateDb.Commit(false)
+	root := stateDb.Root()
+
+	key := StateDbKeys.AddressKey(addrs[3])
+	data, err := stateDb.GetWithProof(key)
+	require.NoError(t, err)
+
+	value, err := VerifyValueWithProof(root, key, data)
+	require.NoError(t, err)
+	var account Account
+	require.NoError(t, account.FromBytes(value))
+	require.Equal(t, big.NewInt(4), account.Balance)
+
+	_, err = VerifyValueWithProof(common.Hash{0x1}, key, data)
+	require.Error(t, err)
+	_, err = VerifyValueWithProof(root, StateDbKeys.AddressKey(addrs[4]), data)
+	require.Error(t, err)
+}
+
+func TestStateDB_GetAbsenceProof(t *testing.T) {
+	database := db.NewMemDB()
+	stateDb, _ := NewLazy(database)
+
+	var addrs []common.Address
+	for i := 0; i < 10; i++ {
+		key, _ := crypto.GenerateKey()
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		stateDb.GetOrNewAccountObject(addr).SetBalance(big.NewInt(int64(i + 1)))
+		addrs = append(addrs, addr)
+	}
+	stateDb.Commit(false)
+	root := stateDb.Root()
+
+	existing := StateDbKeys.AddressKey(addrs[3])
+	data, err := stateDb.GetAbsenceProof(existing)
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	for _, missing := range [][]byte{
+		StateDbKeys.AddressKey(common.Address{}),
+		StateDbKeys.AddressKey(common.Address{0xff, 0xff}),
+		append(StateDbKeys.AddressKey(addrs[5]), 0x1),
+	} {
+		data, err := stateDb.GetAbsenceProof(missing)
+		require.NoError(t, err)
+		require.NotEmpty(t, data)
+		require.NoError(t, VerifyAbsenceProof(root, missing, data))
+
+		require.Error(t, VerifyAbsenceProof(common.Hash{0x1}, missing, data))
+	}
+
+	// proof of a missing key doesn't prove absence of existing keys
+	missing := append(StateDbKeys.AddressKey(addrs[3]), 0x1)
+	data, err = stateDb.GetAbsenceProof(missing)
+	require.NoError(t, err)
+	require.Error(t, VerifyAbsenceProof(root, existing, data))
+}
+
 func TestStateGlobal_IncEpoch(t *testing.T) {
 	database := db.NewMemDB()
 	stateDb, _ := NewLazy(database)
@@ -575,3 +644,36 @@ func TestStateDb_AddNewScore(t *testing.T) {
 		require.Equal(t, uint32(6), flips)
 	}
 }
+
+func BenchmarkStateDB_Commit(b *testing.B) {
+	for _, backend := range []db.BackendType{db.GoLevelDBBackend, db.BoltDBBackend} {
+		b.Run(string(backend), func(b *testing.B) {
+			database, err := db.NewDB("bench", backend, b.TempDir())
+			if err != nil {
+				b.Skipf("backend is not available: %v", err)
+			}
+			defer database.Close()
+			stateDb, _ := NewLazy(database)
+
+			const accounts, changesPerCommit = 1000, 100
+			addrs := make([]common.Address, accounts)
+			for i := range addrs {
+				rand.Read(addrs[i][:])
+				stateDb.SetBalance(addrs[i], big.NewInt(int64(i)))
+			}
+			if _, _, _, err := stateDb.Commit(true); err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < changesPerCommit; j++ {
+					stateDb.AddBalance(addrs[rand.Intn(accounts)], big.NewInt(1))
+				}
+				if _, _, _, err := stateDb.Commit(true); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package state
 
 import (
+	"bytes"
 	"github.com/cosmos/iavl"
+	iavlproto "github.com/cosmos/iavl/proto"
 	"github.com/golang/protobuf/proto"
 	"github.com/idena-network/idena-go/common"
 	models "github.com/idena-network/idena-go/protobuf"
+	"github.com/pkg/errors"
 	dbm "github.com/tendermint/tm-db"
 	"sync"
 )
@@ -274,6 +277,11 @@ func (t *ImmutableTree) Exporter() *iavl.Exporter {
 	return t.tree.Export()
 }
 
+// Iterator returns iterator over keys between start (inclusive) and end (exclusive)
+func (t *ImmutableTree) Iterator(start, end []byte, ascending bool) *iavl.Iterator {
+	return t.tree.Iterator(start, end, ascending)
+}
+
 type valueWithProof struct {
 	Value []byte
 	Leaf  iavl.ProofLeafNode
@@ -320,3 +328,70 @@ func (t *ImmutableTree) GetWithProof(key []byte) ([]byte, error) {
 		Proof: proof.LeftPath,
 	}).toBytes()
 }
+
+// GetAbsenceProof returns a range proof of the key absence, it is nil if the key exists
+func (t *ImmutableTree) GetAbsenceProof(key []byte) ([]byte, error) {
+	// the left leaf is followed by the nearest right leaf, both of them are required to prove the absence
+	keys, _, proof, err := t.tree.GetRangeWithProof(key, nil, 2)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > 0 && bytes.Equal(keys[0], key) {
+		return nil, nil
+	}
+	return proof.ToProto().Marshal()
+}
+
+// VerifyAbsenceProof checks that the range proof produced by GetAbsenceProof belongs to the tree with the given root
+// and proves that the tree has no value for the key
+func VerifyAbsenceProof(root common.Hash, key []byte, data []byte) error {
+	protoObj := new(iavlproto.RangeProof)
+	if err := protoObj.Unmarshal(data); err != nil {
+		return err
+	}
+	proof, err := iavl.RangeProofFromProto(protoObj)
+	if err != nil {
+		return err
+	}
+	if len(proof.Leaves) == 0 {
+		return errors.New("proof leaves are missing")
+	}
+	if err := proof.Verify(root[:]); err != nil {
+		return err
+	}
+	return proof.VerifyAbsence(key)
+}
+
+// VerifyValueWithProof checks that the value with proof produced by GetWithProof belongs to the tree with the given root and returns the value
+func VerifyValueWithProof(root common.Hash, key []byte, data []byte) ([]byte, error) {
+	protoObj := new(models.ValueWithProof)
+	if err := proto.Unmarshal(data, protoObj); err != nil {
+		return nil, err
+	}
+	if protoObj.Leaf == nil {
+		return nil, errors.New("proof leaf is missing")
+	}
+	proof := &iavl.RangeProof{
+		Leaves: []iavl.ProofLeafNode{{
+			Key:       protoObj.Leaf.Key,
+			ValueHash: protoObj.Leaf.ValueHash,
+			Version:   int64(protoObj.Leaf.Version),
+		}},
+	}
+	for _, item := range protoObj.Proof {
+		proof.LeftPath = append(proof.LeftPath, iavl.ProofInnerNode{
+			Height:  int8(item.Height),
+			Size:    int64(item.Size),
+			Version: int64(item.Version),
+			Left:    item.Left,
+			Right:   item.Right,
+		})
+	}
+	if err := proof.Verify(root[:]); err != nil {
+		return nil, err
+	}
+	if err := proof.VerifyItem(key, protoObj.Value); err != nil {
+		return nil, err
+	}
+	return protoObj.Value, nil
+}
@@ -1,12 +1,14 @@
 package database
 
 import (
+	"bytes"
 	"github.com/golang/protobuf/proto"
 	"github.com/idena-network/idena-go/blockchain/types"
 	"github.com/idena-network/idena-go/common"
 	"github.com/idena-network/idena-go/ipfs"
 	"github.com/idena-network/idena-go/log"
 	models "github.com/idena-network/idena-go/protobuf"
+	"github.com/pkg/errors"
 	dbm "github.com/tendermint/tm-db"
 	"time"
 )
@@ -24,6 +26,7 @@ var (
 	PublicFlipKeyPrefix   = []byte("pubk")
 	PrivateFlipKeyPrefix  = []byte("pk")
 	LotteryIdentities     = []byte("li")
+	FlipSubmissionPrefix  = []byte("fsub")
 )
 
 type EpochDb struct {
@@ -45,6 +48,11 @@ type DbEvidenceMap struct {
 	Map    []byte
 }
 
+type DbRecord struct {
+	Key   []byte
+	Value []byte
+}
+
 type DbLotteryIdentity struct {
 	Address                 common.Address
 	ShiftedShardId          common.ShardId
@@ -424,3 +432,68 @@ func (edb *EpochDb) ReadLotteryIdentities() []DbLotteryIdentity {
 	}
 	return res
 }
+
+func (edb *EpochDb) WriteFlipSubmission(cid []byte, data []byte) {
+	assertNoError(edb.db.Set(append(FlipSubmissionPrefix, cid...), data))
+}
+
+func (edb *EpochDb) ReadFlipSubmission(cid []byte) []byte {
+	data, err := edb.db.Get(append(FlipSubmissionPrefix, cid...))
+	assertNoError(err)
+	return data
+}
+
+func (edb *EpochDb) IterateOverFlipSubmissions(callback func(cid []byte, data []byte)) {
+	it, err := edb.db.Iterator(append(FlipSubmissionPrefix, ipfs.MinCid[:]...), append(FlipSubmissionPrefix, ipfs.MaxCid[:]...))
+	assertNoError(err)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		callback(it.Key()[len(FlipSubmissionPrefix):], it.Value())
+	}
+}
+
+// ownRecordPrefixes are prefixes of records created by the node itself which can't be restored from the network
+var ownRecordPrefixes = [][]byte{TxOwnPrefix, SuccessfulTxOwnPrefix, OwnShortAnswerKey, FlipSubmissionPrefix}
+
+func isOwnRecordKey(key []byte) bool {
+	for _, prefix := range ownRecordPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadOwnRecords returns own txs, own answers and flip submissions of the epoch
+func (edb *EpochDb) ReadOwnRecords() []DbRecord {
+	var result []DbRecord
+	for _, prefix := range ownRecordPrefixes {
+		it, err := dbm.IteratePrefix(edb.db, prefix)
+		assertNoError(err)
+		for ; it.Valid(); it.Next() {
+			result = append(result, DbRecord{
+				Key:   common.CopyBytes(it.Key()),
+				Value: common.CopyBytes(it.Value()),
+			})
+		}
+		it.Close()
+	}
+	return result
+}
+
+// WriteOwnRecords restores records returned by ReadOwnRecords, records with other keys are rejected
+func (edb *EpochDb) WriteOwnRecords(records []DbRecord) error {
+	for _, record := range records {
+		if !isOwnRecordKey(record.Key) {
+			return errors.Errorf("unexpected record key %x", record.Key)
+		}
+	}
+	batch := edb.db.NewBatch()
+	defer batch.Close()
+	for _, record := range records {
+		if err := batch.Set(record.Key, record.Value); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
@@ -135,3 +135,46 @@ func TestEpochDb_HasSuccessfulOwnTx(t *testing.T) {
 	require.True(edb.HasSuccessfulOwnTx(common.Hash{0x1}))
 	require.False(edb.HasSuccessfulOwnTx(common.Hash{0x2}))
 }
+
+func TestEpochDb_IterateOverFlipSubmissions(t *testing.T) {
+	require := require.New(t)
+	edb := NewEpochDb(db.NewMemDB(), 1)
+
+	edb.WriteFlipSubmission([]byte{0x1}, []byte{0x10})
+	edb.WriteFlipSubmission([]byte{0x2}, []byte{0x20})
+	edb.WriteFlipSubmission([]byte{0x1}, []byte{0x11})
+	edb.WriteFlipCid([]byte{0x3})
+
+	require.Equal([]byte{0x11}, edb.ReadFlipSubmission([]byte{0x1}))
+	require.Nil(edb.ReadFlipSubmission([]byte{0x3}))
+
+	submissions := make(map[byte][]byte)
+	edb.IterateOverFlipSubmissions(func(cid []byte, data []byte) {
+		submissions[cid[0]] = data
+	})
+	require.Len(submissions, 2)
+	require.Equal([]byte{0x20}, submissions[0x2])
+}
+
+func TestEpochDb_OwnRecords(t *testing.T) {
+	require := require.New(t)
+	edb := NewEpochDb(db.NewMemDB(), 1)
+
+	edb.WriteOwnTx(1, []byte{0x1})
+	edb.WriteSuccessfulOwnTx(common.Hash{0x2})
+	edb.WriteFlipSubmission([]byte{0x3}, []byte{0x4})
+	edb.WriteFlipCid([]byte{0x5})
+	edb.WriteLotterySeed([]byte{0x6})
+
+	records := edb.ReadOwnRecords()
+	require.Len(records, 3)
+
+	restored := NewEpochDb(db.NewMemDB(), 1)
+	require.NoError(restored.WriteOwnRecords(records))
+	require.Equal([]byte{0x1}, restored.ReadOwnTx(1))
+	require.True(restored.HasSuccessfulOwnTx(common.Hash{0x2}))
+	require.Equal([]byte{0x4}, restored.ReadFlipSubmission([]byte{0x3}))
+
+	require.Error(restored.WriteOwnRecords([]DbRecord{{Key: LotterySeedKey, Value: []byte{0x1}}}))
+	require.Nil(restored.ReadLotterySeed())
+}
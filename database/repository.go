@@ -29,6 +29,12 @@ func NewRepo(db dbm.DB) *Repo {
 	}
 }
 
+func encodeUint16Number(number uint16) []byte {
+	enc := make([]byte, 2)
+	binary.BigEndian.PutUint16(enc, number)
+	return enc
+}
+
 func encodeUint32Number(number uint32) []byte {
 	enc := make([]byte, 4)
 	binary.BigEndian.PutUint32(enc, number)
@@ -94,6 +100,15 @@ func identityStateDiffKey(height uint64) []byte {
 	return append(identityStateDiffPrefix, encodeUint64Number(height)...)
 }
 
+func validationReportKey(epoch uint16, address common.Address) []byte {
+	key := append(validationReportPrefix, encodeUint16Number(epoch)...)
+	return append(key, address[:]...)
+}
+
+func ownInviteKey(receiver common.Address) []byte {
+	return append(ownInvitePrefix, receiver[:]...)
+}
+
 func (r *Repo) ReadBlockHeader(hash common.Hash) *types.Header {
 	data, err := r.db.Get(headerKey(hash))
 	assertNoError(err)
@@ -216,6 +231,35 @@ func (r *Repo) ReadTxIndex(hash common.Hash) *types.TransactionIndex {
 	return index
 }
 
+// IterateTxIndexes calls callback for every stored transaction index, iteration stops when callback returns true
+func (r *Repo) IterateTxIndexes(callback func(txHash common.Hash, index *types.TransactionIndex) bool) {
+	it, err := dbm.IteratePrefix(r.db, transactionIndexPrefix)
+	assertNoError(err)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		key := it.Key()
+		if len(key) != len(transactionIndexPrefix)+common.HashLength {
+			continue
+		}
+		index := new(types.TransactionIndex)
+		if err := index.FromBytes(it.Value()); err != nil {
+			log.Error("invalid transaction index proto", "err", err)
+			index = nil
+		}
+		if callback(common.BytesToHash(key[len(transactionIndexPrefix):]), index) {
+			return
+		}
+	}
+}
+
+func (r *Repo) RemoveTxIndex(batch dbm.Batch, hash common.Hash) {
+	if batch != nil {
+		batch.Delete(txIndexKey(hash))
+	} else {
+		r.db.Delete(txIndexKey(hash))
+	}
+}
+
 func (r *Repo) WriteReceiptIndex(hash common.Hash, idx *types.TxReceiptIndex) {
 	data, err := idx.ToBytes()
 	if err != nil {
@@ -240,6 +284,35 @@ func (r *Repo) ReadReceiptIndex(hash common.Hash) *types.TxReceiptIndex {
 	return index
 }
 
+// IterateReceiptIndexes calls callback for every stored receipt index, iteration stops when callback returns true
+func (r *Repo) IterateReceiptIndexes(callback func(txHash common.Hash, index *types.TxReceiptIndex) bool) {
+	it, err := dbm.IteratePrefix(r.db, receiptIndexPrefix)
+	assertNoError(err)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		key := it.Key()
+		if len(key) != len(receiptIndexPrefix)+common.HashLength {
+			continue
+		}
+		index := new(types.TxReceiptIndex)
+		if err := index.FromBytes(it.Value()); err != nil {
+			log.Error("invalid receipt index proto", "err", err)
+			index = nil
+		}
+		if callback(common.BytesToHash(key[len(receiptIndexPrefix):]), index) {
+			return
+		}
+	}
+}
+
+func (r *Repo) RemoveReceiptIndex(batch dbm.Batch, hash common.Hash) {
+	if batch != nil {
+		batch.Delete(receiptIndexKey(hash))
+	} else {
+		r.db.Delete(receiptIndexKey(hash))
+	}
+}
+
 func (r *Repo) ReadCertificate(hash common.Hash) *types.BlockCert {
 	data, err := r.db.Get(certKey(hash))
 	assertNoError(err)
@@ -277,7 +350,7 @@ func (r *Repo) writeWeakCertificate(w *models.ProtoWeakCertificates) {
 	r.db.Set(weakCertificatesKey, data)
 }
 
-func (r *Repo) removeCertificate(hash common.Hash) {
+func (r *Repo) RemoveCertificate(hash common.Hash) {
 	r.db.Delete(certKey(hash))
 }
 
@@ -289,7 +362,7 @@ func (r *Repo) WriteWeakCertificate(hash common.Hash) {
 	weakCerts.Hashes = append(weakCerts.Hashes, hash[:])
 
 	if len(weakCerts.Hashes) > MaxWeakCertificatesCount {
-		r.removeCertificate(common.BytesToHash(weakCerts.Hashes[0]))
+		r.RemoveCertificate(common.BytesToHash(weakCerts.Hashes[0]))
 		weakCerts.Hashes = weakCerts.Hashes[1:]
 	}
 	r.writeWeakCertificate(weakCerts)
@@ -325,6 +398,10 @@ func (r *Repo) WriteLastSnapshotManifest(cidV2 []byte, root common.Hash, height
 	return nil
 }
 
+func (r *Repo) RemoveLastSnapshotManifest() {
+	r.db.Delete(lastSnapshotKey)
+}
+
 func (r *Repo) WriteIdentityStateDiff(height uint64, diff []byte) {
 	r.db.Set(identityStateDiffKey(height), diff)
 }
@@ -335,6 +412,73 @@ func (r *Repo) ReadIdentityStateDiff(height uint64) []byte {
 	return data
 }
 
+func (r *Repo) WriteValidationReports(epoch uint16, reports map[common.Address]*types.ValidationReport) {
+	batch := r.db.NewBatch()
+	defer batch.Close()
+	for addr, report := range reports {
+		data, err := report.ToBytes()
+		if err != nil {
+			log.Crit("failed to proto encode validation report", "err", err)
+			return
+		}
+		assertNoError(batch.Set(validationReportKey(epoch, addr), data))
+	}
+	assertNoError(batch.Write())
+}
+
+func (r *Repo) ReadValidationReport(epoch uint16, address common.Address) *types.ValidationReport {
+	data, err := r.db.Get(validationReportKey(epoch, address))
+	assertNoError(err)
+	if data == nil {
+		return nil
+	}
+	report := new(types.ValidationReport)
+	if err := report.FromBytes(data); err != nil {
+		log.Error("cannot parse validation report", "err", err)
+		return nil
+	}
+	return report
+}
+
+func (r *Repo) WriteOwnInvite(invite *types.OwnInvite) {
+	data, err := invite.ToBytes()
+	if err != nil {
+		log.Crit("failed to proto encode own invite", "err", err)
+		return
+	}
+	assertNoError(r.db.Set(ownInviteKey(invite.Receiver), data))
+}
+
+func (r *Repo) ReadOwnInvite(receiver common.Address) *types.OwnInvite {
+	data, err := r.db.Get(ownInviteKey(receiver))
+	assertNoError(err)
+	if data == nil {
+		return nil
+	}
+	invite := new(types.OwnInvite)
+	if err := invite.FromBytes(data); err != nil {
+		log.Error("cannot parse own invite", "err", err)
+		return nil
+	}
+	return invite
+}
+
+func (r *Repo) GetOwnInvites() []*types.OwnInvite {
+	it, err := dbm.IteratePrefix(r.db, ownInvitePrefix)
+	assertNoError(err)
+	defer it.Close()
+	var invites []*types.OwnInvite
+	for ; it.Valid(); it.Next() {
+		invite := new(types.OwnInvite)
+		if err := invite.FromBytes(it.Value()); err != nil {
+			log.Error("cannot parse own invite", "err", err)
+			continue
+		}
+		invites = append(invites, invite)
+	}
+	return invites
+}
+
 func (r *Repo) WritePreliminaryHead(header *types.Header) {
 	data, err := header.ToBytes()
 	if err != nil {
@@ -405,6 +549,10 @@ func (r *Repo) SaveTx(address common.Address, blockHash common.Hash, timestamp i
 	r.db.Set(savedTxKey(address, timestamp, transaction.AccountNonce, transaction.Hash()), data)
 }
 
+func (r *Repo) DeleteSavedTx(address common.Address, timestamp int64, transaction *types.Transaction) {
+	r.db.Delete(savedTxKey(address, timestamp, transaction.AccountNonce, transaction.Hash()))
+}
+
 func (r *Repo) GetSavedTxs(address common.Address, count int, token []byte) (txs []*types.SavedTransaction, nextToken []byte) {
 
 	if token == nil {
@@ -447,6 +595,26 @@ func (r *Repo) DeleteOutdatedBurntCoins(blockHeight uint64, blockRange uint64) {
 	}
 }
 
+// DeleteBurntCoinsBefore removes burnt coins of blocks below the height
+func (r *Repo) DeleteBurntCoinsBefore(height uint64) {
+	it, err := r.db.Iterator(burntCoinsMinKey(), burntCoinsKey(height, common.BytesToHash(common.MinHash[:])))
+	assertNoError(err)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		r.db.Delete(it.Key())
+	}
+}
+
+// DeleteBurntCoinsAfter removes burnt coins of blocks above the height
+func (r *Repo) DeleteBurntCoinsAfter(height uint64) {
+	it, err := r.db.Iterator(burntCoinsKey(height+1, common.BytesToHash(common.MinHash[:])), burntCoinsKey(math.MaxUint64, common.BytesToHash(common.MaxHash[:])))
+	assertNoError(err)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		r.db.Delete(it.Key())
+	}
+}
+
 func (r *Repo) SaveBurntCoins(blockHeight uint64, txHash common.Hash, address common.Address, key string, amount *big.Int) {
 	s := &types.BurntCoins{
 		Address: address,
@@ -550,6 +718,33 @@ func (r *Repo) ReadIntermediateGenesis() uint64 {
 	return binary.LittleEndian.Uint64(data)
 }
 
+// WriteRestoredSnapshotHeight marks that the chain starts from a locally restored snapshot, so it has no blocks
+// between genesis and the snapshot height
+func (r *Repo) WriteRestoredSnapshotHeight(height uint64) {
+	r.db.Set(restoredSnapshotKey, common.ToBytes(height))
+}
+
+func (r *Repo) ReadRestoredSnapshotHeight() uint64 {
+	data, err := r.db.Get(restoredSnapshotKey)
+	if err != nil || len(data) == 0 {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(data)
+}
+
+// WritePrunedHorizon saves the lowest height whose block body, tx indexes and receipts are kept
+func (r *Repo) WritePrunedHorizon(height uint64) {
+	r.db.Set(prunedHorizonKey, common.ToBytes(height))
+}
+
+func (r *Repo) ReadPrunedHorizon() uint64 {
+	data, err := r.db.Get(prunedHorizonKey)
+	if err != nil || len(data) == 0 {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(data)
+}
+
 func (r *Repo) WriteUpgradeVotes(votes *types.UpgradeVotes) {
 	data, _ := votes.ToBytes()
 	r.db.Set(upgradeVotesKey, data)
@@ -6,6 +6,7 @@ import (
 	"github.com/idena-network/idena-go/common"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tm-db"
+	"math/big"
 	"testing"
 	"time"
 )
@@ -74,6 +75,103 @@ func TestRepo_WritActivityMonitor(t *testing.T) {
 	require.Equal(monitor.Data[0].Time.Unix(), readActivity.Data[0].Time.Unix())
 }
 
+func TestRepo_WriteValidationReports(t *testing.T) {
+	database := db.NewMemDB()
+	repo := NewRepo(database)
+
+	addr1, addr2 := common.Address{0x1}, common.Address{0x2}
+	repo.WriteValidationReports(5, map[common.Address]*types.ValidationReport{
+		addr1: {
+			Epoch:               5,
+			Candidate:           true,
+			PrevState:           3,
+			NewState:            4,
+			Approved:            true,
+			ShortPoint:          4.5,
+			ShortQualifiedFlips: 5,
+			ShortScore:          0.9,
+			BadAuthor:           true,
+			BadAuthorReason:     types.WrongWordsBadAuthor,
+			RewardedReports:     2,
+			Reward:              big.NewInt(150),
+		},
+		addr2: {
+			Epoch:        5,
+			Missed:       true,
+			MissedReason: types.NotCandidateMissedReason,
+			Penalty:      big.NewInt(70),
+		},
+	})
+
+	require := require.New(t)
+
+	report := repo.ReadValidationReport(5, addr1)
+	require.NotNil(report)
+	require.True(report.Candidate)
+	require.Equal(uint8(4), report.NewState)
+	require.Equal(float32(4.5), report.ShortPoint)
+	require.Equal(uint32(5), report.ShortQualifiedFlips)
+	require.Equal(float32(0.9), report.ShortScore)
+	require.True(report.BadAuthor)
+	require.Equal(types.WrongWordsBadAuthor, report.BadAuthorReason)
+	require.Equal(uint32(2), report.RewardedReports)
+	require.Equal(big.NewInt(150), report.Reward)
+	require.Nil(report.Penalty)
+
+	report = repo.ReadValidationReport(5, addr2)
+	require.NotNil(report)
+	require.True(report.Missed)
+	require.Equal(types.NotCandidateMissedReason, report.MissedReason)
+	require.Nil(report.Reward)
+	require.Equal(big.NewInt(70), report.Penalty)
+
+	require.Nil(repo.ReadValidationReport(4, addr1))
+	require.Nil(repo.ReadValidationReport(5, common.Address{0x3}))
+}
+
+func TestRepo_OwnInvites(t *testing.T) {
+	database := db.NewMemDB()
+	repo := NewRepo(database)
+	require := require.New(t)
+
+	killTxHash := getRandHash()
+	invite1 := &types.OwnInvite{
+		Inviter:   common.Address{0x1},
+		Receiver:  common.Address{0x2},
+		Key:       []byte{0x3, 0x4},
+		TxHash:    getRandHash(),
+		Epoch:     7,
+		Amount:    big.NewInt(100),
+		Timestamp: 1000,
+	}
+	invite2 := &types.OwnInvite{
+		Inviter:    common.Address{0x1},
+		Receiver:   common.Address{0x5},
+		TxHash:     getRandHash(),
+		Epoch:      8,
+		KillTxHash: &killTxHash,
+		Status:     types.OwnInviteKilled,
+	}
+	repo.WriteOwnInvite(invite1)
+	repo.WriteOwnInvite(invite2)
+
+	require.Equal(invite1, repo.ReadOwnInvite(invite1.Receiver))
+	require.Equal(invite2, repo.ReadOwnInvite(invite2.Receiver))
+	require.Nil(repo.ReadOwnInvite(common.Address{0x6}))
+
+	activationTxHash := getRandHash()
+	activated := common.Address{0x7}
+	invite1.Status = types.OwnInviteActivated
+	invite1.ActivationTxHash = &activationTxHash
+	invite1.Activated = &activated
+	repo.WriteOwnInvite(invite1)
+	invites := repo.GetOwnInvites()
+	require.Len(invites, 2)
+	require.Equal(invite1, invites[0])
+	require.Nil(invites[1].Activated)
+	require.Equal(killTxHash, *invites[1].KillTxHash)
+}
+
 func TestRepo_GetSavedEvents(t *testing.T) {
 	database := db.NewMemDB()
 	repo := NewRepo(database)
@@ -122,3 +220,76 @@ func TestRepo_GetSavedEvents(t *testing.T) {
 	require.Equal(t, "ZZZZZZZZZZZZZZZ ZZZZZZZZZZZZZZZZZZ", events2[2].Event)
 
 }
+
+func TestRepo_IterateTxIndexes(t *testing.T) {
+	database := db.NewMemDB()
+	repo := NewRepo(database)
+	require := require.New(t)
+
+	hashes := []common.Hash{getRandHash(), getRandHash(), getRandHash()}
+	for i, hash := range hashes {
+		repo.WriteTxIndex(hash, &types.TransactionIndex{BlockHash: common.Hash{0x1}, Idx: uint32(i)})
+		repo.WriteReceiptIndex(hash, &types.TxReceiptIndex{ReceiptCid: []byte{0x2}, Idx: uint32(i)})
+	}
+
+	txIndexes := make(map[common.Hash]uint32)
+	repo.IterateTxIndexes(func(txHash common.Hash, index *types.TransactionIndex) bool {
+		txIndexes[txHash] = index.Idx
+		return false
+	})
+	require.Len(txIndexes, len(hashes))
+	for i, hash := range hashes {
+		require.Equal(uint32(i), txIndexes[hash])
+	}
+
+	repo.RemoveTxIndex(nil, hashes[0])
+	repo.RemoveReceiptIndex(nil, hashes[1])
+	require.Nil(repo.ReadTxIndex(hashes[0]))
+	require.NotNil(repo.ReadReceiptIndex(hashes[0]))
+	require.Nil(repo.ReadReceiptIndex(hashes[1]))
+
+	receiptsCount := 0
+	repo.IterateReceiptIndexes(func(txHash common.Hash, index *types.TxReceiptIndex) bool {
+		receiptsCount++
+		return false
+	})
+	require.Equal(2, receiptsCount)
+}
+
+func TestRepo_DeleteBurntCoins(t *testing.T) {
+	database := db.NewMemDB()
+	repo := NewRepo(database)
+	require := require.New(t)
+
+	for height := uint64(1); height <= 3; height++ {
+		repo.SaveBurntCoins(height, getRandHash(), common.Address{byte(height)}, "", big.NewInt(int64(height)))
+	}
+
+	repo.DeleteBurntCoinsAfter(2)
+	require.Len(repo.GetTotalBurntCoins(), 2)
+
+	repo.DeleteBurntCoinsBefore(2)
+	burntCoins := repo.GetTotalBurntCoins()
+	require.Len(burntCoins, 1)
+	require.Equal(common.Address{0x2}, burntCoins[0].Address)
+
+	repo.WritePrunedHorizon(3)
+	require.Equal(uint64(3), repo.ReadPrunedHorizon())
+}
+
+func TestRepo_DeleteSavedTx(t *testing.T) {
+	database := db.NewMemDB()
+	repo := NewRepo(database)
+	require := require.New(t)
+
+	address := common.Address{0x1}
+	tx1 := &types.Transaction{AccountNonce: 1, Amount: big.NewInt(1)}
+	tx2 := &types.Transaction{AccountNonce: 2, Amount: big.NewInt(2)}
+	repo.SaveTx(address, common.Hash{0x1}, 10, big.NewInt(0), tx1)
+	repo.SaveTx(address, common.Hash{0x2}, 20, big.NewInt(0), tx2)
+
+	repo.DeleteSavedTx(address, 20, tx2)
+	txs, _ := repo.GetSavedTxs(address, 10, nil)
+	require.Len(txs, 1)
+	require.Equal(tx1.Hash(), txs[0].Tx.Hash())
+}
@@ -45,4 +45,12 @@ var (
 	consensusVersionKey = []byte("v")
 
 	preliminaryConsVersionKey = []byte("pv")
+
+	validationReportPrefix = []byte("vr")
+
+	ownInvitePrefix = []byte("oi")
+
+	restoredSnapshotKey = []byte("restored-snapshot")
+
+	prunedHorizonKey = []byte("pruned-horizon")
 )
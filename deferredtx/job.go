@@ -10,6 +10,7 @@ import (
 	"github.com/idena-network/idena-go/common/eventbus"
 	"github.com/idena-network/idena-go/core/appstate"
 	"github.com/idena-network/idena-go/core/mempool"
+	"github.com/idena-network/idena-go/crypto"
 	"github.com/idena-network/idena-go/events"
 	"github.com/idena-network/idena-go/keystore"
 	"github.com/idena-network/idena-go/log"
@@ -155,6 +156,47 @@ func (j *Job) AddDeferredTx(from common.Address, to *common.Address, amount *big
 	return err
 }
 
+// ExportTxs returns serialized deferred txs which are not sent yet
+func (j *Job) ExportTxs() []byte {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return j.txs.ToBytes()
+}
+
+// ImportTxs adds deferred txs exported by another node, txs which are already queued are skipped
+func (j *Job) ImportTxs(data []byte) (skipped int, err error) {
+	txs := new(DeferredTxs)
+	if err := txs.FromBytes(data); err != nil {
+		return 0, err
+	}
+	if len(txs.Txs) == 0 {
+		return 0, nil
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	queued := make(map[common.Hash]struct{}, len(j.txs.Txs))
+	for _, tx := range j.txs.Txs {
+		queued[tx.Hash()] = struct{}{}
+	}
+	var imported []*DeferredTx
+	for _, tx := range txs.Txs {
+		hash := tx.Hash()
+		if _, ok := queued[hash]; ok {
+			skipped++
+			continue
+		}
+		queued[hash] = struct{}{}
+		imported = append(imported, tx)
+	}
+	log.Info("Importing deferred txs", "cnt", len(imported), "skipped", skipped)
+	if len(imported) == 0 {
+		return skipped, nil
+	}
+	j.txs.Txs = append(j.txs.Txs, imported...)
+	return skipped, j.persist()
+}
+
 func (j *Job) persist() error {
 	file, err := j.openFile()
 	defer file.Close()
@@ -266,6 +308,14 @@ func (d *DeferredTx) ToProto() *models.ProtoDeferredTxs_ProtoDeferredTx {
 	return protoObj
 }
 
+// Hash identifies the deferred tx by its content, broadcast block isn't hashed since it is moved by retries
+func (d *DeferredTx) Hash() common.Hash {
+	protoObj := d.ToProto()
+	protoObj.Block = 0
+	data, _ := proto.Marshal(protoObj)
+	return crypto.Keccak256Hash(data)
+}
+
 func (d *DeferredTx) FromProto(protoObj *models.ProtoDeferredTxs_ProtoDeferredTx) {
 	d.From.SetBytes(protoObj.From)
 	if protoObj.To != nil {
@@ -108,3 +108,39 @@ func TestJob_tryLater(t *testing.T) {
 	require.Len(t, job.txs.Txs, 0)
 	require.Equal(t, 1, txPool.counter)
 }
+
+func TestJob_ExportImportTxs(t *testing.T) {
+	chain, appState, _, _ := blockchain.NewTestBlockchain(false, nil)
+	defer chain.SecStore().Destroy()
+	os.RemoveAll("test")
+	os.RemoveAll("test2")
+	defer os.RemoveAll("test2")
+
+	vmCreator := func(appState *appstate.AppState, block *types.Header, statsCollector collector.StatsCollector, cfg *config.Config) vm.VM {
+		return &fakeVm{}
+	}
+	job, _ := NewJob(chain.Bus(), "test", appState, chain.Blockchain, &fakeTxPool{}, nil, chain.SecStore(), vmCreator)
+	coinbase := chain.SecStore().GetAddress()
+	require.NoError(t, job.AddDeferredTx(coinbase, &common.Address{0x1}, common.DnaBase, []byte{0x1}, nil, 100))
+
+	newJob, _ := NewJob(chain.Bus(), "test2", appState, chain.Blockchain, &fakeTxPool{}, nil, chain.SecStore(), vmCreator)
+	skipped, err := newJob.ImportTxs(job.ExportTxs())
+	require.NoError(t, err)
+	require.Zero(t, skipped)
+	require.Len(t, newJob.txs.Txs, 1)
+	require.Equal(t, uint64(100), newJob.txs.Txs[0].BroadcastBlock)
+	require.Equal(t, []byte{0x1}, newJob.txs.Txs[0].Payload)
+
+	// already queued txs are skipped even if their broadcast block is moved
+	newJob.txs.Txs[0].BroadcastBlock = 110
+	require.NoError(t, job.AddDeferredTx(coinbase, &common.Address{0x1}, common.DnaBase, []byte{0x2}, nil, 100))
+	skipped, err = newJob.ImportTxs(job.ExportTxs())
+	require.NoError(t, err)
+	require.Equal(t, 1, skipped)
+	require.Len(t, newJob.txs.Txs, 2)
+	require.Equal(t, []byte{0x2}, newJob.txs.Txs[1].Payload)
+
+	// imported txs are persisted
+	restoredJob, _ := NewJob(chain.Bus(), "test2", appState, chain.Blockchain, &fakeTxPool{}, nil, chain.SecStore(), vmCreator)
+	require.Len(t, restoredJob.txs.Txs, 2)
+}
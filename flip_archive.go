@@ -0,0 +1,86 @@
+package main
+
+import (
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"io/ioutil"
+)
+
+var (
+	flipArchiveFileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "Path of the flip archive",
+	}
+	flipArchivePasswordFlag = cli.StringFlag{
+		Name:  "password",
+		Usage: "Password of the flip archive",
+	}
+
+	exportFlipArchiveCommand = cli.Command{
+		Name:   "export-flip-archive",
+		Usage:  "Export own flips, flip keys, epoch records and deferred txs of the stopped node to an encrypted file",
+		Flags:  []cli.Flag{flipArchiveFileFlag, flipArchivePasswordFlag},
+		Action: exportFlipArchive,
+	}
+	importFlipArchiveCommand = cli.Command{
+		Name:   "import-flip-archive",
+		Usage:  "Restore a file created by export-flip-archive or flip_exportArchive into the stopped node",
+		Flags:  []cli.Flag{flipArchiveFileFlag, flipArchivePasswordFlag},
+		Action: importFlipArchive,
+	}
+)
+
+func flipArchiveArgs(context *cli.Context) (fileName, password string, err error) {
+	fileName = context.String(flipArchiveFileFlag.Name)
+	if fileName == "" {
+		return "", "", errors.New("file option is required")
+	}
+	password = context.String(flipArchivePasswordFlag.Name)
+	if password == "" {
+		return "", "", errors.New("password option is required")
+	}
+	return fileName, password, nil
+}
+
+func exportFlipArchive(context *cli.Context) error {
+	fileName, password, err := flipArchiveArgs(context)
+	if err != nil {
+		return err
+	}
+	cfg, err := makeCommandConfig(context)
+	if err != nil {
+		return err
+	}
+	archive, err := node.ExportFlipArchive(cfg, password)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(fileName, archive, 0600); err != nil {
+		return err
+	}
+	log.Info("Flip archive exported", "file", fileName)
+	return nil
+}
+
+func importFlipArchive(context *cli.Context) error {
+	fileName, password, err := flipArchiveArgs(context)
+	if err != nil {
+		return err
+	}
+	cfg, err := makeCommandConfig(context)
+	if err != nil {
+		return err
+	}
+	archive, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	skipped, err := node.ImportFlipArchive(cfg, archive, password)
+	if err != nil {
+		return err
+	}
+	log.Info("Flip archive imported", "file", fileName, "skippedDeferredTxs", skipped)
+	return nil
+}
@@ -83,6 +83,9 @@ type Proxy interface {
 	LoadTo(key []byte, to io.Writer, ctx context.Context, onLoading func(size, loaded int64)) error
 	Pin(key []byte) error
 	Unpin(key []byte) error
+	// Provide announces the node as a provider of the locally stored content to the DHT peers,
+	// an error is returned if the provider record can't be put to any peer
+	Provide(key []byte) error
 	Cid(data []byte) (cid.Cid, error)
 	Port() int
 	PeerId() string
@@ -92,6 +95,7 @@ type Proxy interface {
 	GetWithSizeLimit(key []byte, dataType DataType, size int64) ([]byte, error)
 	PubSub() *pubsub.PubSub
 	GC() (ctx context.Context, cancel context.CancelFunc)
+	RepoSize() (uint64, error)
 }
 
 type ipfsProxy struct {
@@ -158,6 +162,58 @@ func NewIpfsProxy(cfg *config.IpfsConfig, bus eventbus.Bus) (Proxy, error) {
 	return p, nil
 }
 
+// NewOfflineIpfsProxy opens the existing ipfs repo without connecting to the network, so only locally stored data is
+// available. The node is stopped by the returned function.
+func NewOfflineIpfsProxy(cfg *config.IpfsConfig) (Proxy, func(), error) {
+	if err := loadPlugins(cfg); err != nil {
+		return nil, nil, err
+	}
+	dataDir, _ := filepath.Abs(cfg.DataDir)
+	if !fsrepo.IsInitialized(dataDir) {
+		return nil, nil, errors.Errorf("ipfs repo is not found in %v", dataDir)
+	}
+	repo, err := fsrepo.Open(dataDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	node, err := core.NewNode(ctx, &core.BuildCfg{
+		Repo:      repo,
+		Permanent: true,
+		Online:    false,
+	})
+	if err != nil {
+		cancelCtx()
+		return nil, nil, err
+	}
+	nilNode, err := core.NewNode(context.Background(), &core.BuildCfg{
+		NilRepo: true,
+	})
+	if err != nil {
+		node.Close()
+		cancelCtx()
+		return nil, nil, err
+	}
+	p := &ipfsProxy{
+		node:                 node,
+		log:                  log.New(),
+		cfg:                  cfg,
+		cidCache:             cache.New(2*time.Minute, 5*time.Minute),
+		nodeCtx:              ctx,
+		nodeCtxCancel:        cancelCtx,
+		lastPeersUpdatedTime: time.Now().UTC(),
+		nilNode:              nilNode,
+		bus:                  eventbus.New(),
+	}
+	stop := func() {
+		nilNode.Close()
+		node.Close()
+		cancelCtx()
+	}
+	return p, stop, nil
+}
+
 func createNode(cfg *config.IpfsConfig, eventBus eventbus.Bus) (*core.IpfsNode, context.Context, context.CancelFunc, error) {
 	dataDir, _ := filepath.Abs(cfg.DataDir)
 
@@ -198,6 +254,10 @@ func (p *ipfsProxy) gc(ctx context.Context, cancel context.CancelFunc) {
 	cancel()
 }
 
+func (p *ipfsProxy) RepoSize() (uint64, error) {
+	return p.node.Repo.GetStorageUsage(p.nodeCtx)
+}
+
 func (p *ipfsProxy) changePort() {
 	p.rwLock.Lock()
 	defer p.rwLock.Unlock()
@@ -536,6 +596,31 @@ func (p *ipfsProxy) Unpin(key []byte) error {
 	return err
 }
 
+func (p *ipfsProxy) Provide(key []byte) error {
+	p.rwLock.RLock()
+	defer p.rwLock.RUnlock()
+	api, _ := coreapi.NewCoreAPI(p.node)
+
+	c, err := cid.Cast(key)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	err = api.Dht().Provide(ctx, path.IpfsPath(c))
+
+	select {
+	case <-ctx.Done():
+		err = errors.Errorf("timeout while providing data to ipfs peers, key: %v", c.String())
+	default:
+		break
+	}
+
+	return err
+}
+
 func (p *ipfsProxy) Port() int {
 	return p.cfg.IpfsPort
 }
@@ -774,6 +859,10 @@ func (i *memoryIpfs) Unpin(key []byte) error {
 	return nil
 }
 
+func (i *memoryIpfs) Provide(key []byte) error {
+	return nil
+}
+
 func (i *memoryIpfs) Add(data []byte, pin bool) (cid.Cid, error) {
 	cid, _ := i.Cid(data)
 	i.values[cid] = data
@@ -849,3 +938,11 @@ func configAt(repoPath string) (*ipfsConf.Config, error) {
 func (i *memoryIpfs) GC() (ctx context.Context, cancel context.CancelFunc) {
 	panic("implement me")
 }
+
+func (i *memoryIpfs) RepoSize() (uint64, error) {
+	var size uint64
+	for _, v := range i.values {
+		size += uint64(len(v))
+	}
+	return size, nil
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/urfave/cli"
 	"io/ioutil"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
+	"syscall"
 )
 
 const (
@@ -44,12 +46,17 @@ func main() {
 		config.MaxNetworkDelayFlag,
 		config.FastSyncFlag,
 		config.ForceFullSyncFlag,
+		config.LightModeFlag,
 		config.ProfileFlag,
 		config.IpfsPortStaticFlag,
 		config.ApiKeyFlag,
 		config.LogFileSizeFlag,
 		config.LogColoring,
 		config.AutoOnline,
+		config.MetricsFlag,
+		config.MetricsAddrFlag,
+		config.MetricsPortFlag,
+		config.DbBackendFlag,
 	}
 
 	app.Action = func(context *cli.Context) error {
@@ -65,23 +72,7 @@ func main() {
 
 		log.Root().SetHandler(handler)
 
-		cfg, err := config.MakeConfig(context, func(cfg *config.Config) {
-			db, err := node.OpenDatabase(cfg.DataDir, "idenachain", 16, 16, false)
-			if err != nil {
-				log.Error("Cannot transform consensus config", "err", err)
-				return
-			}
-			defer db.Close()
-			repo := database.NewRepo(db)
-			consVersion := repo.ReadConsensusVersion()
-			if consVersion <= uint32(cfg.Consensus.Version) {
-				return
-			}
-			for v := cfg.Consensus.Version + 1; v <= config.ConsensusVerson(consVersion); v++ {
-				config.ApplyConsensusVersion(v, cfg.Consensus)
-			}
-			log.Info("Consensus config transformed to", "ver", consVersion)
-		})
+		cfg, err := config.MakeConfig(context, transformConsensusConfig)
 
 		if err != nil {
 			return err
@@ -107,16 +98,54 @@ func main() {
 			return err
 		}
 		n.Start()
+		go func() {
+			sigs := make(chan os.Signal, 1)
+			signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+			sig := <-sigs
+			log.Info("Idena node is stopping", "signal", sig)
+			n.Stop()
+		}()
 		n.WaitForStop()
 		return nil
 	}
 
+	app.Commands = []cli.Command{
+		exportBlocksCommand,
+		importBlocksCommand,
+		createSnapshotCommand,
+		restoreSnapshotCommand,
+		pruneCommand,
+		rewindCommand,
+		contractDumpCommand,
+		exportFlipArchiveCommand,
+		importFlipArchiveCommand,
+	}
+
 	err := app.Run(os.Args)
 	if err != nil {
 		log.Error(err.Error())
 	}
 }
 
+// transformConsensusConfig applies consensus upgrades which the local chain has already switched to
+func transformConsensusConfig(cfg *config.Config) {
+	db, err := node.OpenDatabase(cfg.DataDir, "idenachain", cfg.Database, false)
+	if err != nil {
+		log.Error("Cannot transform consensus config", "err", err)
+		return
+	}
+	defer db.Close()
+	repo := database.NewRepo(db)
+	consVersion := repo.ReadConsensusVersion()
+	if consVersion <= uint32(cfg.Consensus.Version) {
+		return
+	}
+	for v := cfg.Consensus.Version + 1; v <= config.ConsensusVerson(consVersion); v++ {
+		config.ApplyConsensusVersion(v, cfg.Consensus)
+	}
+	log.Info("Consensus config transformed to", "ver", consVersion)
+}
+
 func getLogFileHandler(cfg *config.Config, logFileSize int) (log.Handler, error) {
 	path := filepath.Join(cfg.DataDir, LogDir)
 	if _, err := os.Stat(path); os.IsNotExist(err) {
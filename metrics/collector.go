@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/consensus"
+	"github.com/idena-network/idena-go/core/mempool"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/protocol"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+)
+
+const namespace = "idena"
+
+var (
+	headHeightDesc = prometheus.NewDesc(namespace+"_head_height", "Height of the current chain head", nil, nil)
+	syncingDesc    = prometheus.NewDesc(namespace+"_sync_in_progress", "Whether the node is syncing blockchain", nil, nil)
+	syncHeadDesc   = prometheus.NewDesc(namespace+"_sync_current_height", "Height of the synced (including preliminary) head", nil, nil)
+	syncTopDesc    = prometheus.NewDesc(namespace+"_sync_highest_height", "Highest known height during syncing", nil, nil)
+	peersDesc      = prometheus.NewDesc(namespace+"_peers", "Number of connected peers per shard", []string{"shard"}, nil)
+	mempoolDesc    = prometheus.NewDesc(namespace+"_mempool_txs", "Number of transactions in mempool", []string{"state"}, nil)
+	flipKeysDesc   = prometheus.NewDesc(namespace+"_flip_keys_pool_size", "Number of entries in flip keys pool", []string{"type"}, nil)
+	ipfsRepoDesc   = prometheus.NewDesc(namespace+"_ipfs_repo_size_bytes", "Size of IPFS repository", nil, nil)
+	eventsDesc     = prometheus.NewDesc(namespace+"_eventbus_pending_events", "Number of published events which handlers are still being executed", []string{"event"}, nil)
+
+	roundDesc         = prometheus.NewDesc(namespace+"_consensus_last_round", "Last completed consensus round", nil, nil)
+	roundDurationDesc = prometheus.NewDesc(namespace+"_consensus_last_round_duration_seconds", "Duration of the last completed consensus round", nil, nil)
+	phaseDurationDesc = prometheus.NewDesc(namespace+"_consensus_last_round_phase_duration_seconds", "Duration of phases of the last completed consensus round", []string{"phase"}, nil)
+	roundProofsDesc   = prometheus.NewDesc(namespace+"_consensus_last_round_proposer_proofs", "Number of proposer proofs received in the last completed consensus round", nil, nil)
+	roundResultsDesc  = prometheus.NewDesc(namespace+"_consensus_stored_rounds", "Number of stored recent consensus rounds by result", []string{"result"}, nil)
+)
+
+// nodeCollector reads current values from node components on every scrape
+type nodeCollector struct {
+	chain      *blockchain.Blockchain
+	downloader *protocol.Downloader
+	pm         *protocol.IdenaGossipHandler
+	txpool     *mempool.TxPool
+	keysPool   *mempool.KeysPool
+	engine     *consensus.Engine
+	ipfsProxy  ipfs.Proxy
+	bus        eventbus.Bus
+}
+
+func (c *nodeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- headHeightDesc
+	ch <- syncingDesc
+	ch <- syncHeadDesc
+	ch <- syncTopDesc
+	ch <- peersDesc
+	ch <- mempoolDesc
+	ch <- flipKeysDesc
+	ch <- ipfsRepoDesc
+	ch <- eventsDesc
+	ch <- roundDesc
+	ch <- roundDurationDesc
+	ch <- phaseDurationDesc
+	ch <- roundProofsDesc
+	ch <- roundResultsDesc
+}
+
+func (c *nodeCollector) Collect(ch chan<- prometheus.Metric) {
+	if head := c.chain.Head; head != nil {
+		ch <- prometheus.MustNewConstMetric(headHeightDesc, prometheus.GaugeValue, float64(head.Height()))
+	}
+
+	var syncing float64
+	if c.downloader.IsSyncing() {
+		syncing = 1
+	}
+	ch <- prometheus.MustNewConstMetric(syncingDesc, prometheus.GaugeValue, syncing)
+	syncHead, syncTop := c.downloader.SyncProgress()
+	ch <- prometheus.MustNewConstMetric(syncHeadDesc, prometheus.GaugeValue, float64(syncHead))
+	ch <- prometheus.MustNewConstMetric(syncTopDesc, prometheus.GaugeValue, float64(syncTop))
+
+	for shardId, cnt := range c.pm.PeersCountByShard() {
+		ch <- prometheus.MustNewConstMetric(peersDesc, prometheus.GaugeValue, float64(cnt), strconv.Itoa(int(shardId)))
+	}
+
+	executable, pending := c.txpool.Sizes()
+	ch <- prometheus.MustNewConstMetric(mempoolDesc, prometheus.GaugeValue, float64(executable), "executable")
+	ch <- prometheus.MustNewConstMetric(mempoolDesc, prometheus.GaugeValue, float64(pending), "pending")
+
+	publicKeys, privateKeysPackages := c.keysPool.Sizes()
+	ch <- prometheus.MustNewConstMetric(flipKeysDesc, prometheus.GaugeValue, float64(publicKeys), "public")
+	ch <- prometheus.MustNewConstMetric(flipKeysDesc, prometheus.GaugeValue, float64(privateKeysPackages), "privatePackage")
+
+	if size, err := c.ipfsProxy.RepoSize(); err == nil {
+		ch <- prometheus.MustNewConstMetric(ipfsRepoDesc, prometheus.GaugeValue, float64(size))
+	}
+
+	if stats, ok := c.bus.(eventbus.BusStats); ok {
+		for eventId, cnt := range stats.PendingEvents() {
+			ch <- prometheus.MustNewConstMetric(eventsDesc, prometheus.GaugeValue, float64(cnt), string(eventId))
+		}
+	}
+
+	c.collectConsensus(ch)
+}
+
+func (c *nodeCollector) collectConsensus(ch chan<- prometheus.Metric) {
+	rounds := c.engine.GetRounds(0)
+	results := map[string]int{
+		consensus.RoundResultFinal:     0,
+		consensus.RoundResultTentative: 0,
+		consensus.RoundResultEmpty:     0,
+		consensus.RoundResultFailed:    0,
+	}
+	for _, round := range rounds {
+		results[round.Result]++
+	}
+	for result, cnt := range results {
+		ch <- prometheus.MustNewConstMetric(roundResultsDesc, prometheus.GaugeValue, float64(cnt), result)
+	}
+	if len(rounds) == 0 {
+		return
+	}
+	last := rounds[0]
+	ch <- prometheus.MustNewConstMetric(roundDesc, prometheus.GaugeValue, float64(last.Round))
+	ch <- prometheus.MustNewConstMetric(roundDurationDesc, prometheus.GaugeValue, last.Finish.Sub(last.Start).Seconds())
+	ch <- prometheus.MustNewConstMetric(roundProofsDesc, prometheus.GaugeValue, float64(last.ProposerProofs))
+	for _, phase := range last.Phases {
+		ch <- prometheus.MustNewConstMetric(phaseDurationDesc, prometheus.GaugeValue, phase.Duration.Seconds(), phase.Name)
+	}
+}
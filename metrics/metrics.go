@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/consensus"
+	"github.com/idena-network/idena-go/core/mempool"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/protocol"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net"
+	"net/http"
+	"time"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// Server exports node metrics in the Prometheus text exposition format
+type Server struct {
+	cfg         *config.MetricsConfig
+	log         log.Logger
+	registry    *prometheus.Registry
+	rpcDuration *prometheus.HistogramVec
+	rpcErrors   *prometheus.CounterVec
+	listener    net.Listener
+	httpServer  *http.Server
+}
+
+func NewServer(cfg *config.MetricsConfig, chain *blockchain.Blockchain, downloader *protocol.Downloader, pm *protocol.IdenaGossipHandler,
+	txpool *mempool.TxPool, keysPool *mempool.KeysPool, engine *consensus.Engine, ipfsProxy ipfs.Proxy, bus eventbus.Bus) *Server {
+	registry := prometheus.NewRegistry()
+	rpcDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_request_duration_seconds",
+		Help:      "Duration of RPC method calls",
+		Buckets:   []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10},
+	}, []string{"method"})
+	rpcErrors := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_request_errors_total",
+		Help:      "Number of RPC method calls which returned an error",
+	}, []string{"method"})
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		rpcDuration,
+		rpcErrors,
+		&nodeCollector{
+			chain:      chain,
+			downloader: downloader,
+			pm:         pm,
+			txpool:     txpool,
+			keysPool:   keysPool,
+			engine:     engine,
+			ipfsProxy:  ipfsProxy,
+			bus:        bus,
+		},
+	)
+	return &Server{
+		cfg:         cfg,
+		log:         log.New(),
+		registry:    registry,
+		rpcDuration: rpcDuration,
+		rpcErrors:   rpcErrors,
+	}
+}
+
+// Start opens the metrics HTTP endpoint if it is enabled in config
+func (s *Server) Start() error {
+	endpoint := s.cfg.HTTPEndpoint()
+	if endpoint == "" {
+		return nil
+	}
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{
+		ErrorLog: s,
+	}))
+	s.listener = listener
+	s.httpServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go s.httpServer.Serve(listener)
+	s.log.Info("Metrics HTTP endpoint opened", "url", fmt.Sprintf("http://%s/metrics", endpoint))
+	return nil
+}
+
+// Stop gracefully shuts the metrics HTTP endpoint down, scrapes in progress are given a few seconds to complete
+func (s *Server) Stop() {
+	if s.httpServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.log.Warn("Metrics HTTP endpoint is not shut down gracefully", "err", err)
+	}
+	s.httpServer = nil
+	s.listener = nil
+	s.log.Info("Metrics HTTP endpoint closed")
+}
+
+// ObserveRpcCall is supposed to be used as rpc.CallObserver
+func (s *Server) ObserveRpcCall(method string, duration time.Duration, failed bool) {
+	s.rpcDuration.WithLabelValues(method).Observe(duration.Seconds())
+	if failed {
+		s.rpcErrors.WithLabelValues(method).Inc()
+	}
+}
+
+// Println implements promhttp.Logger
+func (s *Server) Println(v ...interface{}) {
+	s.log.Warn("Failed to gather metrics", "err", fmt.Sprint(v...))
+}
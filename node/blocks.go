@@ -0,0 +1,303 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/stats/collector"
+	"github.com/mholt/archiver/v3"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	blocksManifestFile = "manifest.json"
+	blockFileExt       = ".block"
+	certFileExt        = ".cert"
+)
+
+// blocksManifest is the first file of the blocks archive
+type blocksManifest struct {
+	Network uint32 `json:"network"`
+	From    uint64 `json:"from"`
+	To      uint64 `json:"to"`
+}
+
+// ExportBlocks writes canonical blocks of the height range with their bodies and certificates to the tar.gz archive,
+// bodies are read from the local IPFS repo, so the node has to be stopped
+func ExportBlocks(cfg *config.Config, from, to uint64, out io.Writer) (exported uint64, err error) {
+	db, err := OpenDatabase(cfg.DataDir, "idenachain", cfg.Database, false)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+	ipfsProxy, stop, err := ipfs.NewOfflineIpfsProxy(cfg.IpfsConf)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to open ipfs repo")
+	}
+	defer stop()
+	return exportBlocks(database.NewRepo(db), ipfsProxy, cfg.Network, from, to, out)
+}
+
+func exportBlocks(repo *database.Repo, ipfsProxy ipfs.Proxy, network uint32, from, to uint64, out io.Writer) (exported uint64, err error) {
+	head := repo.ReadHead()
+	if head == nil {
+		return 0, errors.New("chain is empty")
+	}
+	if from == 0 {
+		from = 1
+	}
+	if to == 0 || to > head.Height() {
+		to = head.Height()
+	}
+	if from > to {
+		return 0, errors.Errorf("invalid height range %v-%v, head is %v", from, to, head.Height())
+	}
+
+	tgz := archiver.NewTarGz()
+	if err := tgz.Create(out); err != nil {
+		return 0, err
+	}
+	defer tgz.Close()
+
+	manifest, _ := json.Marshal(&blocksManifest{Network: network, From: from, To: to})
+	if err := writeArchiveFile(tgz, blocksManifestFile, manifest); err != nil {
+		return 0, err
+	}
+	logTicker := time.NewTicker(time.Second * 10)
+	defer logTicker.Stop()
+	for height := from; height <= to; height++ {
+		hash := repo.ReadCanonicalHash(height)
+		header := repo.ReadBlockHeader(hash)
+		if header == nil {
+			return exported, errors.Errorf("block %v is not found", height)
+		}
+		block := &types.Block{Header: header, Body: &types.Body{}}
+		if header.EmptyBlockHeader == nil {
+			data, err := ipfsProxy.Get(header.ProposedHeader.IpfsHash, ipfs.Block)
+			if err != nil {
+				return exported, errors.Wrapf(err, "body of block %v is not available in the local ipfs repo", height)
+			}
+			block.Body.FromBytes(data)
+		}
+		if cert := repo.ReadCertificate(hash); cert != nil && !cert.Empty() {
+			data, err := cert.ToBytes()
+			if err != nil {
+				return exported, err
+			}
+			if err := writeArchiveFile(tgz, strconv.FormatUint(height, 10)+certFileExt, data); err != nil {
+				return exported, err
+			}
+		}
+		data, err := block.ToBytes()
+		if err != nil {
+			return exported, err
+		}
+		if err := writeArchiveFile(tgz, strconv.FormatUint(height, 10)+blockFileExt, data); err != nil {
+			return exported, err
+		}
+		exported++
+		select {
+		case <-logTicker.C:
+			log.Info("Exporting blocks", "height", height, "to", to)
+		default:
+		}
+	}
+	return exported, nil
+}
+
+// ImportBlocks applies blocks of the archive created by ExportBlocks on top of the local chain with full validation,
+// blocks which the local chain already has are skipped. Like full sync, blocks are applied only after a certified
+// block which follows them is validated, so blocks after the last certificate of the archive are not imported.
+func ImportBlocks(cfg *config.Config, in io.Reader) (imported uint64, err error) {
+	n, err := openOfflineNode(cfg)
+	if err != nil {
+		return 0, err
+	}
+	defer n.close()
+
+	importer := &blocksImporter{
+		chain:          n.chain,
+		appState:       n.appState,
+		repo:           n.repo,
+		statsCollector: n.statsCollector,
+		network:        cfg.Network,
+	}
+	err = importer.importArchive(in)
+	return importer.imported, err
+}
+
+type blocksImporter struct {
+	chain          *blockchain.Blockchain
+	appState       *appstate.AppState
+	repo           *database.Repo
+	statsCollector collector.StatsCollector
+	network        uint32
+
+	manifest *blocksManifest
+	deferred []*types.BlockBundle
+	imported uint64
+	skipped  uint64
+}
+
+func (bi *blocksImporter) importArchive(in io.Reader) error {
+	tgz := archiver.NewTarGz()
+	if err := tgz.Open(in, 0); err != nil {
+		return err
+	}
+	defer tgz.Close()
+	return bi.read(tgz)
+}
+
+func (bi *blocksImporter) read(tgz *archiver.TarGz) error {
+	var cert *types.BlockCert
+	for {
+		file, err := tgz.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+		name := file.Name()
+		switch {
+		case name == blocksManifestFile:
+			bi.manifest = new(blocksManifest)
+			if err := json.Unmarshal(data, bi.manifest); err != nil {
+				return errors.Wrap(err, "invalid manifest")
+			}
+			if bi.manifest.Network != bi.network {
+				return errors.Errorf("blocks of network %v cannot be imported into network %v", bi.manifest.Network, bi.network)
+			}
+			log.Info("Importing blocks", "from", bi.manifest.From, "to", bi.manifest.To, "head", bi.chain.Head.Height())
+		case bi.manifest == nil:
+			return errors.New("manifest is missing")
+		case strings.HasSuffix(name, certFileExt):
+			cert = new(types.BlockCert)
+			if err := cert.FromBytes(data); err != nil {
+				return errors.Wrapf(err, "invalid certificate %v", name)
+			}
+		case strings.HasSuffix(name, blockFileExt):
+			block := new(types.Block)
+			if err := block.FromBytes(data); err != nil || block.Header == nil {
+				return errors.Errorf("invalid block %v", name)
+			}
+			if block.Body == nil {
+				block.Body = &types.Body{}
+			}
+			if err := bi.addBlock(block, cert); err != nil {
+				return err
+			}
+			cert = nil
+		default:
+			return errors.Errorf("unexpected file %v", name)
+		}
+	}
+	if len(bi.deferred) > 0 {
+		log.Warn("Blocks after the last certificate are not imported", "from", bi.deferred[0].Block.Height(),
+			"to", bi.deferred[len(bi.deferred)-1].Block.Height())
+	}
+	log.Info("Blocks import completed", "imported", bi.imported, "skipped", bi.skipped, "head", bi.chain.Head.Height())
+	return nil
+}
+
+func (bi *blocksImporter) addBlock(block *types.Block, cert *types.BlockCert) error {
+	height := block.Height()
+	if height <= bi.chain.Head.Height() {
+		if bi.repo.ReadCanonicalHash(height) != block.Hash() {
+			return errors.Errorf("block %v differs from the local chain", height)
+		}
+		bi.skipped++
+		return nil
+	}
+	prevBlock := bi.chain.Head
+	if len(bi.deferred) > 0 {
+		prevBlock = bi.deferred[len(bi.deferred)-1].Block.Header
+	}
+	if err := bi.chain.ValidateCheckpoint(block.Header); err != nil {
+		return errors.Wrapf(err, "block %v", height)
+	}
+	if err := bi.chain.ValidateHeader(block.Header, prevBlock); err != nil {
+		return errors.Wrapf(err, "block %v has invalid header", height)
+	}
+	if block.Header.Flags().HasFlag(types.IdentityUpdate|types.Snapshot|types.NewGenesis) ||
+		block.Header.ProposedHeader != nil && block.Header.ProposedHeader.Upgrade > 0 {
+		if cert == nil {
+			return errors.Errorf("certificate of block %v is missing", height)
+		}
+	}
+	bi.deferred = append(bi.deferred, &types.BlockBundle{Block: block, Cert: cert})
+	if cert == nil {
+		return nil
+	}
+	if err := bi.chain.ValidateBlockCert(prevBlock, block.Header, cert, bi.appState.ValidatorsCache, nil); err != nil {
+		return errors.Wrapf(err, "block %v has invalid certificate", height)
+	}
+	return bi.applyDeferred()
+}
+
+func (bi *blocksImporter) applyDeferred() error {
+	defer func() {
+		bi.deferred = nil
+	}()
+	checkState, err := bi.appState.ForCheckWithOverwrite(bi.chain.Head.Height())
+	if err != nil {
+		return err
+	}
+	for _, b := range bi.deferred {
+		if err := bi.chain.AddBlock(b.Block, checkState, bi.statsCollector); err != nil {
+			if resetErr := bi.appState.ResetTo(bi.chain.Head.Height()); resetErr != nil {
+				return resetErr
+			}
+			return errors.Wrapf(err, "block %v is invalid", b.Block.Height())
+		}
+		if b.Cert != nil {
+			bi.chain.WriteCertificate(b.Block.Hash(), b.Cert, true)
+		}
+		if err := checkState.FinalizePrecommit(b.Block); err != nil {
+			return err
+		}
+		bi.imported++
+	}
+	log.Info(fmt.Sprintf("Blocks imported up to %v", bi.chain.Head.Height()))
+	return nil
+}
+
+func writeArchiveFile(tgz *archiver.TarGz, name string, data []byte) error {
+	return tgz.Write(archiver.File{
+		FileInfo: archiver.FileInfo{
+			CustomName: name,
+			FileInfo:   &archiveFileInfo{name: name, size: int64(len(data))},
+		},
+		ReadCloser: ioutil.NopCloser(bytes.NewReader(data)),
+	})
+}
+
+type archiveFileInfo struct {
+	name string
+	size int64
+}
+
+func (fi *archiveFileInfo) Name() string       { return fi.name }
+func (fi *archiveFileInfo) Size() int64        { return fi.size }
+func (fi *archiveFileInfo) Mode() os.FileMode  { return 0644 }
+func (fi *archiveFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi *archiveFileInfo) IsDir() bool        { return false }
+func (fi *archiveFileInfo) Sys() interface{}   { return nil }
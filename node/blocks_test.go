@@ -0,0 +1,63 @@
+package node
+
+import (
+	"bytes"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/stats/collector"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExportImportBlocks(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	source, _ := blockchain.NewCustomTestBlockchain(0, 0, key)
+	target, targetState := source.Copy()
+	source.GenerateBlocks(10, 1).GenerateEmptyBlocks(5).GenerateBlocks(5, 1)
+
+	// bodies are exported from the ipfs repo of the node
+	ipfsProxy := ipfs.NewMemoryIpfsProxy()
+	for height := uint64(1); height <= source.Head.Height(); height++ {
+		block := source.GetBlock(source.Repo().ReadCanonicalHash(height))
+		require.NotNil(t, block)
+		if block.Header.EmptyBlockHeader == nil {
+			_, err := ipfsProxy.Add(block.Body.ToBytes(), true)
+			require.NoError(t, err)
+		}
+	}
+
+	archive := new(bytes.Buffer)
+	exported, err := exportBlocks(source.Repo(), ipfsProxy, source.Config().Network, 0, 0, archive)
+	require.NoError(t, err)
+	require.Equal(t, source.Head.Height(), exported)
+
+	importer := &blocksImporter{
+		chain:          target.Blockchain,
+		appState:       targetState,
+		repo:           target.Repo(),
+		statsCollector: collector.NewStatsCollector(),
+		network:        target.Config().Network,
+	}
+	require.NoError(t, importer.importArchive(bytes.NewReader(archive.Bytes())))
+	// genesis block is the first one of the archive, the fresh chain already has it
+	require.Equal(t, exported-1, importer.imported)
+	require.Equal(t, uint64(1), importer.skipped)
+	require.Equal(t, source.Head.Hash(), target.Head.Hash())
+	require.Equal(t, source.Head.Root(), targetState.State.Root())
+	for height := uint64(1); height <= source.Head.Height(); height++ {
+		hash := source.Repo().ReadCanonicalHash(height)
+		require.Equal(t, hash, target.Repo().ReadCanonicalHash(height))
+		require.Equal(t, source.Repo().ReadCertificate(hash), target.Repo().ReadCertificate(hash))
+	}
+
+	// blocks which the chain already has are skipped
+	importer.imported, importer.skipped = 0, 0
+	require.NoError(t, importer.importArchive(bytes.NewReader(archive.Bytes())))
+	require.Zero(t, importer.imported)
+	require.Equal(t, exported, importer.skipped)
+
+	// archive of another network is rejected
+	importer.network++
+	require.Error(t, importer.importArchive(bytes.NewReader(archive.Bytes())))
+}
@@ -0,0 +1,38 @@
+package node
+
+import (
+	"github.com/idena-network/idena-go/api"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/database"
+	"github.com/pkg/errors"
+)
+
+// DumpContract decodes the storage of the contract at the height of the stopped node, 0 height means the head
+func DumpContract(cfg *config.Config, contract common.Address, height uint64) (*api.ContractDump, error) {
+	db, err := OpenDatabase(cfg.DataDir, "idenachain", cfg.Database, false)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	if height == 0 {
+		head := database.NewRepo(db).ReadHead()
+		if head == nil {
+			return nil, errors.New("chain is empty")
+		}
+		height = head.Height()
+	}
+	stateDb, err := state.NewLazy(db)
+	if err != nil {
+		return nil, err
+	}
+	if !stateDb.HasVersion(height) {
+		return nil, errors.Errorf("state of height %v is not saved, the node keeps states of the last %v blocks",
+			height, state.MaxSavedStatesCount)
+	}
+	if err := stateDb.Load(height); err != nil {
+		return nil, errors.Wrapf(err, "cannot load state of height %v", height)
+	}
+	return api.DumpContract(stateDb, contract)
+}
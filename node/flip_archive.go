@@ -0,0 +1,29 @@
+package node
+
+import (
+	"github.com/idena-network/idena-go/config"
+)
+
+// ExportFlipArchive builds the encrypted flip archive of the stopped node, it matches the archive of flip_exportArchive
+func ExportFlipArchive(cfg *config.Config, password string) ([]byte, error) {
+	n, err := openOfflineNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer n.close()
+	return n.flipper.ExportArchive(n.deferredTxs.ExportTxs(), password)
+}
+
+// ImportFlipArchive restores the flip archive into the stopped node, deferred txs which are already queued are skipped
+func ImportFlipArchive(cfg *config.Config, archive []byte, password string) (skippedDeferredTxs int, err error) {
+	n, err := openOfflineNode(cfg)
+	if err != nil {
+		return 0, err
+	}
+	defer n.close()
+	deferredTxs, err := n.flipper.ImportArchive(archive, password)
+	if err != nil {
+		return 0, err
+	}
+	return n.deferredTxs.ImportTxs(deferredTxs)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/idena-network/idena-go/ipfs"
 	"github.com/idena-network/idena-go/keystore"
 	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/metrics"
 	"github.com/idena-network/idena-go/pengings"
 	"github.com/idena-network/idena-go/protocol"
 	"github.com/idena-network/idena-go/rpc"
@@ -73,6 +74,8 @@ type Node struct {
 	subManager      *subscriptions.Manager
 	upgrader        *upgrade.Upgrader
 	nodeState       *state2.NodeState
+	metricsServer   *metrics.Server
+	stopOnce        sync.Once
 }
 
 type NodeCtx struct {
@@ -153,7 +156,7 @@ func NewNodeWithInjections(config *config.Config, bus eventbus.Bus, statsCollect
 	}
 
 	bus.Publish(&events.DatabaseInitEvent{})
-	db, err := OpenDatabase(config.DataDir, "idenachain", 16, 16, true)
+	db, err := OpenDatabase(config.DataDir, "idenachain", config.Database, true)
 	bus.Publish(&events.DatabaseInitCompletedEvent{})
 
 	if err != nil {
@@ -216,6 +219,11 @@ func NewNodeWithInjections(config *config.Config, bus eventbus.Bus, statsCollect
 		return nil, err
 	}
 
+	var metricsServer *metrics.Server
+	if config.Metrics != nil && config.Metrics.Enabled {
+		metricsServer = metrics.NewServer(config.Metrics, chain, downloader, pm, txpool, flipKeyPool, consensusEngine, ipfsProxy, bus)
+	}
+
 	node := &Node{
 		config:          config,
 		blockchain:      chain,
@@ -244,6 +252,8 @@ func NewNodeWithInjections(config *config.Config, bus eventbus.Bus, statsCollect
 		httpListener:    httpListener,
 		httpHandler:     httpHandler,
 		httpServer:      httpServer,
+		metricsServer:   metricsServer,
+		stop:            make(chan struct{}),
 	}
 	return &NodeCtx{
 		Node:            node,
@@ -317,6 +327,15 @@ func (node *Node) StartWithHeight(height uint64) {
 	if err := node.startRPC(); err != nil {
 		node.log.Error("Cannot start RPC endpoint", "error", err.Error())
 	}
+
+	if node.metricsServer != nil {
+		if node.httpHandler != nil {
+			node.httpHandler.SetCallObserver(node.metricsServer.ObserveRpcCall)
+		}
+		if err := node.metricsServer.Start(); err != nil {
+			node.log.Error("Cannot start metrics endpoint", "error", err.Error())
+		}
+	}
 }
 
 func (node *Node) WaitForStop() {
@@ -324,6 +343,17 @@ func (node *Node) WaitForStop() {
 	node.secStore.Destroy()
 }
 
+// Stop closes the endpoints of the node and releases WaitForStop
+func (node *Node) Stop() {
+	node.stopOnce.Do(func() {
+		if node.metricsServer != nil {
+			node.metricsServer.Stop()
+		}
+		node.stopHTTP()
+		close(node.stop)
+	})
+}
+
 func startInitialRPC(nodeConfig *config.Config, nodeState *state2.NodeState) (net.Listener, *rpc.Server, *http.Server, error) {
 	apis := initialApis(nodeState)
 	listener, handler, httpServer, err := startInitialHTTP(nodeConfig.RPC.HTTPEndpoint(), apis, nodeConfig.RPC.HTTPModules, nodeConfig.RPC.HTTPCors, nodeConfig.RPC.HTTPVirtualHosts, nodeConfig.RPC.HTTPTimeouts, nodeConfig.RPC.APIKey)
@@ -413,11 +443,26 @@ func (node *Node) stopHTTP() {
 	}
 }
 
-func OpenDatabase(datadir string, name string, cache int, handles int, compact bool) (db.DB, error) {
+// OpenDatabase opens the chain database with the configured backend, databases of backends other than goleveldb
+// are stored under the name suffixed with the backend, so the datadir can keep several of them during migration
+func OpenDatabase(datadir string, name string, cfg *config.DatabaseConfig, compact bool) (db.DB, error) {
+	if cfg == nil {
+		cfg = config.GetDefaultDatabaseConfig()
+	}
+	if err := checkDatabaseBackend(datadir, name, cfg.Backend); err != nil {
+		return nil, err
+	}
+	if cfg.Backend != config.GoLevelDbBackend {
+		res, err := db.NewDB(DatabaseName(name, cfg.Backend), db.BackendType(cfg.Backend), datadir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open %v database, check that the node is built with %v tag", cfg.Backend, cfg.Backend)
+		}
+		return res, nil
+	}
 	res, err := db.NewGoLevelDBWithOpts(name, datadir, &opt.Options{
-		OpenFilesCacheCapacity: handles,
-		BlockCacheCapacity:     cache / 2 * opt.MiB,
-		WriteBuffer:            cache / 4 * opt.MiB,
+		OpenFilesCacheCapacity: cfg.Handles,
+		BlockCacheCapacity:     cfg.Cache / 2 * opt.MiB,
+		WriteBuffer:            cfg.Cache / 4 * opt.MiB,
 		Filter:                 filter.NewBloomFilter(10),
 	})
 	if err != nil {
@@ -432,6 +477,64 @@ func OpenDatabase(datadir string, name string, cache int, handles int, compact b
 	return res, nil
 }
 
+// OpenOfflineDatabase opens the chain database of the stopped node for offline tools with the default cache settings,
+// the backend is detected by files in the datadir if it's empty
+func OpenOfflineDatabase(datadir string, name string, backend string) (db.DB, error) {
+	cfg := config.GetDefaultDatabaseConfig()
+	if backend == "" {
+		backend = DetectDatabaseBackend(datadir, name)
+	}
+	cfg.Backend = backend
+	return OpenDatabase(datadir, name, cfg, false)
+}
+
+// DatabaseName returns a name of the database stored with the backend
+func DatabaseName(name string, backend string) string {
+	if backend == config.GoLevelDbBackend {
+		return name
+	}
+	return name + "-" + backend
+}
+
+// DatabasePath returns a path of the database files created by the backend
+func DatabasePath(datadir string, name string, backend string) string {
+	return filepath.Join(datadir, DatabaseName(name, backend)+".db")
+}
+
+// DetectDatabaseBackend returns the backend of the database which exists in the datadir,
+// goleveldb is returned if there is no database yet
+func DetectDatabaseBackend(datadir string, name string) string {
+	for _, backend := range config.DatabaseBackends {
+		if _, err := os.Stat(DatabasePath(datadir, name, backend)); err == nil {
+			return backend
+		}
+	}
+	return config.GoLevelDbBackend
+}
+
+// checkDatabaseBackend prevents starting from scratch when the datadir keeps the database of another backend
+func checkDatabaseBackend(datadir string, name string, backend string) error {
+	known := false
+	for _, b := range config.DatabaseBackends {
+		known = known || b == backend
+	}
+	if !known {
+		return errors.Errorf("unknown database backend %v", backend)
+	}
+	if _, err := os.Stat(DatabasePath(datadir, name, backend)); err == nil {
+		return nil
+	}
+	for _, other := range config.DatabaseBackends {
+		if other == backend {
+			continue
+		}
+		if _, err := os.Stat(DatabasePath(datadir, name, other)); err == nil {
+			return errors.Errorf("datadir contains %v database, migrate it with dbmigrate command or select %v backend", other, other)
+		}
+	}
+	return nil
+}
+
 func compactDb(goLevelDB *db.GoLevelDB) error {
 	start := time.Now()
 	logTimeout := time.After(time.Second)
@@ -455,7 +558,7 @@ func (node *Node) apis() []rpc.API {
 
 	baseApi := api.NewBaseApi(node.consensusEngine, node.txpool, node.keyStore, node.secStore, node.ipfsProxy)
 
-	return []rpc.API{
+	apis := []rpc.API{
 		{
 			Namespace: "net",
 			Version:   "1.0",
@@ -465,7 +568,7 @@ func (node *Node) apis() []rpc.API {
 		{
 			Namespace: "dna",
 			Version:   "1.0",
-			Service:   api.NewDnaApi(baseApi, node.blockchain, node.ceremony, node.appVersion, node.profileManager),
+			Service:   api.NewDnaApi(baseApi, node.blockchain, node.ceremony, node.appVersion, node.profileManager, node.pm, node.downloader, node.flipKeyPool),
 			Public:    true,
 		},
 		{
@@ -477,7 +580,7 @@ func (node *Node) apis() []rpc.API {
 		{
 			Namespace: "flip",
 			Version:   "1.0",
-			Service:   api.NewFlipApi(baseApi, node.fp, node.ipfsProxy, node.ceremony),
+			Service:   api.NewFlipApi(baseApi, node.fp, node.ipfsProxy, node.ceremony, node.deferJob),
 			Public:    true,
 		},
 		{
@@ -498,5 +601,20 @@ func (node *Node) apis() []rpc.API {
 			Service:   api.NewContractApi(baseApi, node.blockchain, node.deferJob, node.subManager),
 			Public:    true,
 		},
+		{
+			Namespace: "debug",
+			Version:   "1.0",
+			Service:   api.NewDebugApi(node.consensusEngine),
+			Public:    true,
+		},
+	}
+	if node.config.Sync.LightMode {
+		apis = append(apis, rpc.API{
+			Namespace: "light",
+			Version:   "1.0",
+			Service:   api.NewLightApi(protocol.NewLightState(node.pm, node.blockchain)),
+			Public:    true,
+		})
 	}
+	return apis
 }
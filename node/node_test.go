@@ -0,0 +1,48 @@
+package node
+
+import (
+	"github.com/idena-network/idena-go/config"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOpenDatabase_backends(t *testing.T) {
+	for _, backend := range config.DatabaseBackends {
+		t.Run(backend, func(t *testing.T) {
+			datadir := t.TempDir()
+			cfg := config.GetDefaultDatabaseConfig()
+			cfg.Backend = backend
+			db, err := OpenDatabase(datadir, "idenachain", cfg, false)
+			if err != nil && backend != config.GoLevelDbBackend {
+				t.Skipf("backend is not available: %v", err)
+			}
+			require.NoError(t, err)
+			require.NoError(t, db.Set([]byte{0x1}, []byte{0x2}))
+			require.NoError(t, db.Close())
+
+			require.Equal(t, backend, DetectDatabaseBackend(datadir, "idenachain"))
+			db, err = OpenOfflineDatabase(datadir, "idenachain", "")
+			require.NoError(t, err)
+			value, err := db.Get([]byte{0x1})
+			require.NoError(t, err)
+			require.Equal(t, []byte{0x2}, value)
+			require.NoError(t, db.Close())
+
+			// database of another backend isn't created next to the existing one
+			for _, other := range config.DatabaseBackends {
+				if other == backend {
+					continue
+				}
+				cfg.Backend = other
+				_, err := OpenDatabase(datadir, "idenachain", cfg, false)
+				require.Error(t, err)
+			}
+		})
+	}
+	require.Equal(t, config.GoLevelDbBackend, DetectDatabaseBackend(t.TempDir(), "idenachain"))
+
+	cfg := config.GetDefaultDatabaseConfig()
+	cfg.Backend = "unknown"
+	_, err := OpenDatabase(t.TempDir(), "idenachain", cfg, false)
+	require.Error(t, err)
+}
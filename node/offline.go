@@ -0,0 +1,123 @@
+package node
+
+import (
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/validation"
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/core/ceremony"
+	"github.com/idena-network/idena-go/core/flip"
+	"github.com/idena-network/idena-go/core/mempool"
+	"github.com/idena-network/idena-go/core/upgrade"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/deferredtx"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/keystore"
+	"github.com/idena-network/idena-go/secstore"
+	"github.com/idena-network/idena-go/stats/collector"
+	"github.com/idena-network/idena-go/subscriptions"
+	"github.com/idena-network/idena-go/vm"
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// offlineSyncer makes the ceremony treat imported blocks as synced from the network, so it never interacts with peers
+type offlineSyncer struct{}
+
+func (offlineSyncer) IsSyncing() bool {
+	return true
+}
+
+// offlineNode holds components of the stopped node which are needed to change its chain without network
+type offlineNode struct {
+	db             dbm.DB
+	repo           *database.Repo
+	chain          *blockchain.Blockchain
+	appState       *appstate.AppState
+	keyStore       *keystore.KeyStore
+	secStore       *secstore.SecStore
+	ipfsProxy      ipfs.Proxy
+	statsCollector collector.StatsCollector
+	flipper        *flip.Flipper
+	deferredTxs    *deferredtx.Job
+	stopIpfs       func()
+}
+
+// openOfflineNode opens the chain database and the local IPFS repo and initializes the chain like the node does
+// on start, it has to be closed by close
+func openOfflineNode(cfg *config.Config) (*offlineNode, error) {
+	db, err := OpenDatabase(cfg.DataDir, "idenachain", cfg.Database, false)
+	if err != nil {
+		return nil, err
+	}
+	n := &offlineNode{db: db, repo: database.NewRepo(db)}
+	if err := n.initialize(cfg); err != nil {
+		n.close()
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *offlineNode) initialize(cfg *config.Config) error {
+	ipfsProxy, stop, err := ipfs.NewOfflineIpfsProxy(cfg.IpfsConf)
+	if err != nil {
+		return errors.Wrap(err, "failed to open ipfs repo")
+	}
+	n.ipfsProxy, n.stopIpfs = ipfsProxy, stop
+
+	keyStoreDir, err := cfg.KeyStoreDataDir()
+	if err != nil {
+		return err
+	}
+	privateKey, err := cfg.NodeKey()
+	if err != nil {
+		return errors.Wrap(err, "cannot initialize node key")
+	}
+	validation.SetAppConfig(cfg)
+	bus := eventbus.New()
+	n.statsCollector = collector.NewStatsCollector()
+	n.keyStore = keystore.NewKeyStore(keyStoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
+	n.secStore = secstore.NewSecStore()
+	n.secStore.AddKey(crypto.FromECDSA(privateKey))
+	n.appState, err = appstate.NewAppState(n.db, bus)
+	if err != nil {
+		return err
+	}
+	offlineDetector := blockchain.NewOfflineDetector(cfg, n.db, n.appState, n.secStore, bus)
+	upgrader := upgrade.NewUpgrader(cfg, n.appState, n.db)
+	txpool := mempool.NewTxPool(n.appState, bus, cfg, n.statsCollector)
+	flipKeyPool := mempool.NewKeysPool(n.db, n.appState, bus, n.secStore)
+	subManager, err := subscriptions.NewManager(cfg.DataDir)
+	if err != nil {
+		return err
+	}
+	n.chain = blockchain.NewBlockchain(cfg, n.db, txpool, n.appState, ipfsProxy, n.secStore, bus, offlineDetector, n.keyStore, subManager, upgrader)
+	n.flipper = flip.NewFlipper(n.db, ipfsProxy, flipKeyPool, txpool, n.secStore, n.appState, bus)
+	validationCeremony := ceremony.NewValidationCeremony(n.appState, bus, n.flipper, n.secStore, n.db, txpool, n.chain, offlineSyncer{}, flipKeyPool, cfg)
+
+	if err := n.chain.InitializeChain(); err != nil {
+		return errors.Wrap(err, "cannot initialize blockchain")
+	}
+	if err := n.appState.Initialize(n.chain.Head.Height()); err != nil {
+		return errors.Wrap(err, "cannot initialize state")
+	}
+	if err := n.chain.EnsureIntegrity(); err != nil {
+		return errors.Wrap(err, "failed to recover blockchain")
+	}
+	txpool.Initialize(n.chain.Head, n.secStore.GetAddress(), false)
+	flipKeyPool.Initialize(n.chain.Head)
+	n.flipper.Initialize()
+	validationCeremony.Initialize(n.chain.GetBlock(n.chain.Head.Hash()))
+	n.chain.ProvideApplyNewEpochFunc(validationCeremony.ApplyNewEpoch)
+	n.deferredTxs, err = deferredtx.NewJob(bus, cfg.DataDir, n.appState, n.chain, txpool, n.keyStore, n.secStore, vm.NewVmImpl)
+	return err
+}
+
+func (n *offlineNode) close() {
+	if n.stopIpfs != nil {
+		n.stopIpfs()
+	}
+	n.db.Close()
+}
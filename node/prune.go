@@ -0,0 +1,154 @@
+package node
+
+import (
+	"bytes"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/log"
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+	"time"
+)
+
+// PruneResult describes what is dropped by Prune
+type PruneResult struct {
+	// Horizon is the lowest height whose block body, tx indexes and receipts are kept
+	Horizon   uint64
+	Unpinned  int
+	TxIndexes int
+}
+
+// Prune drops block bodies, receipts, tx indexes and burnt coins of blocks before the epoch which started retainEpochs
+// epochs before the current one, headers and certificates are kept. Bodies and receipts are unpinned from the local
+// IPFS repo and removed by its garbage collection, so the node has to be stopped. The running node never prunes,
+// Blockchain.RetainEpochs of the config is only the default of the prune command.
+func Prune(cfg *config.Config, retainEpochs uint32) (*PruneResult, error) {
+	if retainEpochs == 0 {
+		return nil, errors.New("number of retained epochs should be positive")
+	}
+	db, err := OpenDatabase(cfg.DataDir, "idenachain", cfg.Database, false)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	repo := database.NewRepo(db)
+	head := repo.ReadHead()
+	if head == nil {
+		return nil, errors.New("chain is empty")
+	}
+	stateDb, err := state.NewLazy(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := stateDb.Load(head.Height()); err != nil {
+		return nil, errors.Wrap(err, "cannot load state at the head")
+	}
+
+	ipfsProxy, stop, err := ipfs.NewOfflineIpfsProxy(cfg.IpfsConf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open ipfs repo")
+	}
+	defer stop()
+
+	prevHorizon := repo.ReadPrunedHorizon()
+	result, err := pruneBlocks(db, ipfsProxy, stateDb.EpochBlock(), retainEpochs)
+	if err != nil || result.Horizon == prevHorizon {
+		return result, err
+	}
+	log.Info("Running ipfs garbage collection")
+	ctx, cancel := ipfsProxy.GC()
+	<-ctx.Done()
+	cancel()
+	return result, nil
+}
+
+func pruneBlocks(db dbm.DB, ipfsProxy ipfs.Proxy, epochBlock uint64, retainEpochs uint32) (*PruneResult, error) {
+	repo := database.NewRepo(db)
+	result := &PruneResult{Horizon: repo.ReadPrunedHorizon()}
+	horizon := findPruneHorizon(repo, epochBlock, retainEpochs)
+	if horizon <= result.Horizon {
+		log.Info("Nothing to prune", "horizon", result.Horizon)
+		return result, nil
+	}
+	from := result.Horizon
+	if from == 0 {
+		from = 1
+	}
+	log.Info("Pruning blocks", "from", from, "to", horizon-1)
+
+	logTicker := time.NewTicker(time.Second * 10)
+	defer logTicker.Stop()
+	unpin := func(cid []byte) {
+		if len(cid) == 0 || bytes.Equal(cid, ipfs.EmptyCid.Bytes()) {
+			return
+		}
+		// data may be not pinned, it is removed by gc anyway
+		if err := ipfsProxy.Unpin(cid); err == nil {
+			result.Unpinned++
+		}
+	}
+	for height := from; height < horizon; height++ {
+		header := repo.ReadBlockHeader(repo.ReadCanonicalHash(height))
+		if header != nil && header.ProposedHeader != nil {
+			unpin(header.ProposedHeader.IpfsHash)
+			unpin(header.ProposedHeader.TxReceiptsCid)
+		}
+		select {
+		case <-logTicker.C:
+			log.Info("Unpinning block bodies", "height", height, "to", horizon-1)
+		default:
+		}
+	}
+
+	batch := db.NewBatch()
+	defer batch.Close()
+	repo.IterateTxIndexes(func(txHash common.Hash, index *types.TransactionIndex) bool {
+		if index != nil {
+			if header := repo.ReadBlockHeader(index.BlockHash); header != nil && header.Height() >= horizon {
+				return false
+			}
+		}
+		repo.RemoveTxIndex(batch, txHash)
+		repo.RemoveReceiptIndex(batch, txHash)
+		result.TxIndexes++
+		return false
+	})
+	if err := batch.WriteSync(); err != nil {
+		return nil, err
+	}
+	repo.DeleteBurntCoinsBefore(horizon)
+	repo.WritePrunedHorizon(horizon)
+	result.Horizon = horizon
+	return result, nil
+}
+
+// findPruneHorizon walks headers back from the start of the current epoch and returns the first block of the epoch
+// which started retainEpochs epochs before, 0 if the chain doesn't have so many epochs
+func findPruneHorizon(repo *database.Repo, epochBlock uint64, retainEpochs uint32) uint64 {
+	horizon := epochBlock
+	for i := uint32(0); i < retainEpochs; i++ {
+		if horizon <= 1 {
+			return 0
+		}
+		found := false
+		for height := horizon - 1; height > 0; height-- {
+			header := repo.ReadBlockHeader(repo.ReadCanonicalHash(height))
+			if header == nil {
+				// history before the restored snapshot or the genesis is absent
+				return 0
+			}
+			if header.Flags().HasFlag(types.ValidationFinished) {
+				horizon, found = height, true
+				break
+			}
+		}
+		if !found {
+			return 0
+		}
+	}
+	return horizon
+}
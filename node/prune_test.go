@@ -0,0 +1,93 @@
+package node
+
+import (
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/stretchr/testify/require"
+	db "github.com/tendermint/tm-db"
+	"testing"
+)
+
+// writePruneTestChain writes canonical headers up to the height, blocks of finishedEpochs have ValidationFinished flag
+func writePruneTestChain(t *testing.T, repo *database.Repo, height uint64, finishedEpochs ...uint64) {
+	finished := make(map[uint64]bool)
+	for _, h := range finishedEpochs {
+		finished[h] = true
+	}
+	ipfsProxy := ipfs.NewMemoryIpfsProxy()
+	for h := uint64(1); h <= height; h++ {
+		c, err := ipfsProxy.Cid(common.ToBytes(h))
+		require.NoError(t, err)
+		header := &types.Header{ProposedHeader: &types.ProposedHeader{Height: h, IpfsHash: c.Bytes()}}
+		if finished[h] {
+			header.ProposedHeader.Flags = types.ValidationFinished
+		}
+		repo.WriteBlockHeader(header)
+		repo.WriteCanonicalHash(h, header.Hash())
+	}
+	repo.SetHead(nil, height)
+}
+
+func Test_findPruneHorizon(t *testing.T) {
+	repo := database.NewRepo(db.NewMemDB())
+	writePruneTestChain(t, repo, 50, 10, 25, 40)
+
+	cases := []struct {
+		name         string
+		epochBlock   uint64
+		retainEpochs uint32
+		expected     uint64
+	}{
+		{"one epoch", 40, 1, 25},
+		{"two epochs", 40, 2, 10},
+		{"more epochs than the chain has", 40, 3, 0},
+		{"first epoch", 1, 1, 0},
+		{"epoch block without finished epochs before", 10, 1, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expected, findPruneHorizon(repo, c.epochBlock, c.retainEpochs))
+		})
+	}
+
+	// history before the restored snapshot is absent
+	restored := database.NewRepo(db.NewMemDB())
+	writePruneTestChain(t, restored, 50, 10, 25, 40)
+	restored.RemoveCanonicalHash(15)
+	require.Equal(t, uint64(25), findPruneHorizon(restored, 40, 1))
+	require.Zero(t, findPruneHorizon(restored, 40, 2))
+}
+
+func Test_pruneBlocks(t *testing.T) {
+	memDb := db.NewMemDB()
+	repo := database.NewRepo(memDb)
+	writePruneTestChain(t, repo, 50, 10, 25, 40)
+	pruned, kept := common.Hash{0x1}, common.Hash{0x2}
+	repo.WriteTxIndex(pruned, &types.TransactionIndex{BlockHash: repo.ReadCanonicalHash(5)})
+	repo.WriteTxIndex(kept, &types.TransactionIndex{BlockHash: repo.ReadCanonicalHash(30)})
+
+	result, err := pruneBlocks(memDb, ipfs.NewMemoryIpfsProxy(), 40, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), result.Horizon)
+	require.Equal(t, 9, result.Unpinned)
+	require.Equal(t, 1, result.TxIndexes)
+	require.Equal(t, uint64(10), repo.ReadPrunedHorizon())
+	require.Nil(t, repo.ReadTxIndex(pruned))
+	require.NotNil(t, repo.ReadTxIndex(kept))
+
+	// lower horizon doesn't change the pruned one
+	result, err = pruneBlocks(memDb, ipfs.NewMemoryIpfsProxy(), 40, 3)
+	require.NoError(t, err)
+	require.Equal(t, &PruneResult{Horizon: 10}, result)
+
+	// next prune starts from the previous horizon
+	result, err = pruneBlocks(memDb, ipfs.NewMemoryIpfsProxy(), 40, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(25), result.Horizon)
+	require.Equal(t, 15, result.Unpinned)
+	require.Zero(t, result.TxIndexes)
+	require.Equal(t, uint64(25), repo.ReadPrunedHorizon())
+	require.NotNil(t, repo.ReadTxIndex(kept))
+}
@@ -0,0 +1,178 @@
+package node
+
+import (
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/log"
+	"github.com/pkg/errors"
+	"os"
+)
+
+// RewindResult describes the chain after Rewind
+type RewindResult struct {
+	Head          *types.Header
+	RemovedBlocks uint64
+	// LocalTxs are reverted transactions sent by accounts of the node, they have to be resubmitted
+	LocalTxs      []*types.Transaction
+	ClearedEpochs []uint16
+}
+
+// Rewind resets the chain of the stopped node to the height. State trees are reset to the saved versions of the height,
+// blocks above it are removed together with their certificates and tx indexes and epoch dbs of epochs started above it
+// are cleared. Rewinding across an epoch boundary requires crossEpoch, since the epoch db of the epoch of the height is
+// cleared when the epoch is finished and it cannot be restored.
+func Rewind(cfg *config.Config, height uint64, crossEpoch bool) (*RewindResult, error) {
+	n, err := openOfflineNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer n.close()
+	return n.rewind(height, crossEpoch)
+}
+
+func (n *offlineNode) rewind(height uint64, crossEpoch bool) (*RewindResult, error) {
+	head := n.chain.Head
+	target, err := n.validateRewind(height)
+	if err != nil {
+		return nil, err
+	}
+	targetState, err := n.appState.Readonly(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load state of height %v", height)
+	}
+	if targetState.State.Root() != target.Root() || targetState.IdentityState.Root() != target.IdentityRoot() {
+		return nil, errors.Errorf("saved state of height %v doesn't match the block", height)
+	}
+	prevEpoch, epoch := n.appState.State.Epoch(), targetState.State.Epoch()
+	if epoch != prevEpoch {
+		if !crossEpoch {
+			return nil, errors.Errorf("target height %v is in epoch %v before the current epoch %v, "+
+				"flip keys and answers of the epoch %v are cleared and cannot be restored", height, epoch, prevEpoch, epoch)
+		}
+		log.Warn("Rewinding across epoch boundary, flip keys and answers of the target epoch are not restored",
+			"epoch", epoch, "currentEpoch", prevEpoch)
+	}
+
+	localTxs, savedTxs := n.collectLocalTxs(height, head.Height())
+	var removedHashes []common.Hash
+	for h := height + 1; h <= head.Height(); h++ {
+		if hash := n.repo.ReadCanonicalHash(h); hash != (common.Hash{}) {
+			removedHashes = append(removedHashes, hash)
+		}
+	}
+	revertedTxs, err := n.chain.ResetTo(height)
+	if err != nil {
+		return nil, err
+	}
+	for _, saved := range savedTxs {
+		n.repo.DeleteSavedTx(saved.address, saved.timestamp, saved.tx)
+	}
+	for _, hash := range removedHashes {
+		n.repo.RemoveCertificate(hash)
+	}
+	batch := n.db.NewBatch()
+	defer batch.Close()
+	for _, tx := range revertedTxs {
+		n.repo.RemoveTxIndex(batch, tx.Hash())
+		n.repo.RemoveReceiptIndex(batch, tx.Hash())
+	}
+	if err := batch.WriteSync(); err != nil {
+		return nil, err
+	}
+	n.repo.DeleteBurntCoinsAfter(height)
+	n.dropSnapshotManifest(height)
+
+	result := &RewindResult{
+		Head:          n.chain.Head,
+		RemovedBlocks: head.Height() - height,
+		LocalTxs:      localTxs,
+	}
+	for e := epoch + 1; e <= prevEpoch; e++ {
+		database.NewEpochDb(n.db, e).Clear()
+		result.ClearedEpochs = append(result.ClearedEpochs, e)
+	}
+	return result, nil
+}
+
+func (n *offlineNode) validateRewind(height uint64) (*types.Header, error) {
+	head := n.chain.Head
+	if height >= head.Height() {
+		return nil, errors.Errorf("target height %v should be below the head %v", height, head.Height())
+	}
+	if n.chain.PreliminaryHead != nil {
+		return nil, errors.New("fast sync is not completed, chain cannot be rewound")
+	}
+	if genesis := n.chain.GenesisInfo().Genesis.Height(); height < genesis {
+		return nil, errors.Errorf("target height is below the genesis %v", genesis)
+	}
+	if restored := n.repo.ReadRestoredSnapshotHeight(); height < restored {
+		return nil, errors.Errorf("target height is below the restored snapshot %v", restored)
+	}
+	if n.chain.IsPruned(height + 1) {
+		return nil, errors.Errorf("blocks above the target height are pruned, the node keeps blocks since %v", n.chain.PrunedHorizon())
+	}
+	if !n.appState.State.HasVersion(height) || !n.appState.IdentityState.HasVersion(height) {
+		return nil, errors.Errorf("state of height %v is not saved, the node keeps states of the last %v blocks",
+			height, state.MaxSavedStatesCount)
+	}
+	target := n.chain.GetBlockHeaderByHeight(height)
+	if target == nil {
+		return nil, errors.Errorf("block %v is not found", height)
+	}
+	return target, nil
+}
+
+type savedTx struct {
+	address   common.Address
+	timestamp int64
+	tx        *types.Transaction
+}
+
+// collectLocalTxs collects transactions of the node accounts in blocks to be removed and their saved records
+func (n *offlineNode) collectLocalTxs(from, to uint64) (localTxs []*types.Transaction, savedTxs []savedTx) {
+	accounts := map[common.Address]struct{}{
+		n.secStore.GetAddress(): {},
+	}
+	for _, account := range n.keyStore.Accounts() {
+		accounts[account.Address] = struct{}{}
+	}
+	for h := from + 1; h <= to; h++ {
+		block := n.chain.GetBlockByHeight(h)
+		if block == nil {
+			log.Warn("Block body is not available, its transactions cannot be reported", "height", h)
+			continue
+		}
+		for _, tx := range block.Body.Transactions {
+			sender, _ := types.Sender(tx)
+			if _, ok := accounts[sender]; ok {
+				localTxs = append(localTxs, tx)
+				savedTxs = append(savedTxs, savedTx{sender, block.Header.Time(), tx})
+			}
+			if tx.To != nil && *tx.To != sender {
+				if _, ok := accounts[*tx.To]; ok {
+					savedTxs = append(savedTxs, savedTx{*tx.To, block.Header.Time(), tx})
+				}
+			}
+		}
+	}
+	return localTxs, savedTxs
+}
+
+// dropSnapshotManifest removes the last snapshot if it is above the height, so it isn't served to peers
+func (n *offlineNode) dropSnapshotManifest(height uint64) {
+	cid, _, snapshotHeight, fileName := n.repo.LastSnapshotManifest()
+	if cid == nil || snapshotHeight <= height {
+		return
+	}
+	if err := n.ipfsProxy.Unpin(cid); err != nil {
+		log.Warn("Cannot unpin snapshot", "height", snapshotHeight, "err", err)
+	}
+	n.repo.RemoveLastSnapshotManifest()
+	if fileName != "" {
+		os.Remove(fileName)
+	}
+	log.Info("Snapshot above the target height is dropped", "height", snapshotHeight)
+}
@@ -0,0 +1,105 @@
+package node
+
+import (
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/keystore"
+	"github.com/idena-network/idena-go/stats/collector"
+	"github.com/stretchr/testify/require"
+	db "github.com/tendermint/tm-db"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func newRewindTestNode(t *testing.T) (*offlineNode, *blockchain.TestBlockchain) {
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	consensusCfg := blockchain.GetDefaultConsensusConfig()
+	consensusCfg.Automine = true
+	cfg := &config.Config{
+		Network:   0x99,
+		Consensus: consensusCfg,
+		GenesisConf: &config.GenesisConf{
+			Alloc: map[common.Address]config.GenesisAllocation{
+				address: {Balance: new(big.Int).Mul(big.NewInt(100), common.DnaBase)},
+			},
+			GodAddress:        address,
+			FirstCeremonyTime: 4070908800,
+		},
+		Validation: &config.ValidationConfig{},
+		Blockchain: &config.BlockchainConfig{},
+	}
+	memDb := db.NewMemDB()
+	chain, appState := blockchain.NewCustomTestBlockchainWithDb(memDb, 0, 0, key, cfg)
+	return &offlineNode{
+		db:             memDb,
+		repo:           chain.Repo(),
+		chain:          chain.Blockchain,
+		appState:       appState,
+		keyStore:       keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP),
+		secStore:       chain.SecStore(),
+		ipfsProxy:      ipfs.NewMemoryIpfsProxy(),
+		statsCollector: collector.NewStatsCollector(),
+	}, chain
+}
+
+func TestOfflineNode_rewind(t *testing.T) {
+	n, chain := newRewindTestNode(t)
+	// genesis is at height 1, blocks above 6 have a tx of the node
+	chain.GenerateBlocks(5, 0).GenerateBlocks(5, 1)
+	target := chain.Repo().ReadCanonicalHash(6)
+	removed := chain.Repo().ReadCanonicalHash(9)
+	require.NotNil(t, chain.Repo().ReadCertificate(removed))
+	address := n.secStore.GetAddress()
+	savedTxs, _ := n.repo.GetSavedTxs(address, 100, nil)
+	require.Len(t, savedTxs, 5)
+
+	result, err := n.rewind(6, false)
+	require.NoError(t, err)
+	require.Equal(t, target, result.Head.Hash())
+	require.Equal(t, uint64(5), result.RemovedBlocks)
+	require.Len(t, result.LocalTxs, 5)
+	require.Empty(t, result.ClearedEpochs)
+	require.Equal(t, int64(6), n.appState.State.Version())
+
+	require.Nil(t, n.repo.ReadBlockHeader(removed))
+	require.Nil(t, n.repo.ReadCertificate(removed))
+	require.NotNil(t, n.repo.ReadCertificate(target))
+	savedTxs, _ = n.repo.GetSavedTxs(address, 100, nil)
+	require.Empty(t, savedTxs)
+	for _, tx := range result.LocalTxs {
+		require.Nil(t, n.repo.ReadTxIndex(tx.Hash()))
+	}
+
+	// the rewound chain continues
+	chain.GenerateBlocks(3, 0)
+	require.Equal(t, uint64(9), chain.Head.Height())
+}
+
+func TestOfflineNode_rewind_crossEpoch(t *testing.T) {
+	n, chain := newRewindTestNode(t)
+	chain.GenerateBlocks(5, 0)
+	n.appState.State.SetGlobalEpoch(1)
+	n.appState.Commit(nil)
+	chain.CommitState()
+	chain.GenerateBlocks(3, 0)
+	head := chain.Head.Hash()
+
+	_, err := n.rewind(4, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "before the current epoch 1")
+	require.Equal(t, head, chain.Head.Hash())
+
+	database.NewEpochDb(n.db, 1).WriteAnswerHash(n.secStore.GetAddress(), common.Hash{0x1}, time.Now())
+	result, err := n.rewind(4, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), result.Head.Height())
+	require.Equal(t, []uint16{1}, result.ClearedEpochs)
+	require.Equal(t, uint16(0), n.appState.State.Epoch())
+	require.Equal(t, common.Hash{}, database.NewEpochDb(n.db, 1).GetAnswerHash(n.secStore.GetAddress()))
+}
@@ -0,0 +1,458 @@
+package node
+
+import (
+	"encoding/json"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/blockchain/types"
+	"github.com/idena-network/idena-go/blockchain/validation"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/config"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/core/mempool"
+	"github.com/idena-network/idena-go/core/state/snapshot"
+	"github.com/idena-network/idena-go/core/upgrade"
+	"github.com/idena-network/idena-go/core/validators"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/idena-network/idena-go/database"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/keystore"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/secstore"
+	"github.com/idena-network/idena-go/stats/collector"
+	"github.com/idena-network/idena-go/subscriptions"
+	"github.com/mholt/archiver/v3"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+const (
+	snapshotManifestFile = "manifest.json"
+	snapshotHeaderFile   = "header"
+	snapshotParentFile   = "parent"
+	snapshotCertFile     = "cert"
+	snapshotGenesisFile  = "genesis"
+	snapshotStateFile    = "state"
+	snapshotIdentityFile = "identity"
+)
+
+// localSnapshotManifest is the first file of the local snapshot archive
+type localSnapshotManifest struct {
+	Network             uint32 `json:"network"`
+	Height              uint64 `json:"height"`
+	ConsensusVersion    uint32 `json:"consensusVersion"`
+	IntermediateGenesis uint64 `json:"intermediateGenesis,omitempty"`
+}
+
+// CreateSnapshot writes the state and the identity state at the head of the stopped node to the tar.gz archive together
+// with the head header, its parent header and certificate and the intermediate genesis header, so the archive can be
+// restored by RestoreSnapshot without IPFS. Hashes of the returned head and intermediate genesis (nil if the chain
+// has none) have to be passed to the restoring side by a trusted channel.
+func CreateSnapshot(cfg *config.Config, out io.Writer) (head, genesis *types.Header, err error) {
+	db, err := OpenDatabase(cfg.DataDir, "idenachain", cfg.Database, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+	repo := database.NewRepo(db)
+	head = repo.ReadHead()
+	if head == nil {
+		return nil, nil, errors.New("chain is empty")
+	}
+	appState, err := appstate.NewAppState(db, eventbus.New())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := appState.Initialize(head.Height()); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot load state at the head")
+	}
+
+	tgz := archiver.NewTarGz()
+	if err := tgz.Create(out); err != nil {
+		return nil, nil, err
+	}
+	defer tgz.Close()
+
+	manifest := &localSnapshotManifest{
+		Network:          cfg.Network,
+		Height:           head.Height(),
+		ConsensusVersion: repo.ReadConsensusVersion(),
+	}
+	if height := repo.ReadIntermediateGenesis(); height > 0 {
+		if genesis = repo.ReadBlockHeader(repo.ReadCanonicalHash(height)); genesis == nil {
+			return nil, nil, errors.Errorf("intermediate genesis %v is not found", height)
+		}
+		manifest.IntermediateGenesis = height
+	}
+	data, _ := json.Marshal(manifest)
+	if err := writeArchiveFile(tgz, snapshotManifestFile, data); err != nil {
+		return nil, nil, err
+	}
+	if data, err = head.ToBytes(); err != nil {
+		return nil, nil, err
+	}
+	if err := writeArchiveFile(tgz, snapshotHeaderFile, data); err != nil {
+		return nil, nil, err
+	}
+	if parent := repo.ReadBlockHeader(head.ParentHash()); parent != nil {
+		if data, err = parent.ToBytes(); err != nil {
+			return nil, nil, err
+		}
+		if err := writeArchiveFile(tgz, snapshotParentFile, data); err != nil {
+			return nil, nil, err
+		}
+	}
+	if cert := repo.ReadCertificate(head.Hash()); cert != nil && !cert.Empty() {
+		if data, err = cert.ToBytes(); err != nil {
+			return nil, nil, err
+		}
+		if err := writeArchiveFile(tgz, snapshotCertFile, data); err != nil {
+			return nil, nil, err
+		}
+	}
+	if genesis != nil {
+		if data, err = genesis.ToBytes(); err != nil {
+			return nil, nil, err
+		}
+		if err := writeArchiveFile(tgz, snapshotGenesisFile, data); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	writeTree := func(name string, expectedRoot common.Hash, write func(height uint64, to io.Writer) (common.Hash, error)) error {
+		// tar entries have to know their size in advance, so the tree is buffered in a temporary file
+		tmp, err := ioutil.TempFile("", "idena-snapshot-"+name)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+		root, err := write(head.Height(), tmp)
+		if err != nil {
+			return errors.Wrapf(err, "failed to write %v tree", name)
+		}
+		if root != expectedRoot {
+			return errors.Errorf("%v root %v doesn't match the head", name, root.Hex())
+		}
+		stat, err := tmp.Stat()
+		if err != nil {
+			return err
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		return tgz.Write(archiver.File{
+			FileInfo: archiver.FileInfo{
+				CustomName: name,
+				FileInfo:   &archiveFileInfo{name: name, size: stat.Size()},
+			},
+			ReadCloser: ioutil.NopCloser(tmp),
+		})
+	}
+	if err := writeTree(snapshotStateFile, head.Root(), appState.State.WriteSnapshot2); err != nil {
+		return nil, nil, err
+	}
+	if err := writeTree(snapshotIdentityFile, head.IdentityRoot(), appState.IdentityState.WriteSnapshot2); err != nil {
+		return nil, nil, err
+	}
+	return head, genesis, nil
+}
+
+// RestoreSnapshot bootstraps a datadir which has only the genesis block from the archive created by CreateSnapshot.
+// The snapshot header is trusted if its hash equals trustedHash or it matches a trusted checkpoint, the intermediate
+// genesis header is trusted the same way by trustedGenesisHash. Both state roots and votes of the certificate are
+// verified against the header before the node switches to the restored state, like it does after fast sync.
+func RestoreSnapshot(cfg *config.Config, in io.Reader, trustedHash, trustedGenesisHash common.Hash) (*types.Header, error) {
+	db, err := OpenDatabase(cfg.DataDir, "idenachain", cfg.Database, false)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	keyStoreDir, err := cfg.KeyStoreDataDir()
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := cfg.NodeKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot initialize node key")
+	}
+	validation.SetAppConfig(cfg)
+	bus := eventbus.New()
+	keyStore := keystore.NewKeyStore(keyStoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
+	secStore := secstore.NewSecStore()
+	secStore.AddKey(crypto.FromECDSA(privateKey))
+	appState, err := appstate.NewAppState(db, bus)
+	if err != nil {
+		return nil, err
+	}
+	offlineDetector := blockchain.NewOfflineDetector(cfg, db, appState, secStore, bus)
+	upgrader := upgrade.NewUpgrader(cfg, appState, db)
+	txpool := mempool.NewTxPool(appState, bus, cfg, collector.NewStatsCollector())
+	subManager, err := subscriptions.NewManager(cfg.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	// the genesis block has an empty body, so the ipfs repo isn't required for a fresh datadir
+	chain := blockchain.NewBlockchain(cfg, db, txpool, appState, ipfs.NewMemoryIpfsProxy(), secStore, bus, offlineDetector, keyStore, subManager, upgrader)
+	if err := chain.InitializeChain(); err != nil {
+		return nil, errors.Wrap(err, "cannot initialize blockchain")
+	}
+	if err := appState.Initialize(chain.Head.Height()); err != nil {
+		return nil, errors.Wrap(err, "cannot initialize state")
+	}
+	if chain.Head.Hash() != chain.GenesisInfo().Genesis.Hash() {
+		return nil, errors.Errorf("datadir has blocks up to %v, snapshot can be restored only into a fresh datadir", chain.Head.Height())
+	}
+
+	tgz := archiver.NewTarGz()
+	if err := tgz.Open(in, 0); err != nil {
+		return nil, err
+	}
+	defer tgz.Close()
+
+	restorer := &snapshotRestorer{
+		chain:              chain,
+		appState:           appState,
+		network:            cfg.Network,
+		trustedHash:        trustedHash,
+		trustedGenesisHash: trustedGenesisHash,
+	}
+	if err := restorer.read(tgz); err != nil {
+		restorer.drop()
+		return nil, err
+	}
+	if err := restorer.switchToSnapshot(); err != nil {
+		restorer.drop()
+		return nil, err
+	}
+	database.NewRepo(db).WriteRestoredSnapshotHeight(restorer.header.Height())
+	return restorer.header, nil
+}
+
+type snapshotRestorer struct {
+	chain              *blockchain.Blockchain
+	appState           *appstate.AppState
+	network            uint32
+	trustedHash        common.Hash
+	trustedGenesisHash common.Hash
+
+	manifest         *localSnapshotManifest
+	header           *types.Header
+	parent           *types.Header
+	cert             *types.BlockCert
+	genesis          *types.Header
+	stateRestored    bool
+	identityRestored bool
+}
+
+func (sr *snapshotRestorer) read(tgz *archiver.TarGz) error {
+	for {
+		file, err := tgz.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		err = sr.readFile(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if !sr.stateRestored || !sr.identityRestored {
+		return errors.New("snapshot is incomplete")
+	}
+	return nil
+}
+
+func (sr *snapshotRestorer) readFile(file archiver.File) error {
+	name := file.Name()
+	if name != snapshotManifestFile && sr.manifest == nil {
+		return errors.New("manifest is missing")
+	}
+	if (name == snapshotStateFile || name == snapshotIdentityFile) && sr.header == nil {
+		return errors.New("header is missing")
+	}
+	switch name {
+	case snapshotManifestFile:
+		data, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		sr.manifest = new(localSnapshotManifest)
+		if err := json.Unmarshal(data, sr.manifest); err != nil {
+			return errors.Wrap(err, "invalid manifest")
+		}
+		if sr.manifest.Network != sr.network {
+			return errors.Errorf("snapshot of network %v cannot be restored into network %v", sr.manifest.Network, sr.network)
+		}
+		if sr.manifest.Height <= sr.chain.Head.Height() {
+			return errors.Errorf("snapshot height %v is not above the local head", sr.manifest.Height)
+		}
+	case snapshotHeaderFile:
+		data, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		header := new(types.Header)
+		if err := header.FromBytes(data); err != nil {
+			return errors.Wrap(err, "invalid header")
+		}
+		if err := sr.verifyHeader(header); err != nil {
+			return err
+		}
+		sr.header = header
+	case snapshotParentFile:
+		data, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		parent := new(types.Header)
+		if err := parent.FromBytes(data); err != nil {
+			return errors.Wrap(err, "invalid parent header")
+		}
+		if sr.header == nil || parent.Hash() != sr.header.ParentHash() {
+			return errors.New("parent header doesn't match the header")
+		}
+		sr.parent = parent
+	case snapshotCertFile:
+		data, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		sr.cert = new(types.BlockCert)
+		if err := sr.cert.FromBytes(data); err != nil {
+			return errors.Wrap(err, "invalid certificate")
+		}
+	case snapshotGenesisFile:
+		data, err := ioutil.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		genesis := new(types.Header)
+		if err := genesis.FromBytes(data); err != nil {
+			return errors.Wrap(err, "invalid genesis header")
+		}
+		if genesis.Height() != sr.manifest.IntermediateGenesis || genesis.Height() >= sr.manifest.Height ||
+			!genesis.Flags().HasFlag(types.NewGenesis) {
+			return errors.New("genesis header doesn't match the manifest")
+		}
+		if err := sr.verifyGenesis(genesis); err != nil {
+			return err
+		}
+		sr.genesis = genesis
+	case snapshotStateFile:
+		log.Info("Restoring state", "height", sr.header.Height())
+		if err := sr.appState.State.RecoverSnapshot2(sr.header.Height(), sr.header.Root(), file); err != nil {
+			return errors.Wrap(err, "failed to restore state")
+		}
+		sr.stateRestored = true
+	case snapshotIdentityFile:
+		log.Info("Restoring identity state", "height", sr.header.Height())
+		if err := sr.appState.IdentityState.RecoverPreliminarySnapshot(sr.header.Height(), sr.header.IdentityRoot(), file); err != nil {
+			return errors.Wrap(err, "failed to restore identity state")
+		}
+		sr.identityRestored = true
+	default:
+		return errors.Errorf("unexpected file %v", name)
+	}
+	return nil
+}
+
+func (sr *snapshotRestorer) verifyHeader(header *types.Header) error {
+	if header.Height() != sr.manifest.Height {
+		return errors.New("header doesn't match the manifest")
+	}
+	if err := sr.chain.ValidateCheckpoint(header); err != nil {
+		return err
+	}
+	if sr.trustedHash != (common.Hash{}) {
+		if header.Hash() != sr.trustedHash {
+			return errors.Errorf("snapshot header %v doesn't match the trusted hash %v", header.Hash().Hex(), sr.trustedHash.Hex())
+		}
+		return nil
+	}
+	if sr.chain.LastCheckpointHeight(header.Height()) != header.Height() {
+		return errors.New("trusted hash is required, there is no trusted checkpoint at the snapshot height")
+	}
+	return nil
+}
+
+func (sr *snapshotRestorer) verifyGenesis(genesis *types.Header) error {
+	if err := sr.chain.ValidateCheckpoint(genesis); err != nil {
+		return err
+	}
+	if sr.trustedGenesisHash != (common.Hash{}) {
+		if genesis.Hash() != sr.trustedGenesisHash {
+			return errors.Errorf("genesis header %v doesn't match the trusted genesis hash %v", genesis.Hash().Hex(), sr.trustedGenesisHash.Hex())
+		}
+		return nil
+	}
+	if sr.chain.LastCheckpointHeight(genesis.Height()) != genesis.Height() {
+		return errors.New("trusted genesis hash is required, there is no trusted checkpoint at the intermediate genesis height")
+	}
+	return nil
+}
+
+// verifyCert checks votes of the certificate against the trusted header by validators of the restored identity state.
+// Validators of the certificate are defined by the state of the parent block, so the certificate of the block which
+// updates identities or of the archive without the parent header can't be verified and it isn't restored.
+func (sr *snapshotRestorer) verifyCert() error {
+	if sr.cert == nil {
+		return nil
+	}
+	if sr.parent == nil || sr.header.Flags().HasFlag(types.IdentityUpdate) {
+		log.Warn("Certificate of the snapshot header can't be verified by the restored state, it is skipped")
+		sr.cert = nil
+		return nil
+	}
+	identityState, err := sr.appState.IdentityState.LoadPreliminary(sr.header.Height())
+	if err != nil {
+		return err
+	}
+	validatorsCache := validators.NewValidatorsCache(identityState, sr.appState.State.GodAddress())
+	validatorsCache.Load()
+	if err := sr.chain.ValidateBlockCert(sr.parent, sr.header, sr.cert, validatorsCache, nil); err != nil {
+		return errors.Wrap(err, "invalid certificate")
+	}
+	return nil
+}
+
+// switchToSnapshot writes headers of the verified snapshot as preliminary and makes the restored state current
+func (sr *snapshotRestorer) switchToSnapshot() error {
+	if sr.manifest.IntermediateGenesis > 0 && sr.genesis == nil {
+		return errors.New("genesis header is missing")
+	}
+	if err := sr.verifyCert(); err != nil {
+		return err
+	}
+	if sr.genesis != nil {
+		sr.chain.AddHeaderUnsafe(sr.genesis)
+		sr.chain.WritePreliminaryIntermediateGenesis(sr.genesis.Height())
+	}
+	if sr.manifest.ConsensusVersion > 0 {
+		sr.chain.WritePreliminaryConsensusVersion(sr.manifest.ConsensusVersion)
+	}
+	sr.chain.AddHeaderUnsafe(sr.header)
+	if sr.cert != nil {
+		sr.chain.WriteCertificate(sr.header.Hash(), sr.cert, true)
+	}
+	return sr.chain.SwitchToPreliminaryOffline(&snapshot.Manifest{Root: sr.header.Root(), Height: sr.header.Height()})
+}
+
+// drop removes restored trees and preliminary data, so a failed restore can be repeated
+func (sr *snapshotRestorer) drop() {
+	if sr.manifest != nil {
+		sr.appState.State.DropSnapshot(&snapshot.Manifest{Height: sr.manifest.Height})
+	}
+	if sr.identityRestored {
+		sr.appState.IdentityState.DropPreliminary()
+	}
+	sr.chain.RemovePreliminaryHead(nil)
+	sr.chain.RemovePreliminaryConsensusVersion()
+	sr.chain.RemovePreliminaryIntermediateGenesis()
+}
@@ -48,6 +48,8 @@ type Proposals struct {
 	// proposals with worse proof can be skipped
 	bestProofs      map[uint64]bestHash
 	bestProofsMutex sync.RWMutex
+	// number of valid proposer proofs received per round
+	proofsCount map[uint64]int
 }
 
 type blockPeer struct {
@@ -85,6 +87,7 @@ func NewProposals(chain *blockchain.Blockchain, appState *appstate.AppState, det
 		proposeCache:         cache.New(30*time.Second, 1*time.Minute),
 		blockCache:           cache.New(time.Minute, time.Minute),
 		bestProofs:           map[uint64]bestHash{},
+		proofsCount:          map[uint64]int{},
 	}
 	return p, p.pendingProofs
 }
@@ -141,6 +144,7 @@ func (proposals *Proposals) AddProposeProof(proposal *types.ProofProposal) (adde
 		}
 
 		proposals.setBestHash(currentRound, hash, pubKeyBytes, modifier)
+		proposals.incProofsCount(currentRound)
 		proposals.statsCollector.SubmitProofProposal(currentRound, hash, pubKeyBytes, modifier)
 
 		return true, false
@@ -162,6 +166,13 @@ func (proposals *Proposals) GetProposerPubKey(round uint64) []byte {
 	return nil
 }
 
+// ProofsCount returns the number of valid proposer proofs which were received for the round
+func (proposals *Proposals) ProofsCount(round uint64) int {
+	proposals.bestProofsMutex.RLock()
+	defer proposals.bestProofsMutex.RUnlock()
+	return proposals.proofsCount[round]
+}
+
 func (proposals *Proposals) CompleteRound(height uint64) {
 
 	proposals.blocksByRound.Range(func(key, value interface{}) bool {
@@ -177,6 +188,11 @@ func (proposals *Proposals) CompleteRound(height uint64) {
 			delete(proposals.bestProofs, round)
 		}
 	}
+	for round := range proposals.proofsCount {
+		if round <= height {
+			delete(proposals.proofsCount, round)
+		}
+	}
 	proposals.bestProofsMutex.Unlock()
 }
 
@@ -387,6 +403,12 @@ func (proposals *Proposals) compareWithBestHash(round uint64, hash common.Hash,
 	return true
 }
 
+func (proposals *Proposals) incProofsCount(round uint64) {
+	proposals.bestProofsMutex.Lock()
+	defer proposals.bestProofsMutex.Unlock()
+	proposals.proofsCount[round]++
+}
+
 func (proposals *Proposals) setBestHash(round uint64, hash common.Hash, proposerPubKey []byte, modifier int) {
 	q := common.HashToFloat(hash, int64(modifier))
 
@@ -3748,6 +3748,744 @@ func (x *ProtoLotteryIdentitiesDb) GetIdentities() []*ProtoLotteryIdentitiesDb_I
 	return nil
 }
 
+type ProtoValidationReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch                 uint32  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Failed                bool    `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Candidate             bool    `protobuf:"varint,3,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	PrevState             uint32  `protobuf:"varint,4,opt,name=prevState,proto3" json:"prevState,omitempty"`
+	NewState              uint32  `protobuf:"varint,5,opt,name=newState,proto3" json:"newState,omitempty"`
+	Approved              bool    `protobuf:"varint,6,opt,name=approved,proto3" json:"approved,omitempty"`
+	Missed                bool    `protobuf:"varint,7,opt,name=missed,proto3" json:"missed,omitempty"`
+	MissedReason          uint32  `protobuf:"varint,8,opt,name=missedReason,proto3" json:"missedReason,omitempty"`
+	ShortFlipsToSolve     uint32  `protobuf:"varint,9,opt,name=shortFlipsToSolve,proto3" json:"shortFlipsToSolve,omitempty"`
+	LongFlipsToSolve      uint32  `protobuf:"varint,10,opt,name=longFlipsToSolve,proto3" json:"longFlipsToSolve,omitempty"`
+	ShortPoint            float32 `protobuf:"fixed32,11,opt,name=shortPoint,proto3" json:"shortPoint,omitempty"`
+	ShortQualifiedFlips   uint32  `protobuf:"varint,12,opt,name=shortQualifiedFlips,proto3" json:"shortQualifiedFlips,omitempty"`
+	LongPoint             float32 `protobuf:"fixed32,13,opt,name=longPoint,proto3" json:"longPoint,omitempty"`
+	LongQualifiedFlips    uint32  `protobuf:"varint,14,opt,name=longQualifiedFlips,proto3" json:"longQualifiedFlips,omitempty"`
+	ShortScore            float32 `protobuf:"fixed32,15,opt,name=shortScore,proto3" json:"shortScore,omitempty"`
+	LongScore             float32 `protobuf:"fixed32,16,opt,name=longScore,proto3" json:"longScore,omitempty"`
+	TotalScore            float32 `protobuf:"fixed32,17,opt,name=totalScore,proto3" json:"totalScore,omitempty"`
+	TotalQualifiedFlips   uint32  `protobuf:"varint,18,opt,name=totalQualifiedFlips,proto3" json:"totalQualifiedFlips,omitempty"`
+	NoQualifiedShortFlips bool    `protobuf:"varint,19,opt,name=noQualifiedShortFlips,proto3" json:"noQualifiedShortFlips,omitempty"`
+	NoQualifiedLongFlips  bool    `protobuf:"varint,20,opt,name=noQualifiedLongFlips,proto3" json:"noQualifiedLongFlips,omitempty"`
+	BadAuthor             bool    `protobuf:"varint,21,opt,name=badAuthor,proto3" json:"badAuthor,omitempty"`
+	BadAuthorReason       uint32  `protobuf:"varint,22,opt,name=badAuthorReason,proto3" json:"badAuthorReason,omitempty"`
+	RewardedFlips         uint32  `protobuf:"varint,23,opt,name=rewardedFlips,proto3" json:"rewardedFlips,omitempty"`
+	RewardedReports       uint32  `protobuf:"varint,24,opt,name=rewardedReports,proto3" json:"rewardedReports,omitempty"`
+	Reward                []byte  `protobuf:"bytes,25,opt,name=reward,proto3" json:"reward,omitempty"`
+	Penalty               []byte  `protobuf:"bytes,26,opt,name=penalty,proto3" json:"penalty,omitempty"`
+}
+
+func (x *ProtoValidationReport) Reset() {
+	*x = ProtoValidationReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_models_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoValidationReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoValidationReport) ProtoMessage() {}
+
+func (x *ProtoValidationReport) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_models_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoValidationReport.ProtoReflect.Descriptor instead.
+func (*ProtoValidationReport) Descriptor() ([]byte, []int) {
+	return file_protobuf_models_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ProtoValidationReport) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
+func (x *ProtoValidationReport) GetCandidate() bool {
+	if x != nil {
+		return x.Candidate
+	}
+	return false
+}
+
+func (x *ProtoValidationReport) GetPrevState() uint32 {
+	if x != nil {
+		return x.PrevState
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetNewState() uint32 {
+	if x != nil {
+		return x.NewState
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ProtoValidationReport) GetMissed() bool {
+	if x != nil {
+		return x.Missed
+	}
+	return false
+}
+
+func (x *ProtoValidationReport) GetMissedReason() uint32 {
+	if x != nil {
+		return x.MissedReason
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetShortFlipsToSolve() uint32 {
+	if x != nil {
+		return x.ShortFlipsToSolve
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetLongFlipsToSolve() uint32 {
+	if x != nil {
+		return x.LongFlipsToSolve
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetShortPoint() float32 {
+	if x != nil {
+		return x.ShortPoint
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetShortQualifiedFlips() uint32 {
+	if x != nil {
+		return x.ShortQualifiedFlips
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetLongPoint() float32 {
+	if x != nil {
+		return x.LongPoint
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetLongQualifiedFlips() uint32 {
+	if x != nil {
+		return x.LongQualifiedFlips
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetShortScore() float32 {
+	if x != nil {
+		return x.ShortScore
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetLongScore() float32 {
+	if x != nil {
+		return x.LongScore
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetTotalScore() float32 {
+	if x != nil {
+		return x.TotalScore
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetTotalQualifiedFlips() uint32 {
+	if x != nil {
+		return x.TotalQualifiedFlips
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetNoQualifiedShortFlips() bool {
+	if x != nil {
+		return x.NoQualifiedShortFlips
+	}
+	return false
+}
+
+func (x *ProtoValidationReport) GetNoQualifiedLongFlips() bool {
+	if x != nil {
+		return x.NoQualifiedLongFlips
+	}
+	return false
+}
+
+func (x *ProtoValidationReport) GetBadAuthor() bool {
+	if x != nil {
+		return x.BadAuthor
+	}
+	return false
+}
+
+func (x *ProtoValidationReport) GetBadAuthorReason() uint32 {
+	if x != nil {
+		return x.BadAuthorReason
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetRewardedFlips() uint32 {
+	if x != nil {
+		return x.RewardedFlips
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetRewardedReports() uint32 {
+	if x != nil {
+		return x.RewardedReports
+	}
+	return 0
+}
+
+func (x *ProtoValidationReport) GetReward() []byte {
+	if x != nil {
+		return x.Reward
+	}
+	return nil
+}
+
+func (x *ProtoValidationReport) GetPenalty() []byte {
+	if x != nil {
+		return x.Penalty
+	}
+	return nil
+}
+
+type ProtoFlipSubmission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cid         []byte `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Tx          []byte `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+	PublicPart  []byte `protobuf:"bytes,3,opt,name=publicPart,proto3" json:"publicPart,omitempty"`
+	PrivatePart []byte `protobuf:"bytes,4,opt,name=privatePart,proto3" json:"privatePart,omitempty"`
+	PairId      uint32 `protobuf:"varint,5,opt,name=pairId,proto3" json:"pairId,omitempty"`
+	Status      uint32 `protobuf:"varint,6,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts    uint32 `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError   string `protobuf:"bytes,8,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	NextAttempt int64  `protobuf:"varint,9,opt,name=nextAttempt,proto3" json:"nextAttempt,omitempty"`
+	Created     int64  `protobuf:"varint,10,opt,name=created,proto3" json:"created,omitempty"`
+	Updated     int64  `protobuf:"varint,11,opt,name=updated,proto3" json:"updated,omitempty"`
+}
+
+func (x *ProtoFlipSubmission) Reset() {
+	*x = ProtoFlipSubmission{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_models_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoFlipSubmission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoFlipSubmission) ProtoMessage() {}
+
+func (x *ProtoFlipSubmission) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_models_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoFlipSubmission.ProtoReflect.Descriptor instead.
+func (*ProtoFlipSubmission) Descriptor() ([]byte, []int) {
+	return file_protobuf_models_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ProtoFlipSubmission) GetCid() []byte {
+	if x != nil {
+		return x.Cid
+	}
+	return nil
+}
+
+func (x *ProtoFlipSubmission) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *ProtoFlipSubmission) GetPublicPart() []byte {
+	if x != nil {
+		return x.PublicPart
+	}
+	return nil
+}
+
+func (x *ProtoFlipSubmission) GetPrivatePart() []byte {
+	if x != nil {
+		return x.PrivatePart
+	}
+	return nil
+}
+
+func (x *ProtoFlipSubmission) GetPairId() uint32 {
+	if x != nil {
+		return x.PairId
+	}
+	return 0
+}
+
+func (x *ProtoFlipSubmission) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ProtoFlipSubmission) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *ProtoFlipSubmission) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ProtoFlipSubmission) GetNextAttempt() int64 {
+	if x != nil {
+		return x.NextAttempt
+	}
+	return 0
+}
+
+func (x *ProtoFlipSubmission) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ProtoFlipSubmission) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+type ProtoFlipArchive struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch          uint32                     `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Address        []byte                     `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	FlipPublicKey  []byte                     `protobuf:"bytes,3,opt,name=flipPublicKey,proto3" json:"flipPublicKey,omitempty"`
+	FlipPrivateKey []byte                     `protobuf:"bytes,4,opt,name=flipPrivateKey,proto3" json:"flipPrivateKey,omitempty"`
+	Flips          []*ProtoFlipArchive_Flip   `protobuf:"bytes,5,rep,name=flips,proto3" json:"flips,omitempty"`
+	Records        []*ProtoFlipArchive_Record `protobuf:"bytes,6,rep,name=records,proto3" json:"records,omitempty"`
+	DeferredTxs    []byte                     `protobuf:"bytes,7,opt,name=deferredTxs,proto3" json:"deferredTxs,omitempty"`
+}
+
+func (x *ProtoFlipArchive) Reset() {
+	*x = ProtoFlipArchive{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_models_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoFlipArchive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoFlipArchive) ProtoMessage() {}
+
+func (x *ProtoFlipArchive) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_models_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoFlipArchive.ProtoReflect.Descriptor instead.
+func (*ProtoFlipArchive) Descriptor() ([]byte, []int) {
+	return file_protobuf_models_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ProtoFlipArchive) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ProtoFlipArchive) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ProtoFlipArchive) GetFlipPublicKey() []byte {
+	if x != nil {
+		return x.FlipPublicKey
+	}
+	return nil
+}
+
+func (x *ProtoFlipArchive) GetFlipPrivateKey() []byte {
+	if x != nil {
+		return x.FlipPrivateKey
+	}
+	return nil
+}
+
+func (x *ProtoFlipArchive) GetFlips() []*ProtoFlipArchive_Flip {
+	if x != nil {
+		return x.Flips
+	}
+	return nil
+}
+
+func (x *ProtoFlipArchive) GetRecords() []*ProtoFlipArchive_Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ProtoFlipArchive) GetDeferredTxs() []byte {
+	if x != nil {
+		return x.DeferredTxs
+	}
+	return nil
+}
+
+type ProtoGetStateProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReqId  uint32 `protobuf:"varint,1,opt,name=reqId,proto3" json:"reqId,omitempty"`
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Key    []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *ProtoGetStateProofRequest) Reset() {
+	*x = ProtoGetStateProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_models_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoGetStateProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoGetStateProofRequest) ProtoMessage() {}
+
+func (x *ProtoGetStateProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_models_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoGetStateProofRequest.ProtoReflect.Descriptor instead.
+func (*ProtoGetStateProofRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_models_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ProtoGetStateProofRequest) GetReqId() uint32 {
+	if x != nil {
+		return x.ReqId
+	}
+	return 0
+}
+
+func (x *ProtoGetStateProofRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProtoGetStateProofRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type ProtoStateProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReqId        uint32 `protobuf:"varint,1,opt,name=reqId,proto3" json:"reqId,omitempty"`
+	Height       uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Proof        []byte `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+	Error        string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	AbsenceProof []byte `protobuf:"bytes,5,opt,name=absenceProof,proto3" json:"absenceProof,omitempty"`
+}
+
+func (x *ProtoStateProof) Reset() {
+	*x = ProtoStateProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_models_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoStateProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoStateProof) ProtoMessage() {}
+
+func (x *ProtoStateProof) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_models_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoStateProof.ProtoReflect.Descriptor instead.
+func (*ProtoStateProof) Descriptor() ([]byte, []int) {
+	return file_protobuf_models_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ProtoStateProof) GetReqId() uint32 {
+	if x != nil {
+		return x.ReqId
+	}
+	return 0
+}
+
+func (x *ProtoStateProof) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProtoStateProof) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *ProtoStateProof) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProtoStateProof) GetAbsenceProof() []byte {
+	if x != nil {
+		return x.AbsenceProof
+	}
+	return nil
+}
+
+type ProtoOwnInvite struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Inviter          []byte `protobuf:"bytes,1,opt,name=inviter,proto3" json:"inviter,omitempty"`
+	Receiver         []byte `protobuf:"bytes,2,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Key              []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	TxHash           []byte `protobuf:"bytes,4,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Epoch            uint32 `protobuf:"varint,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Amount           []byte `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Timestamp        int64  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	KillTxHash       []byte `protobuf:"bytes,8,opt,name=killTxHash,proto3" json:"killTxHash,omitempty"`
+	Status           uint32 `protobuf:"varint,9,opt,name=status,proto3" json:"status,omitempty"`
+	ActivationTxHash []byte `protobuf:"bytes,10,opt,name=activationTxHash,proto3" json:"activationTxHash,omitempty"`
+	Activated        []byte `protobuf:"bytes,11,opt,name=activated,proto3" json:"activated,omitempty"`
+}
+
+func (x *ProtoOwnInvite) Reset() {
+	*x = ProtoOwnInvite{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_models_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoOwnInvite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoOwnInvite) ProtoMessage() {}
+
+func (x *ProtoOwnInvite) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_models_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoOwnInvite.ProtoReflect.Descriptor instead.
+func (*ProtoOwnInvite) Descriptor() ([]byte, []int) {
+	return file_protobuf_models_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ProtoOwnInvite) GetInviter() []byte {
+	if x != nil {
+		return x.Inviter
+	}
+	return nil
+}
+
+func (x *ProtoOwnInvite) GetReceiver() []byte {
+	if x != nil {
+		return x.Receiver
+	}
+	return nil
+}
+
+func (x *ProtoOwnInvite) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *ProtoOwnInvite) GetTxHash() []byte {
+	if x != nil {
+		return x.TxHash
+	}
+	return nil
+}
+
+func (x *ProtoOwnInvite) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ProtoOwnInvite) GetAmount() []byte {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *ProtoOwnInvite) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ProtoOwnInvite) GetKillTxHash() []byte {
+	if x != nil {
+		return x.KillTxHash
+	}
+	return nil
+}
+
+func (x *ProtoOwnInvite) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ProtoOwnInvite) GetActivationTxHash() []byte {
+	if x != nil {
+		return x.ActivationTxHash
+	}
+	return nil
+}
+
+func (x *ProtoOwnInvite) GetActivated() []byte {
+	if x != nil {
+		return x.Activated
+	}
+	return nil
+}
+
 type ProtoTransaction_Data struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -3766,7 +4504,7 @@ type ProtoTransaction_Data struct {
 func (x *ProtoTransaction_Data) Reset() {
 	*x = ProtoTransaction_Data{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[61]
+		mi := &file_protobuf_models_proto_msgTypes[67]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3779,7 +4517,7 @@ func (x *ProtoTransaction_Data) String() string {
 func (*ProtoTransaction_Data) ProtoMessage() {}
 
 func (x *ProtoTransaction_Data) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[61]
+	mi := &file_protobuf_models_proto_msgTypes[67]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3877,7 +4615,7 @@ type ProtoBlockHeader_Proposed struct {
 func (x *ProtoBlockHeader_Proposed) Reset() {
 	*x = ProtoBlockHeader_Proposed{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[62]
+		mi := &file_protobuf_models_proto_msgTypes[68]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3890,7 +4628,7 @@ func (x *ProtoBlockHeader_Proposed) String() string {
 func (*ProtoBlockHeader_Proposed) ProtoMessage() {}
 
 func (x *ProtoBlockHeader_Proposed) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[62]
+	mi := &file_protobuf_models_proto_msgTypes[68]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4035,7 +4773,7 @@ type ProtoBlockHeader_Empty struct {
 func (x *ProtoBlockHeader_Empty) Reset() {
 	*x = ProtoBlockHeader_Empty{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[63]
+		mi := &file_protobuf_models_proto_msgTypes[69]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4048,7 +4786,7 @@ func (x *ProtoBlockHeader_Empty) String() string {
 func (*ProtoBlockHeader_Empty) ProtoMessage() {}
 
 func (x *ProtoBlockHeader_Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[63]
+	mi := &file_protobuf_models_proto_msgTypes[69]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4126,7 +4864,7 @@ type ProtoBlockProposal_Data struct {
 func (x *ProtoBlockProposal_Data) Reset() {
 	*x = ProtoBlockProposal_Data{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[64]
+		mi := &file_protobuf_models_proto_msgTypes[70]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4139,7 +4877,7 @@ func (x *ProtoBlockProposal_Data) String() string {
 func (*ProtoBlockProposal_Data) ProtoMessage() {}
 
 func (x *ProtoBlockProposal_Data) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[64]
+	mi := &file_protobuf_models_proto_msgTypes[70]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4189,7 +4927,7 @@ type ProtoBlockCert_Signature struct {
 func (x *ProtoBlockCert_Signature) Reset() {
 	*x = ProtoBlockCert_Signature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[65]
+		mi := &file_protobuf_models_proto_msgTypes[71]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4202,7 +4940,7 @@ func (x *ProtoBlockCert_Signature) String() string {
 func (*ProtoBlockCert_Signature) ProtoMessage() {}
 
 func (x *ProtoBlockCert_Signature) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[65]
+	mi := &file_protobuf_models_proto_msgTypes[71]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4251,7 +4989,7 @@ type ProtoMsgBatch_BatchItem struct {
 func (x *ProtoMsgBatch_BatchItem) Reset() {
 	*x = ProtoMsgBatch_BatchItem{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[66]
+		mi := &file_protobuf_models_proto_msgTypes[72]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4264,7 +5002,7 @@ func (x *ProtoMsgBatch_BatchItem) String() string {
 func (*ProtoMsgBatch_BatchItem) ProtoMessage() {}
 
 func (x *ProtoMsgBatch_BatchItem) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[66]
+	mi := &file_protobuf_models_proto_msgTypes[72]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4307,7 +5045,7 @@ type ProtoIdentityStateDiff_IdentityStateDiffValue struct {
 func (x *ProtoIdentityStateDiff_IdentityStateDiffValue) Reset() {
 	*x = ProtoIdentityStateDiff_IdentityStateDiffValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[67]
+		mi := &file_protobuf_models_proto_msgTypes[73]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4320,7 +5058,7 @@ func (x *ProtoIdentityStateDiff_IdentityStateDiffValue) String() string {
 func (*ProtoIdentityStateDiff_IdentityStateDiffValue) ProtoMessage() {}
 
 func (x *ProtoIdentityStateDiff_IdentityStateDiffValue) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[67]
+	mi := &file_protobuf_models_proto_msgTypes[73]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4369,7 +5107,7 @@ type ProtoSnapshotBlock_KeyValue struct {
 func (x *ProtoSnapshotBlock_KeyValue) Reset() {
 	*x = ProtoSnapshotBlock_KeyValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[68]
+		mi := &file_protobuf_models_proto_msgTypes[74]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4382,7 +5120,7 @@ func (x *ProtoSnapshotBlock_KeyValue) String() string {
 func (*ProtoSnapshotBlock_KeyValue) ProtoMessage() {}
 
 func (x *ProtoSnapshotBlock_KeyValue) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[68]
+	mi := &file_protobuf_models_proto_msgTypes[74]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4427,7 +5165,7 @@ type ProtoSnapshotNodes_Node struct {
 func (x *ProtoSnapshotNodes_Node) Reset() {
 	*x = ProtoSnapshotNodes_Node{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[69]
+		mi := &file_protobuf_models_proto_msgTypes[75]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4440,7 +5178,7 @@ func (x *ProtoSnapshotNodes_Node) String() string {
 func (*ProtoSnapshotNodes_Node) ProtoMessage() {}
 
 func (x *ProtoSnapshotNodes_Node) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[69]
+	mi := &file_protobuf_models_proto_msgTypes[75]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4504,7 +5242,7 @@ type ProtoGossipBlockRange_Block struct {
 func (x *ProtoGossipBlockRange_Block) Reset() {
 	*x = ProtoGossipBlockRange_Block{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[70]
+		mi := &file_protobuf_models_proto_msgTypes[76]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4517,7 +5255,7 @@ func (x *ProtoGossipBlockRange_Block) String() string {
 func (*ProtoGossipBlockRange_Block) ProtoMessage() {}
 
 func (x *ProtoGossipBlockRange_Block) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[70]
+	mi := &file_protobuf_models_proto_msgTypes[76]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4566,7 +5304,7 @@ type ProtoProposeProof_Data struct {
 func (x *ProtoProposeProof_Data) Reset() {
 	*x = ProtoProposeProof_Data{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[71]
+		mi := &file_protobuf_models_proto_msgTypes[77]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4579,7 +5317,7 @@ func (x *ProtoProposeProof_Data) String() string {
 func (*ProtoProposeProof_Data) ProtoMessage() {}
 
 func (x *ProtoProposeProof_Data) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[71]
+	mi := &file_protobuf_models_proto_msgTypes[77]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4625,7 +5363,7 @@ type ProtoVote_Data struct {
 func (x *ProtoVote_Data) Reset() {
 	*x = ProtoVote_Data{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[72]
+		mi := &file_protobuf_models_proto_msgTypes[78]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4638,7 +5376,7 @@ func (x *ProtoVote_Data) String() string {
 func (*ProtoVote_Data) ProtoMessage() {}
 
 func (x *ProtoVote_Data) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[72]
+	mi := &file_protobuf_models_proto_msgTypes[78]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4708,7 +5446,7 @@ type ProtoFlipKey_Data struct {
 func (x *ProtoFlipKey_Data) Reset() {
 	*x = ProtoFlipKey_Data{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[73]
+		mi := &file_protobuf_models_proto_msgTypes[79]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4721,7 +5459,7 @@ func (x *ProtoFlipKey_Data) String() string {
 func (*ProtoFlipKey_Data) ProtoMessage() {}
 
 func (x *ProtoFlipKey_Data) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[73]
+	mi := &file_protobuf_models_proto_msgTypes[79]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4763,7 +5501,7 @@ type ProtoPrivateFlipKeysPackage_Data struct {
 func (x *ProtoPrivateFlipKeysPackage_Data) Reset() {
 	*x = ProtoPrivateFlipKeysPackage_Data{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[74]
+		mi := &file_protobuf_models_proto_msgTypes[80]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4776,7 +5514,7 @@ func (x *ProtoPrivateFlipKeysPackage_Data) String() string {
 func (*ProtoPrivateFlipKeysPackage_Data) ProtoMessage() {}
 
 func (x *ProtoPrivateFlipKeysPackage_Data) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[74]
+	mi := &file_protobuf_models_proto_msgTypes[80]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4818,7 +5556,7 @@ type ProtoAnswersDb_Answer struct {
 func (x *ProtoAnswersDb_Answer) Reset() {
 	*x = ProtoAnswersDb_Answer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[75]
+		mi := &file_protobuf_models_proto_msgTypes[81]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4831,7 +5569,7 @@ func (x *ProtoAnswersDb_Answer) String() string {
 func (*ProtoAnswersDb_Answer) ProtoMessage() {}
 
 func (x *ProtoAnswersDb_Answer) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[75]
+	mi := &file_protobuf_models_proto_msgTypes[81]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4873,7 +5611,7 @@ type ProtoActivityMonitor_Activity struct {
 func (x *ProtoActivityMonitor_Activity) Reset() {
 	*x = ProtoActivityMonitor_Activity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[76]
+		mi := &file_protobuf_models_proto_msgTypes[82]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4886,7 +5624,7 @@ func (x *ProtoActivityMonitor_Activity) String() string {
 func (*ProtoActivityMonitor_Activity) ProtoMessage() {}
 
 func (x *ProtoActivityMonitor_Activity) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[76]
+	mi := &file_protobuf_models_proto_msgTypes[82]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4928,7 +5666,7 @@ type ProtoStateAccount_ProtoContractData struct {
 func (x *ProtoStateAccount_ProtoContractData) Reset() {
 	*x = ProtoStateAccount_ProtoContractData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[77]
+		mi := &file_protobuf_models_proto_msgTypes[83]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4941,7 +5679,7 @@ func (x *ProtoStateAccount_ProtoContractData) String() string {
 func (*ProtoStateAccount_ProtoContractData) ProtoMessage() {}
 
 func (x *ProtoStateAccount_ProtoContractData) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[77]
+	mi := &file_protobuf_models_proto_msgTypes[83]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4983,7 +5721,7 @@ type ProtoStateIdentity_Flip struct {
 func (x *ProtoStateIdentity_Flip) Reset() {
 	*x = ProtoStateIdentity_Flip{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[78]
+		mi := &file_protobuf_models_proto_msgTypes[84]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4996,7 +5734,7 @@ func (x *ProtoStateIdentity_Flip) String() string {
 func (*ProtoStateIdentity_Flip) ProtoMessage() {}
 
 func (x *ProtoStateIdentity_Flip) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[78]
+	mi := &file_protobuf_models_proto_msgTypes[84]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5038,7 +5776,7 @@ type ProtoStateIdentity_TxAddr struct {
 func (x *ProtoStateIdentity_TxAddr) Reset() {
 	*x = ProtoStateIdentity_TxAddr{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[79]
+		mi := &file_protobuf_models_proto_msgTypes[85]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5051,7 +5789,7 @@ func (x *ProtoStateIdentity_TxAddr) String() string {
 func (*ProtoStateIdentity_TxAddr) ProtoMessage() {}
 
 func (x *ProtoStateIdentity_TxAddr) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[79]
+	mi := &file_protobuf_models_proto_msgTypes[85]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5094,7 +5832,7 @@ type ProtoStateIdentity_Inviter struct {
 func (x *ProtoStateIdentity_Inviter) Reset() {
 	*x = ProtoStateIdentity_Inviter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[80]
+		mi := &file_protobuf_models_proto_msgTypes[86]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5107,7 +5845,7 @@ func (x *ProtoStateIdentity_Inviter) String() string {
 func (*ProtoStateIdentity_Inviter) ProtoMessage() {}
 
 func (x *ProtoStateIdentity_Inviter) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[80]
+	mi := &file_protobuf_models_proto_msgTypes[86]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5156,7 +5894,7 @@ type ProtoStateGlobal_EmptyBlocksByShards struct {
 func (x *ProtoStateGlobal_EmptyBlocksByShards) Reset() {
 	*x = ProtoStateGlobal_EmptyBlocksByShards{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[81]
+		mi := &file_protobuf_models_proto_msgTypes[87]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5169,7 +5907,7 @@ func (x *ProtoStateGlobal_EmptyBlocksByShards) String() string {
 func (*ProtoStateGlobal_EmptyBlocksByShards) ProtoMessage() {}
 
 func (x *ProtoStateGlobal_EmptyBlocksByShards) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[81]
+	mi := &file_protobuf_models_proto_msgTypes[87]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5211,7 +5949,7 @@ type ProtoStateGlobal_ShardSize struct {
 func (x *ProtoStateGlobal_ShardSize) Reset() {
 	*x = ProtoStateGlobal_ShardSize{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[82]
+		mi := &file_protobuf_models_proto_msgTypes[88]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5224,7 +5962,7 @@ func (x *ProtoStateGlobal_ShardSize) String() string {
 func (*ProtoStateGlobal_ShardSize) ProtoMessage() {}
 
 func (x *ProtoStateGlobal_ShardSize) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[82]
+	mi := &file_protobuf_models_proto_msgTypes[88]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5266,7 +6004,7 @@ type ProtoStateDelegationSwitch_Delegation struct {
 func (x *ProtoStateDelegationSwitch_Delegation) Reset() {
 	*x = ProtoStateDelegationSwitch_Delegation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[83]
+		mi := &file_protobuf_models_proto_msgTypes[89]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5279,7 +6017,7 @@ func (x *ProtoStateDelegationSwitch_Delegation) String() string {
 func (*ProtoStateDelegationSwitch_Delegation) ProtoMessage() {}
 
 func (x *ProtoStateDelegationSwitch_Delegation) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[83]
+	mi := &file_protobuf_models_proto_msgTypes[89]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5333,7 +6071,7 @@ type ProtoPredefinedState_Global struct {
 func (x *ProtoPredefinedState_Global) Reset() {
 	*x = ProtoPredefinedState_Global{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[84]
+		mi := &file_protobuf_models_proto_msgTypes[90]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5346,7 +6084,7 @@ func (x *ProtoPredefinedState_Global) String() string {
 func (*ProtoPredefinedState_Global) ProtoMessage() {}
 
 func (x *ProtoPredefinedState_Global) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[84]
+	mi := &file_protobuf_models_proto_msgTypes[90]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5471,7 +6209,7 @@ type ProtoPredefinedState_StatusSwitch struct {
 func (x *ProtoPredefinedState_StatusSwitch) Reset() {
 	*x = ProtoPredefinedState_StatusSwitch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[85]
+		mi := &file_protobuf_models_proto_msgTypes[91]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5484,7 +6222,7 @@ func (x *ProtoPredefinedState_StatusSwitch) String() string {
 func (*ProtoPredefinedState_StatusSwitch) ProtoMessage() {}
 
 func (x *ProtoPredefinedState_StatusSwitch) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[85]
+	mi := &file_protobuf_models_proto_msgTypes[91]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5522,7 +6260,7 @@ type ProtoPredefinedState_Account struct {
 func (x *ProtoPredefinedState_Account) Reset() {
 	*x = ProtoPredefinedState_Account{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[86]
+		mi := &file_protobuf_models_proto_msgTypes[92]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5535,7 +6273,7 @@ func (x *ProtoPredefinedState_Account) String() string {
 func (*ProtoPredefinedState_Account) ProtoMessage() {}
 
 func (x *ProtoPredefinedState_Account) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[86]
+	mi := &file_protobuf_models_proto_msgTypes[92]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5623,7 +6361,7 @@ type ProtoPredefinedState_Identity struct {
 func (x *ProtoPredefinedState_Identity) Reset() {
 	*x = ProtoPredefinedState_Identity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[87]
+		mi := &file_protobuf_models_proto_msgTypes[93]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5636,7 +6374,7 @@ func (x *ProtoPredefinedState_Identity) String() string {
 func (*ProtoPredefinedState_Identity) ProtoMessage() {}
 
 func (x *ProtoPredefinedState_Identity) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[87]
+	mi := &file_protobuf_models_proto_msgTypes[93]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5856,7 +6594,7 @@ type ProtoPredefinedState_ApprovedIdentity struct {
 func (x *ProtoPredefinedState_ApprovedIdentity) Reset() {
 	*x = ProtoPredefinedState_ApprovedIdentity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[88]
+		mi := &file_protobuf_models_proto_msgTypes[94]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5869,7 +6607,7 @@ func (x *ProtoPredefinedState_ApprovedIdentity) String() string {
 func (*ProtoPredefinedState_ApprovedIdentity) ProtoMessage() {}
 
 func (x *ProtoPredefinedState_ApprovedIdentity) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[88]
+	mi := &file_protobuf_models_proto_msgTypes[94]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5932,7 +6670,7 @@ type ProtoPredefinedState_ContractKeyValue struct {
 func (x *ProtoPredefinedState_ContractKeyValue) Reset() {
 	*x = ProtoPredefinedState_ContractKeyValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[89]
+		mi := &file_protobuf_models_proto_msgTypes[95]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -5945,7 +6683,7 @@ func (x *ProtoPredefinedState_ContractKeyValue) String() string {
 func (*ProtoPredefinedState_ContractKeyValue) ProtoMessage() {}
 
 func (x *ProtoPredefinedState_ContractKeyValue) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[89]
+	mi := &file_protobuf_models_proto_msgTypes[95]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5987,7 +6725,7 @@ type ProtoPredefinedState_Account_ContractData struct {
 func (x *ProtoPredefinedState_Account_ContractData) Reset() {
 	*x = ProtoPredefinedState_Account_ContractData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[90]
+		mi := &file_protobuf_models_proto_msgTypes[96]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -6000,7 +6738,7 @@ func (x *ProtoPredefinedState_Account_ContractData) String() string {
 func (*ProtoPredefinedState_Account_ContractData) ProtoMessage() {}
 
 func (x *ProtoPredefinedState_Account_ContractData) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[90]
+	mi := &file_protobuf_models_proto_msgTypes[96]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6042,7 +6780,7 @@ type ProtoPredefinedState_Identity_Flip struct {
 func (x *ProtoPredefinedState_Identity_Flip) Reset() {
 	*x = ProtoPredefinedState_Identity_Flip{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[91]
+		mi := &file_protobuf_models_proto_msgTypes[97]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -6055,7 +6793,7 @@ func (x *ProtoPredefinedState_Identity_Flip) String() string {
 func (*ProtoPredefinedState_Identity_Flip) ProtoMessage() {}
 
 func (x *ProtoPredefinedState_Identity_Flip) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[91]
+	mi := &file_protobuf_models_proto_msgTypes[97]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6097,7 +6835,7 @@ type ProtoPredefinedState_Identity_TxAddr struct {
 func (x *ProtoPredefinedState_Identity_TxAddr) Reset() {
 	*x = ProtoPredefinedState_Identity_TxAddr{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[92]
+		mi := &file_protobuf_models_proto_msgTypes[98]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -6110,7 +6848,7 @@ func (x *ProtoPredefinedState_Identity_TxAddr) String() string {
 func (*ProtoPredefinedState_Identity_TxAddr) ProtoMessage() {}
 
 func (x *ProtoPredefinedState_Identity_TxAddr) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[92]
+	mi := &file_protobuf_models_proto_msgTypes[98]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6153,7 +6891,7 @@ type ProtoPredefinedState_Identity_Inviter struct {
 func (x *ProtoPredefinedState_Identity_Inviter) Reset() {
 	*x = ProtoPredefinedState_Identity_Inviter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[93]
+		mi := &file_protobuf_models_proto_msgTypes[99]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -6166,7 +6904,7 @@ func (x *ProtoPredefinedState_Identity_Inviter) String() string {
 func (*ProtoPredefinedState_Identity_Inviter) ProtoMessage() {}
 
 func (x *ProtoPredefinedState_Identity_Inviter) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[93]
+	mi := &file_protobuf_models_proto_msgTypes[99]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6222,7 +6960,7 @@ type ProtoTxReceipts_ProtoTxReceipt struct {
 func (x *ProtoTxReceipts_ProtoTxReceipt) Reset() {
 	*x = ProtoTxReceipts_ProtoTxReceipt{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[94]
+		mi := &file_protobuf_models_proto_msgTypes[100]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -6235,7 +6973,7 @@ func (x *ProtoTxReceipts_ProtoTxReceipt) String() string {
 func (*ProtoTxReceipts_ProtoTxReceipt) ProtoMessage() {}
 
 func (x *ProtoTxReceipts_ProtoTxReceipt) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[94]
+	mi := &file_protobuf_models_proto_msgTypes[100]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6326,7 +7064,7 @@ type ProtoTxReceipts_ProtoEvent struct {
 func (x *ProtoTxReceipts_ProtoEvent) Reset() {
 	*x = ProtoTxReceipts_ProtoEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[95]
+		mi := &file_protobuf_models_proto_msgTypes[101]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -6339,7 +7077,7 @@ func (x *ProtoTxReceipts_ProtoEvent) String() string {
 func (*ProtoTxReceipts_ProtoEvent) ProtoMessage() {}
 
 func (x *ProtoTxReceipts_ProtoEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[95]
+	mi := &file_protobuf_models_proto_msgTypes[101]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6385,7 +7123,7 @@ type ProtoDeferredTxs_ProtoDeferredTx struct {
 func (x *ProtoDeferredTxs_ProtoDeferredTx) Reset() {
 	*x = ProtoDeferredTxs_ProtoDeferredTx{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[96]
+		mi := &file_protobuf_models_proto_msgTypes[102]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -6398,7 +7136,7 @@ func (x *ProtoDeferredTxs_ProtoDeferredTx) String() string {
 func (*ProtoDeferredTxs_ProtoDeferredTx) ProtoMessage() {}
 
 func (x *ProtoDeferredTxs_ProtoDeferredTx) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[96]
+	mi := &file_protobuf_models_proto_msgTypes[102]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6468,7 +7206,7 @@ type ProtoUpgradeVotes_ProtoUpgradeVote struct {
 func (x *ProtoUpgradeVotes_ProtoUpgradeVote) Reset() {
 	*x = ProtoUpgradeVotes_ProtoUpgradeVote{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[97]
+		mi := &file_protobuf_models_proto_msgTypes[103]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -6481,7 +7219,7 @@ func (x *ProtoUpgradeVotes_ProtoUpgradeVote) String() string {
 func (*ProtoUpgradeVotes_ProtoUpgradeVote) ProtoMessage() {}
 
 func (x *ProtoUpgradeVotes_ProtoUpgradeVote) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[97]
+	mi := &file_protobuf_models_proto_msgTypes[103]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6527,7 +7265,7 @@ type ProtoLotteryIdentitiesDb_Identity struct {
 func (x *ProtoLotteryIdentitiesDb_Identity) Reset() {
 	*x = ProtoLotteryIdentitiesDb_Identity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_models_proto_msgTypes[98]
+		mi := &file_protobuf_models_proto_msgTypes[104]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -6540,7 +7278,7 @@ func (x *ProtoLotteryIdentitiesDb_Identity) String() string {
 func (*ProtoLotteryIdentitiesDb_Identity) ProtoMessage() {}
 
 func (x *ProtoLotteryIdentitiesDb_Identity) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_models_proto_msgTypes[98]
+	mi := &file_protobuf_models_proto_msgTypes[104]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6598,6 +7336,116 @@ func (x *ProtoLotteryIdentitiesDb_Identity) GetHasDoneAllRequiredFlips() bool {
 	return false
 }
 
+type ProtoFlipArchive_Flip struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cid  []byte `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ProtoFlipArchive_Flip) Reset() {
+	*x = ProtoFlipArchive_Flip{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_models_proto_msgTypes[105]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoFlipArchive_Flip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoFlipArchive_Flip) ProtoMessage() {}
+
+func (x *ProtoFlipArchive_Flip) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_models_proto_msgTypes[105]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoFlipArchive_Flip.ProtoReflect.Descriptor instead.
+func (*ProtoFlipArchive_Flip) Descriptor() ([]byte, []int) {
+	return file_protobuf_models_proto_rawDescGZIP(), []int{63, 0}
+}
+
+func (x *ProtoFlipArchive_Flip) GetCid() []byte {
+	if x != nil {
+		return x.Cid
+	}
+	return nil
+}
+
+func (x *ProtoFlipArchive_Flip) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ProtoFlipArchive_Record struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ProtoFlipArchive_Record) Reset() {
+	*x = ProtoFlipArchive_Record{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_models_proto_msgTypes[106]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoFlipArchive_Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoFlipArchive_Record) ProtoMessage() {}
+
+func (x *ProtoFlipArchive_Record) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_models_proto_msgTypes[106]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoFlipArchive_Record.ProtoReflect.Descriptor instead.
+func (*ProtoFlipArchive_Record) Descriptor() ([]byte, []int) {
+	return file_protobuf_models_proto_rawDescGZIP(), []int{63, 1}
+}
+
+func (x *ProtoFlipArchive_Record) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *ProtoFlipArchive_Record) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
 var File_protobuf_models_proto protoreflect.FileDescriptor
 
 var file_protobuf_models_proto_rawDesc = []byte{
@@ -7423,7 +8271,146 @@ var file_protobuf_models_proto_rawDesc = []byte{
 	0x44, 0x6f, 0x6e, 0x65, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x46,
 	0x6c, 0x69, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x68, 0x61, 0x73, 0x44,
 	0x6f, 0x6e, 0x65, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x46, 0x6c,
-	0x69, 0x70, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x70, 0x73, 0x22, 0xb3, 0x07, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x65,
+	0x76, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72,
+	0x65, 0x76, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x69, 0x73, 0x73, 0x65,
+	0x64, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d,
+	0x69, 0x73, 0x73, 0x65, 0x64, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x11, 0x73,
+	0x68, 0x6f, 0x72, 0x74, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x54, 0x6f, 0x53, 0x6f, 0x6c, 0x76, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x46, 0x6c, 0x69,
+	0x70, 0x73, 0x54, 0x6f, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x6c, 0x6f, 0x6e,
+	0x67, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x54, 0x6f, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x10, 0x6c, 0x6f, 0x6e, 0x67, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x54, 0x6f,
+	0x53, 0x6f, 0x6c, 0x76, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x73, 0x68, 0x6f, 0x72, 0x74,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x51, 0x75,
+	0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x13, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x6c, 0x6f, 0x6e, 0x67, 0x51, 0x75, 0x61,
+	0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x12, 0x6c, 0x6f, 0x6e, 0x67, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x46, 0x6c, 0x69, 0x70, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x73, 0x68, 0x6f, 0x72, 0x74,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x51, 0x75, 0x61, 0x6c,
+	0x69, 0x66, 0x69, 0x65, 0x64, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x46, 0x6c, 0x69, 0x70, 0x73, 0x12, 0x34, 0x0a, 0x15, 0x6e, 0x6f, 0x51, 0x75, 0x61, 0x6c, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x6e, 0x6f, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x12, 0x32, 0x0a, 0x14, 0x6e,
+	0x6f, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4c, 0x6f, 0x6e, 0x67, 0x46, 0x6c,
+	0x69, 0x70, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x6e, 0x6f, 0x51, 0x75, 0x61,
+	0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4c, 0x6f, 0x6e, 0x67, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x62, 0x61, 0x64, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x15, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x62, 0x61, 0x64, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x28, 0x0a,
+	0x0f, 0x62, 0x61, 0x64, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x62, 0x61, 0x64, 0x41, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x77, 0x61, 0x72,
+	0x64, 0x65, 0x64, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
+	0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x46, 0x6c, 0x69, 0x70, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x18, 0x18, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72,
+	0x64, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x22, 0xb9, 0x02, 0x0a, 0x13, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x46, 0x6c, 0x69, 0x70, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x63, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x02, 0x74, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x50, 0x61, 0x72,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x50,
+	0x61, 0x72, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x50, 0x61,
+	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74,
+	0x65, 0x50, 0x61, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x69, 0x72, 0x49, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x61, 0x69, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x82, 0x03, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x46,
+	0x6c, 0x69, 0x70, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x6c,
+	0x69, 0x70, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0d, 0x66, 0x6c, 0x69, 0x70, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
+	0x12, 0x26, 0x0a, 0x0e, 0x66, 0x6c, 0x69, 0x70, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b,
+	0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x66, 0x6c, 0x69, 0x70, 0x50, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x66, 0x6c, 0x69, 0x70,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
+	0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x46, 0x6c, 0x69, 0x70, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76,
+	0x65, 0x2e, 0x46, 0x6c, 0x69, 0x70, 0x52, 0x05, 0x66, 0x6c, 0x69, 0x70, 0x73, 0x12, 0x39, 0x0a,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x46, 0x6c, 0x69,
+	0x70, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x66, 0x65,
+	0x72, 0x72, 0x65, 0x64, 0x54, 0x78, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x64,
+	0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x54, 0x78, 0x73, 0x1a, 0x2c, 0x0a, 0x04, 0x46, 0x6c,
+	0x69, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x63, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x30, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x5b, 0x0a, 0x19, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x71, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x65, 0x71, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x8f, 0x01, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x65, 0x71, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x65, 0x71, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x63, 0x65,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x61, 0x62, 0x73,
+	0x65, 0x6e, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0xbe, 0x02, 0x0a, 0x0e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x4f, 0x77, 0x6e, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x69,
+	0x6e, 0x76, 0x69, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x6b, 0x69, 0x6c, 0x6c,
+	0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6b, 0x69,
+	0x6c, 0x6c, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x2a, 0x0a, 0x10, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x78,
+	0x48, 0x61, 0x73, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -7438,7 +8425,7 @@ func file_protobuf_models_proto_rawDescGZIP() []byte {
 	return file_protobuf_models_proto_rawDescData
 }
 
-var file_protobuf_models_proto_msgTypes = make([]protoimpl.MessageInfo, 99)
+var file_protobuf_models_proto_msgTypes = make([]protoimpl.MessageInfo, 107)
 var file_protobuf_models_proto_goTypes = []interface{}{
 	(*ProtoTransaction)(nil),                              // 0: models.ProtoTransaction
 	(*ProtoBlockHeader)(nil),                              // 1: models.ProtoBlockHeader
@@ -7501,99 +8488,109 @@ var file_protobuf_models_proto_goTypes = []interface{}{
 	(*ProtoSavedEvent)(nil),                               // 58: models.ProtoSavedEvent
 	(*ProtoUpgradeVotes)(nil),                             // 59: models.ProtoUpgradeVotes
 	(*ProtoLotteryIdentitiesDb)(nil),                      // 60: models.ProtoLotteryIdentitiesDb
-	(*ProtoTransaction_Data)(nil),                         // 61: models.ProtoTransaction.Data
-	(*ProtoBlockHeader_Proposed)(nil),                     // 62: models.ProtoBlockHeader.Proposed
-	(*ProtoBlockHeader_Empty)(nil),                        // 63: models.ProtoBlockHeader.Empty
-	(*ProtoBlockProposal_Data)(nil),                       // 64: models.ProtoBlockProposal.Data
-	(*ProtoBlockCert_Signature)(nil),                      // 65: models.ProtoBlockCert.Signature
-	(*ProtoMsgBatch_BatchItem)(nil),                       // 66: models.ProtoMsgBatch.BatchItem
-	(*ProtoIdentityStateDiff_IdentityStateDiffValue)(nil), // 67: models.ProtoIdentityStateDiff.IdentityStateDiffValue
-	(*ProtoSnapshotBlock_KeyValue)(nil),                   // 68: models.ProtoSnapshotBlock.KeyValue
-	(*ProtoSnapshotNodes_Node)(nil),                       // 69: models.ProtoSnapshotNodes.Node
-	(*ProtoGossipBlockRange_Block)(nil),                   // 70: models.ProtoGossipBlockRange.Block
-	(*ProtoProposeProof_Data)(nil),                        // 71: models.ProtoProposeProof.Data
-	(*ProtoVote_Data)(nil),                                // 72: models.ProtoVote.Data
-	(*ProtoFlipKey_Data)(nil),                             // 73: models.ProtoFlipKey.Data
-	(*ProtoPrivateFlipKeysPackage_Data)(nil),              // 74: models.ProtoPrivateFlipKeysPackage.Data
-	(*ProtoAnswersDb_Answer)(nil),                         // 75: models.ProtoAnswersDb.Answer
-	(*ProtoActivityMonitor_Activity)(nil),                 // 76: models.ProtoActivityMonitor.Activity
-	(*ProtoStateAccount_ProtoContractData)(nil),           // 77: models.ProtoStateAccount.ProtoContractData
-	(*ProtoStateIdentity_Flip)(nil),                       // 78: models.ProtoStateIdentity.Flip
-	(*ProtoStateIdentity_TxAddr)(nil),                     // 79: models.ProtoStateIdentity.TxAddr
-	(*ProtoStateIdentity_Inviter)(nil),                    // 80: models.ProtoStateIdentity.Inviter
-	(*ProtoStateGlobal_EmptyBlocksByShards)(nil),          // 81: models.ProtoStateGlobal.EmptyBlocksByShards
-	(*ProtoStateGlobal_ShardSize)(nil),                    // 82: models.ProtoStateGlobal.ShardSize
-	(*ProtoStateDelegationSwitch_Delegation)(nil),         // 83: models.ProtoStateDelegationSwitch.Delegation
-	(*ProtoPredefinedState_Global)(nil),                   // 84: models.ProtoPredefinedState.Global
-	(*ProtoPredefinedState_StatusSwitch)(nil),             // 85: models.ProtoPredefinedState.StatusSwitch
-	(*ProtoPredefinedState_Account)(nil),                  // 86: models.ProtoPredefinedState.Account
-	(*ProtoPredefinedState_Identity)(nil),                 // 87: models.ProtoPredefinedState.Identity
-	(*ProtoPredefinedState_ApprovedIdentity)(nil),         // 88: models.ProtoPredefinedState.ApprovedIdentity
-	(*ProtoPredefinedState_ContractKeyValue)(nil),         // 89: models.ProtoPredefinedState.ContractKeyValue
-	(*ProtoPredefinedState_Account_ContractData)(nil),     // 90: models.ProtoPredefinedState.Account.ContractData
-	(*ProtoPredefinedState_Identity_Flip)(nil),            // 91: models.ProtoPredefinedState.Identity.Flip
-	(*ProtoPredefinedState_Identity_TxAddr)(nil),          // 92: models.ProtoPredefinedState.Identity.TxAddr
-	(*ProtoPredefinedState_Identity_Inviter)(nil),         // 93: models.ProtoPredefinedState.Identity.Inviter
-	(*ProtoTxReceipts_ProtoTxReceipt)(nil),                // 94: models.ProtoTxReceipts.ProtoTxReceipt
-	(*ProtoTxReceipts_ProtoEvent)(nil),                    // 95: models.ProtoTxReceipts.ProtoEvent
-	(*ProtoDeferredTxs_ProtoDeferredTx)(nil),              // 96: models.ProtoDeferredTxs.ProtoDeferredTx
-	(*ProtoUpgradeVotes_ProtoUpgradeVote)(nil),            // 97: models.ProtoUpgradeVotes.ProtoUpgradeVote
-	(*ProtoLotteryIdentitiesDb_Identity)(nil),             // 98: models.ProtoLotteryIdentitiesDb.Identity
+	(*ProtoValidationReport)(nil),                         // 61: models.ProtoValidationReport
+	(*ProtoFlipSubmission)(nil),                           // 62: models.ProtoFlipSubmission
+	(*ProtoFlipArchive)(nil),                              // 63: models.ProtoFlipArchive
+	(*ProtoGetStateProofRequest)(nil),                     // 64: models.ProtoGetStateProofRequest
+	(*ProtoStateProof)(nil),                               // 65: models.ProtoStateProof
+	(*ProtoOwnInvite)(nil),                                // 66: models.ProtoOwnInvite
+	(*ProtoTransaction_Data)(nil),                         // 67: models.ProtoTransaction.Data
+	(*ProtoBlockHeader_Proposed)(nil),                     // 68: models.ProtoBlockHeader.Proposed
+	(*ProtoBlockHeader_Empty)(nil),                        // 69: models.ProtoBlockHeader.Empty
+	(*ProtoBlockProposal_Data)(nil),                       // 70: models.ProtoBlockProposal.Data
+	(*ProtoBlockCert_Signature)(nil),                      // 71: models.ProtoBlockCert.Signature
+	(*ProtoMsgBatch_BatchItem)(nil),                       // 72: models.ProtoMsgBatch.BatchItem
+	(*ProtoIdentityStateDiff_IdentityStateDiffValue)(nil), // 73: models.ProtoIdentityStateDiff.IdentityStateDiffValue
+	(*ProtoSnapshotBlock_KeyValue)(nil),                   // 74: models.ProtoSnapshotBlock.KeyValue
+	(*ProtoSnapshotNodes_Node)(nil),                       // 75: models.ProtoSnapshotNodes.Node
+	(*ProtoGossipBlockRange_Block)(nil),                   // 76: models.ProtoGossipBlockRange.Block
+	(*ProtoProposeProof_Data)(nil),                        // 77: models.ProtoProposeProof.Data
+	(*ProtoVote_Data)(nil),                                // 78: models.ProtoVote.Data
+	(*ProtoFlipKey_Data)(nil),                             // 79: models.ProtoFlipKey.Data
+	(*ProtoPrivateFlipKeysPackage_Data)(nil),              // 80: models.ProtoPrivateFlipKeysPackage.Data
+	(*ProtoAnswersDb_Answer)(nil),                         // 81: models.ProtoAnswersDb.Answer
+	(*ProtoActivityMonitor_Activity)(nil),                 // 82: models.ProtoActivityMonitor.Activity
+	(*ProtoStateAccount_ProtoContractData)(nil),           // 83: models.ProtoStateAccount.ProtoContractData
+	(*ProtoStateIdentity_Flip)(nil),                       // 84: models.ProtoStateIdentity.Flip
+	(*ProtoStateIdentity_TxAddr)(nil),                     // 85: models.ProtoStateIdentity.TxAddr
+	(*ProtoStateIdentity_Inviter)(nil),                    // 86: models.ProtoStateIdentity.Inviter
+	(*ProtoStateGlobal_EmptyBlocksByShards)(nil),          // 87: models.ProtoStateGlobal.EmptyBlocksByShards
+	(*ProtoStateGlobal_ShardSize)(nil),                    // 88: models.ProtoStateGlobal.ShardSize
+	(*ProtoStateDelegationSwitch_Delegation)(nil),         // 89: models.ProtoStateDelegationSwitch.Delegation
+	(*ProtoPredefinedState_Global)(nil),                   // 90: models.ProtoPredefinedState.Global
+	(*ProtoPredefinedState_StatusSwitch)(nil),             // 91: models.ProtoPredefinedState.StatusSwitch
+	(*ProtoPredefinedState_Account)(nil),                  // 92: models.ProtoPredefinedState.Account
+	(*ProtoPredefinedState_Identity)(nil),                 // 93: models.ProtoPredefinedState.Identity
+	(*ProtoPredefinedState_ApprovedIdentity)(nil),         // 94: models.ProtoPredefinedState.ApprovedIdentity
+	(*ProtoPredefinedState_ContractKeyValue)(nil),         // 95: models.ProtoPredefinedState.ContractKeyValue
+	(*ProtoPredefinedState_Account_ContractData)(nil),     // 96: models.ProtoPredefinedState.Account.ContractData
+	(*ProtoPredefinedState_Identity_Flip)(nil),            // 97: models.ProtoPredefinedState.Identity.Flip
+	(*ProtoPredefinedState_Identity_TxAddr)(nil),          // 98: models.ProtoPredefinedState.Identity.TxAddr
+	(*ProtoPredefinedState_Identity_Inviter)(nil),         // 99: models.ProtoPredefinedState.Identity.Inviter
+	(*ProtoTxReceipts_ProtoTxReceipt)(nil),                // 100: models.ProtoTxReceipts.ProtoTxReceipt
+	(*ProtoTxReceipts_ProtoEvent)(nil),                    // 101: models.ProtoTxReceipts.ProtoEvent
+	(*ProtoDeferredTxs_ProtoDeferredTx)(nil),              // 102: models.ProtoDeferredTxs.ProtoDeferredTx
+	(*ProtoUpgradeVotes_ProtoUpgradeVote)(nil),            // 103: models.ProtoUpgradeVotes.ProtoUpgradeVote
+	(*ProtoLotteryIdentitiesDb_Identity)(nil),             // 104: models.ProtoLotteryIdentitiesDb.Identity
+	(*ProtoFlipArchive_Flip)(nil),                         // 105: models.ProtoFlipArchive.Flip
+	(*ProtoFlipArchive_Record)(nil),                       // 106: models.ProtoFlipArchive.Record
 }
 var file_protobuf_models_proto_depIdxs = []int32{
-	61, // 0: models.ProtoTransaction.data:type_name -> models.ProtoTransaction.Data
-	62, // 1: models.ProtoBlockHeader.proposedHeader:type_name -> models.ProtoBlockHeader.Proposed
-	63, // 2: models.ProtoBlockHeader.emptyHeader:type_name -> models.ProtoBlockHeader.Empty
-	0,  // 3: models.ProtoBlockBody.transactions:type_name -> models.ProtoTransaction
-	1,  // 4: models.ProtoBlock.header:type_name -> models.ProtoBlockHeader
-	2,  // 5: models.ProtoBlock.body:type_name -> models.ProtoBlockBody
-	64, // 6: models.ProtoBlockProposal.data:type_name -> models.ProtoBlockProposal.Data
-	65, // 7: models.ProtoBlockCert.signatures:type_name -> models.ProtoBlockCert.Signature
-	66, // 8: models.ProtoMsgBatch.data:type_name -> models.ProtoMsgBatch.BatchItem
-	67, // 9: models.ProtoIdentityStateDiff.values:type_name -> models.ProtoIdentityStateDiff.IdentityStateDiffValue
-	68, // 10: models.ProtoSnapshotBlock.data:type_name -> models.ProtoSnapshotBlock.KeyValue
-	69, // 11: models.ProtoSnapshotNodes.nodes:type_name -> models.ProtoSnapshotNodes.Node
-	70, // 12: models.ProtoGossipBlockRange.blocks:type_name -> models.ProtoGossipBlockRange.Block
-	71, // 13: models.ProtoProposeProof.data:type_name -> models.ProtoProposeProof.Data
-	72, // 14: models.ProtoVote.data:type_name -> models.ProtoVote.Data
-	0,  // 15: models.ProtoFlip.transaction:type_name -> models.ProtoTransaction
-	73, // 16: models.ProtoFlipKey.data:type_name -> models.ProtoFlipKey.Data
-	74, // 17: models.ProtoPrivateFlipKeysPackage.data:type_name -> models.ProtoPrivateFlipKeysPackage.Data
-	75, // 18: models.ProtoAnswersDb.answers:type_name -> models.ProtoAnswersDb.Answer
-	0,  // 19: models.ProtoSavedTransaction.tx:type_name -> models.ProtoTransaction
-	76, // 20: models.ProtoActivityMonitor.activities:type_name -> models.ProtoActivityMonitor.Activity
-	77, // 21: models.ProtoStateAccount.contractData:type_name -> models.ProtoStateAccount.ProtoContractData
-	78, // 22: models.ProtoStateIdentity.flips:type_name -> models.ProtoStateIdentity.Flip
-	79, // 23: models.ProtoStateIdentity.invitees:type_name -> models.ProtoStateIdentity.TxAddr
-	80, // 24: models.ProtoStateIdentity.inviter:type_name -> models.ProtoStateIdentity.Inviter
-	81, // 25: models.ProtoStateGlobal.emptyBlocksByShards:type_name -> models.ProtoStateGlobal.EmptyBlocksByShards
-	82, // 26: models.ProtoStateGlobal.shardSizes:type_name -> models.ProtoStateGlobal.ShardSize
-	83, // 27: models.ProtoStateDelegationSwitch.delegations:type_name -> models.ProtoStateDelegationSwitch.Delegation
-	84, // 28: models.ProtoPredefinedState.global:type_name -> models.ProtoPredefinedState.Global
-	85, // 29: models.ProtoPredefinedState.statusSwitch:type_name -> models.ProtoPredefinedState.StatusSwitch
-	86, // 30: models.ProtoPredefinedState.accounts:type_name -> models.ProtoPredefinedState.Account
-	87, // 31: models.ProtoPredefinedState.identities:type_name -> models.ProtoPredefinedState.Identity
-	88, // 32: models.ProtoPredefinedState.approvedIdentities:type_name -> models.ProtoPredefinedState.ApprovedIdentity
-	89, // 33: models.ProtoPredefinedState.contractValues:type_name -> models.ProtoPredefinedState.ContractKeyValue
-	94, // 34: models.ProtoTxReceipts.receipts:type_name -> models.ProtoTxReceipts.ProtoTxReceipt
-	96, // 35: models.ProtoDeferredTxs.Txs:type_name -> models.ProtoDeferredTxs.ProtoDeferredTx
-	97, // 36: models.ProtoUpgradeVotes.votes:type_name -> models.ProtoUpgradeVotes.ProtoUpgradeVote
-	98, // 37: models.ProtoLotteryIdentitiesDb.identities:type_name -> models.ProtoLotteryIdentitiesDb.Identity
-	1,  // 38: models.ProtoBlockProposal.Data.header:type_name -> models.ProtoBlockHeader
-	2,  // 39: models.ProtoBlockProposal.Data.body:type_name -> models.ProtoBlockBody
-	1,  // 40: models.ProtoGossipBlockRange.Block.header:type_name -> models.ProtoBlockHeader
-	6,  // 41: models.ProtoGossipBlockRange.Block.cert:type_name -> models.ProtoBlockCert
-	16, // 42: models.ProtoGossipBlockRange.Block.diff:type_name -> models.ProtoIdentityStateDiff
-	90, // 43: models.ProtoPredefinedState.Account.contractData:type_name -> models.ProtoPredefinedState.Account.ContractData
-	91, // 44: models.ProtoPredefinedState.Identity.flips:type_name -> models.ProtoPredefinedState.Identity.Flip
-	92, // 45: models.ProtoPredefinedState.Identity.invitees:type_name -> models.ProtoPredefinedState.Identity.TxAddr
-	93, // 46: models.ProtoPredefinedState.Identity.inviter:type_name -> models.ProtoPredefinedState.Identity.Inviter
-	95, // 47: models.ProtoTxReceipts.ProtoTxReceipt.events:type_name -> models.ProtoTxReceipts.ProtoEvent
-	48, // [48:48] is the sub-list for method output_type
-	48, // [48:48] is the sub-list for method input_type
-	48, // [48:48] is the sub-list for extension type_name
-	48, // [48:48] is the sub-list for extension extendee
-	0,  // [0:48] is the sub-list for field type_name
+	67,  // 0: models.ProtoTransaction.data:type_name -> models.ProtoTransaction.Data
+	68,  // 1: models.ProtoBlockHeader.proposedHeader:type_name -> models.ProtoBlockHeader.Proposed
+	69,  // 2: models.ProtoBlockHeader.emptyHeader:type_name -> models.ProtoBlockHeader.Empty
+	0,   // 3: models.ProtoBlockBody.transactions:type_name -> models.ProtoTransaction
+	1,   // 4: models.ProtoBlock.header:type_name -> models.ProtoBlockHeader
+	2,   // 5: models.ProtoBlock.body:type_name -> models.ProtoBlockBody
+	70,  // 6: models.ProtoBlockProposal.data:type_name -> models.ProtoBlockProposal.Data
+	71,  // 7: models.ProtoBlockCert.signatures:type_name -> models.ProtoBlockCert.Signature
+	72,  // 8: models.ProtoMsgBatch.data:type_name -> models.ProtoMsgBatch.BatchItem
+	73,  // 9: models.ProtoIdentityStateDiff.values:type_name -> models.ProtoIdentityStateDiff.IdentityStateDiffValue
+	74,  // 10: models.ProtoSnapshotBlock.data:type_name -> models.ProtoSnapshotBlock.KeyValue
+	75,  // 11: models.ProtoSnapshotNodes.nodes:type_name -> models.ProtoSnapshotNodes.Node
+	76,  // 12: models.ProtoGossipBlockRange.blocks:type_name -> models.ProtoGossipBlockRange.Block
+	77,  // 13: models.ProtoProposeProof.data:type_name -> models.ProtoProposeProof.Data
+	78,  // 14: models.ProtoVote.data:type_name -> models.ProtoVote.Data
+	0,   // 15: models.ProtoFlip.transaction:type_name -> models.ProtoTransaction
+	79,  // 16: models.ProtoFlipKey.data:type_name -> models.ProtoFlipKey.Data
+	80,  // 17: models.ProtoPrivateFlipKeysPackage.data:type_name -> models.ProtoPrivateFlipKeysPackage.Data
+	81,  // 18: models.ProtoAnswersDb.answers:type_name -> models.ProtoAnswersDb.Answer
+	0,   // 19: models.ProtoSavedTransaction.tx:type_name -> models.ProtoTransaction
+	82,  // 20: models.ProtoActivityMonitor.activities:type_name -> models.ProtoActivityMonitor.Activity
+	83,  // 21: models.ProtoStateAccount.contractData:type_name -> models.ProtoStateAccount.ProtoContractData
+	84,  // 22: models.ProtoStateIdentity.flips:type_name -> models.ProtoStateIdentity.Flip
+	85,  // 23: models.ProtoStateIdentity.invitees:type_name -> models.ProtoStateIdentity.TxAddr
+	86,  // 24: models.ProtoStateIdentity.inviter:type_name -> models.ProtoStateIdentity.Inviter
+	87,  // 25: models.ProtoStateGlobal.emptyBlocksByShards:type_name -> models.ProtoStateGlobal.EmptyBlocksByShards
+	88,  // 26: models.ProtoStateGlobal.shardSizes:type_name -> models.ProtoStateGlobal.ShardSize
+	89,  // 27: models.ProtoStateDelegationSwitch.delegations:type_name -> models.ProtoStateDelegationSwitch.Delegation
+	90,  // 28: models.ProtoPredefinedState.global:type_name -> models.ProtoPredefinedState.Global
+	91,  // 29: models.ProtoPredefinedState.statusSwitch:type_name -> models.ProtoPredefinedState.StatusSwitch
+	92,  // 30: models.ProtoPredefinedState.accounts:type_name -> models.ProtoPredefinedState.Account
+	93,  // 31: models.ProtoPredefinedState.identities:type_name -> models.ProtoPredefinedState.Identity
+	94,  // 32: models.ProtoPredefinedState.approvedIdentities:type_name -> models.ProtoPredefinedState.ApprovedIdentity
+	95,  // 33: models.ProtoPredefinedState.contractValues:type_name -> models.ProtoPredefinedState.ContractKeyValue
+	100, // 34: models.ProtoTxReceipts.receipts:type_name -> models.ProtoTxReceipts.ProtoTxReceipt
+	102, // 35: models.ProtoDeferredTxs.Txs:type_name -> models.ProtoDeferredTxs.ProtoDeferredTx
+	103, // 36: models.ProtoUpgradeVotes.votes:type_name -> models.ProtoUpgradeVotes.ProtoUpgradeVote
+	104, // 37: models.ProtoLotteryIdentitiesDb.identities:type_name -> models.ProtoLotteryIdentitiesDb.Identity
+	105, // 38: models.ProtoFlipArchive.flips:type_name -> models.ProtoFlipArchive.Flip
+	106, // 39: models.ProtoFlipArchive.records:type_name -> models.ProtoFlipArchive.Record
+	1,   // 40: models.ProtoBlockProposal.Data.header:type_name -> models.ProtoBlockHeader
+	2,   // 41: models.ProtoBlockProposal.Data.body:type_name -> models.ProtoBlockBody
+	1,   // 42: models.ProtoGossipBlockRange.Block.header:type_name -> models.ProtoBlockHeader
+	6,   // 43: models.ProtoGossipBlockRange.Block.cert:type_name -> models.ProtoBlockCert
+	16,  // 44: models.ProtoGossipBlockRange.Block.diff:type_name -> models.ProtoIdentityStateDiff
+	96,  // 45: models.ProtoPredefinedState.Account.contractData:type_name -> models.ProtoPredefinedState.Account.ContractData
+	97,  // 46: models.ProtoPredefinedState.Identity.flips:type_name -> models.ProtoPredefinedState.Identity.Flip
+	98,  // 47: models.ProtoPredefinedState.Identity.invitees:type_name -> models.ProtoPredefinedState.Identity.TxAddr
+	99,  // 48: models.ProtoPredefinedState.Identity.inviter:type_name -> models.ProtoPredefinedState.Identity.Inviter
+	101, // 49: models.ProtoTxReceipts.ProtoTxReceipt.events:type_name -> models.ProtoTxReceipts.ProtoEvent
+	50,  // [50:50] is the sub-list for method output_type
+	50,  // [50:50] is the sub-list for method input_type
+	50,  // [50:50] is the sub-list for extension type_name
+	50,  // [50:50] is the sub-list for extension extendee
+	0,   // [0:50] is the sub-list for field type_name
 }
 
 func init() { file_protobuf_models_proto_init() }
@@ -8335,7 +9332,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoTransaction_Data); i {
+			switch v := v.(*ProtoValidationReport); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8347,7 +9344,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoBlockHeader_Proposed); i {
+			switch v := v.(*ProtoFlipSubmission); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8359,7 +9356,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoBlockHeader_Empty); i {
+			switch v := v.(*ProtoFlipArchive); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8371,7 +9368,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoBlockProposal_Data); i {
+			switch v := v.(*ProtoGetStateProofRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8383,7 +9380,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoBlockCert_Signature); i {
+			switch v := v.(*ProtoStateProof); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8395,7 +9392,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoMsgBatch_BatchItem); i {
+			switch v := v.(*ProtoOwnInvite); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8407,7 +9404,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoIdentityStateDiff_IdentityStateDiffValue); i {
+			switch v := v.(*ProtoTransaction_Data); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8419,7 +9416,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoSnapshotBlock_KeyValue); i {
+			switch v := v.(*ProtoBlockHeader_Proposed); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8431,7 +9428,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoSnapshotNodes_Node); i {
+			switch v := v.(*ProtoBlockHeader_Empty); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8443,7 +9440,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoGossipBlockRange_Block); i {
+			switch v := v.(*ProtoBlockProposal_Data); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8455,7 +9452,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoProposeProof_Data); i {
+			switch v := v.(*ProtoBlockCert_Signature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8467,7 +9464,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoVote_Data); i {
+			switch v := v.(*ProtoMsgBatch_BatchItem); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8479,7 +9476,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoFlipKey_Data); i {
+			switch v := v.(*ProtoIdentityStateDiff_IdentityStateDiffValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8491,7 +9488,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPrivateFlipKeysPackage_Data); i {
+			switch v := v.(*ProtoSnapshotBlock_KeyValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8503,7 +9500,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoAnswersDb_Answer); i {
+			switch v := v.(*ProtoSnapshotNodes_Node); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8515,7 +9512,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoActivityMonitor_Activity); i {
+			switch v := v.(*ProtoGossipBlockRange_Block); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8527,7 +9524,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoStateAccount_ProtoContractData); i {
+			switch v := v.(*ProtoProposeProof_Data); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8539,7 +9536,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoStateIdentity_Flip); i {
+			switch v := v.(*ProtoVote_Data); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8551,7 +9548,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoStateIdentity_TxAddr); i {
+			switch v := v.(*ProtoFlipKey_Data); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8563,7 +9560,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoStateIdentity_Inviter); i {
+			switch v := v.(*ProtoPrivateFlipKeysPackage_Data); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8575,7 +9572,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoStateGlobal_EmptyBlocksByShards); i {
+			switch v := v.(*ProtoAnswersDb_Answer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8587,7 +9584,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoStateGlobal_ShardSize); i {
+			switch v := v.(*ProtoActivityMonitor_Activity); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8599,7 +9596,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoStateDelegationSwitch_Delegation); i {
+			switch v := v.(*ProtoStateAccount_ProtoContractData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8611,7 +9608,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPredefinedState_Global); i {
+			switch v := v.(*ProtoStateIdentity_Flip); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8623,7 +9620,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPredefinedState_StatusSwitch); i {
+			switch v := v.(*ProtoStateIdentity_TxAddr); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8635,7 +9632,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPredefinedState_Account); i {
+			switch v := v.(*ProtoStateIdentity_Inviter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8647,7 +9644,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[87].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPredefinedState_Identity); i {
+			switch v := v.(*ProtoStateGlobal_EmptyBlocksByShards); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8659,7 +9656,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[88].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPredefinedState_ApprovedIdentity); i {
+			switch v := v.(*ProtoStateGlobal_ShardSize); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8671,7 +9668,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[89].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPredefinedState_ContractKeyValue); i {
+			switch v := v.(*ProtoStateDelegationSwitch_Delegation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8683,7 +9680,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[90].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPredefinedState_Account_ContractData); i {
+			switch v := v.(*ProtoPredefinedState_Global); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8695,7 +9692,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[91].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPredefinedState_Identity_Flip); i {
+			switch v := v.(*ProtoPredefinedState_StatusSwitch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8707,7 +9704,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[92].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPredefinedState_Identity_TxAddr); i {
+			switch v := v.(*ProtoPredefinedState_Account); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8719,7 +9716,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[93].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoPredefinedState_Identity_Inviter); i {
+			switch v := v.(*ProtoPredefinedState_Identity); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8731,7 +9728,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[94].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoTxReceipts_ProtoTxReceipt); i {
+			switch v := v.(*ProtoPredefinedState_ApprovedIdentity); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8743,7 +9740,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[95].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoTxReceipts_ProtoEvent); i {
+			switch v := v.(*ProtoPredefinedState_ContractKeyValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8755,7 +9752,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[96].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoDeferredTxs_ProtoDeferredTx); i {
+			switch v := v.(*ProtoPredefinedState_Account_ContractData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8767,7 +9764,7 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[97].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProtoUpgradeVotes_ProtoUpgradeVote); i {
+			switch v := v.(*ProtoPredefinedState_Identity_Flip); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -8779,6 +9776,78 @@ func file_protobuf_models_proto_init() {
 			}
 		}
 		file_protobuf_models_proto_msgTypes[98].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoPredefinedState_Identity_TxAddr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_models_proto_msgTypes[99].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoPredefinedState_Identity_Inviter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_models_proto_msgTypes[100].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoTxReceipts_ProtoTxReceipt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_models_proto_msgTypes[101].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoTxReceipts_ProtoEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_models_proto_msgTypes[102].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoDeferredTxs_ProtoDeferredTx); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_models_proto_msgTypes[103].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoUpgradeVotes_ProtoUpgradeVote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_models_proto_msgTypes[104].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ProtoLotteryIdentitiesDb_Identity); i {
 			case 0:
 				return &v.state
@@ -8790,6 +9859,30 @@ func file_protobuf_models_proto_init() {
 				return nil
 			}
 		}
+		file_protobuf_models_proto_msgTypes[105].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoFlipArchive_Flip); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_models_proto_msgTypes[106].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoFlipArchive_Record); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -8797,7 +9890,7 @@ func file_protobuf_models_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protobuf_models_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   99,
+			NumMessages:   107,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
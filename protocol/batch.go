@@ -6,6 +6,8 @@ import (
 	"github.com/idena-network/idena-go/core/state"
 	models "github.com/idena-network/idena-go/protobuf"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"sync/atomic"
+	"time"
 )
 
 type batch struct {
@@ -13,6 +15,15 @@ type batch struct {
 	from    uint64
 	to      uint64
 	headers chan *block
+	// closed when all headers have been received, nil for batches which are not tracked by rangeStats
+	loaded      chan struct{}
+	requestTime time.Time
+	settled     uint32
+}
+
+// settle returns true only for the first call, it is used to account the batch in rangeStats exactly once
+func (b *batch) settle() bool {
+	return atomic.CompareAndSwapUint32(&b.settled, 0, 1)
 }
 
 type block struct {
@@ -27,6 +27,8 @@ const (
 	BatchPush         = 0x12
 	BatchFlipKey      = 0x13
 	Disconnect        = 0x14
+	GetStateProof     = 0x15
+	StateProof        = 0x16
 )
 
 var batchSupportVersion *semver.Version
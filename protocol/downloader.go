@@ -26,6 +26,10 @@ import (
 
 const (
 	MaxAttemptsCountPerBatch = 10
+	// max number of requested batches waiting for applying
+	MaxPendingBatches = 16
+	// time after batch request when the batch is reassigned to another peer if it is still not loaded
+	BatchLoadingTimeout = 40 * time.Second
 )
 
 var (
@@ -150,7 +154,7 @@ func (d *Downloader) SyncBlockchain(forkResolver ForkResolver) error {
 			return errors.New("all connected peers are in fork")
 		}
 
-		head := d.chain.Head
+		head := d.syncedHead()
 		d.top = getTopHeight(knownHeights)
 		if head.Height() >= d.top {
 			d.log.Info(fmt.Sprintf("Node is synchronized"))
@@ -164,6 +168,14 @@ func (d *Downloader) SyncBlockchain(forkResolver ForkResolver) error {
 	}
 }
 
+// syncedHead returns the head of headers chain in light mode and the chain head otherwise
+func (d *Downloader) syncedHead() *types.Header {
+	if d.cfg.Sync.LightMode && d.chain.PreliminaryHead != nil {
+		return d.chain.PreliminaryHead
+	}
+	return d.chain.Head
+}
+
 func (d *Downloader) Load() {
 
 	head := d.chain.Head
@@ -178,36 +190,53 @@ func (d *Downloader) Load() {
 		return
 	}
 
-	d.batches = make(chan *batch, 10)
+	d.batches = make(chan *batch, MaxPendingBatches)
 	term := make(chan interface{})
 	completed := make(chan interface{})
 	go d.consumeBlocks(applier, term, completed)
 
 	knownHeights := d.pm.GetKnownHeights()
 loop:
-	for from <= toHeight && len(knownHeights) > 0 {
-		for peer, height := range knownHeights {
+	for from <= toHeight {
+		for peerId, height := range knownHeights {
 			if height < from {
-				delete(knownHeights, peer)
-				continue
+				delete(knownHeights, peerId)
 			}
-			to := math.Min(from+applier.batchSize(), math.Min(toHeight, height))
-			if batch, err := d.pm.GetBlocksRange(peer, from, to); err != nil {
-				delete(knownHeights, peer)
+		}
+		if len(knownHeights) == 0 {
+			break
+		}
+		peerId, ok := d.pm.rangeStats.bestPeer(knownHeights, from, "")
+		if !ok {
+			// all peers are busy with previous batches
+			select {
+			case <-time.After(200 * time.Millisecond):
 				continue
-			} else {
-				select {
-				case d.batches <- batch:
-				case <-term:
-					break loop
-				}
+			case <-term:
+				break loop
 			}
-			from = to + 1
 		}
+		size := d.pm.rangeStats.batchSize(peerId, applier.batchSize())
+		to := math.Min(from+size, math.Min(toHeight, knownHeights[peerId]))
+		batch, err := d.pm.GetBlocksRange(peerId, from, to)
+		if err != nil {
+			delete(knownHeights, peerId)
+			continue
+		}
+		select {
+		case d.batches <- batch:
+		case <-term:
+			d.pm.releaseBatch(batch)
+			break loop
+		}
+		from = to + 1
 	}
 	d.log.Info("All blocks were requested. Wait for applying of blocks")
 	close(completed)
 	<-term
+	for len(d.batches) > 0 {
+		d.pm.releaseBatch(<-d.batches)
+	}
 	if err := applier.postConsuming(); err != nil {
 		d.log.Error("Post consuming error", "err", err)
 		time.Sleep(5 * time.Second)
@@ -226,6 +255,11 @@ func (d *Downloader) consumeBlocks(applier blockApplier, term chan interface{},
 			}
 		}
 
+		if batch = d.waitBatchLoading(batch); batch == nil {
+			d.log.Warn("failed to process batch", "err", "no peers")
+			return true
+		}
+
 		if err := applier.processBatch(batch, 1); err != nil {
 			d.log.Warn("failed to process batch", "err", err)
 			return true
@@ -259,6 +293,26 @@ func (d *Downloader) consumeBlocks(applier blockApplier, term chan interface{},
 	}
 }
 
+// waitBatchLoading waits until the batch is loaded and reassigns it to another peer if the current one is too slow
+func (d *Downloader) waitBatchLoading(b *batch) *batch {
+	if b.loaded == nil {
+		return b
+	}
+	select {
+	case <-b.loaded:
+		return b
+	case <-time.After(time.Until(b.requestTime.Add(BatchLoadingTimeout))):
+	}
+	if b.settle() {
+		d.pm.rangeStats.failed(b.p.id)
+	}
+	d.log.Warn("Batch is not loaded in time, reassign it", "from", b.from, "to", b.to, "peer", b.p.id)
+	if b.p.addTimeout() {
+		d.BanPeer(b.p.id, BanReasonTimeout)
+	}
+	return requestBatch(d.pm, b.from, b.to, b.p.id)
+}
+
 func (d *Downloader) SeekBlocks(fromBlock, toBlock uint64, peers []peer.ID) chan *types.BlockBundle {
 	return NewFullSync(d.pm, d.log, d.chain, d.ipfs, d.appState, d.potentialForkedPeers, 0, d.statsCollector).SeekBlocks(fromBlock, toBlock, peers)
 }
@@ -281,6 +335,11 @@ func (d *Downloader) ClearPotentialForks() {
 
 func (d *Downloader) createBlockApplier() (loader blockApplier, toHeight uint64) {
 
+	if d.cfg.Sync.LightMode {
+		d.log.Info("Light sync will be used")
+		return NewLightSync(d.pm, d.log, d.chain, d.ipfs, d.appState, d.potentialForkedPeers, d.sm, d.bus, d.secStore.GetAddress(), d.keyStore, d.subManager, d.upgrader), d.top
+	}
+
 	canUseFastSync := d.cfg.Sync.FastSync
 
 	if d.top-d.chain.Head.Height() < d.cfg.Sync.ForceFullSync {
@@ -318,7 +377,12 @@ func (d *Downloader) getBestManifest() *snapshot.Manifest {
 	}
 
 	var best *snapshot.Manifest
-	for _, m := range manifests {
+	for peerId, m := range manifests {
+		if err := d.chain.ValidateCheckpointRoot(m.Height, m.Root); err != nil {
+			d.log.Warn("Snapshot manifest conflicts with trusted checkpoint", "peer", peerId, "err", err)
+			d.BanPeer(peerId, err)
+			continue
+		}
 		if (best == nil || best.Height < m.Height) && !d.sm.IsInvalidManifest(m.CidV2) {
 			best = m
 		}
@@ -355,6 +419,17 @@ func requestBatch(pm *IdenaGossipHandler, from, to uint64, ignoredPeer peer.ID)
 	if knownHeights == nil {
 		return nil
 	}
+	for {
+		peerId, ok := pm.rangeStats.bestPeer(knownHeights, to, ignoredPeer)
+		if !ok {
+			break
+		}
+		if batch, err := pm.GetBlocksRange(peerId, from, to); err == nil {
+			return batch
+		}
+		delete(knownHeights, peerId)
+	}
+	// all suitable peers are busy, request the batch from any of them
 	for peerId, height := range knownHeights {
 		if (peerId != ignoredPeer || len(knownHeights) == 1) && height >= to {
 			if batch, err := pm.GetBlocksRange(peerId, from, to); err != nil {
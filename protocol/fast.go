@@ -45,6 +45,7 @@ type fastSync struct {
 	subManager           *subscriptions.Manager
 	upgrader             *upgrade.Upgrader
 	prevConfig           *config.ConsensusConf
+	headersOnly          bool
 
 	pubKeyToAddrCache map[string]common.Address
 }
@@ -236,7 +237,7 @@ func (fs *fastSync) GetTxReceipts(receiptCid []byte) (types.TxReceipts, error) {
 }
 
 func (fs *fastSync) processBatch(batch *batch, attemptNum int) error {
-	if fs.manifest == nil {
+	if fs.manifest == nil && !fs.headersOnly {
 		panic("manifest is required")
 	}
 	fs.log.Info("Start process batch", "from", batch.from, "to", batch.to)
@@ -282,6 +283,7 @@ func (fs *fastSync) processBatch(batch *batch, attemptNum int) error {
 
 		case <-timeout:
 			fs.log.Warn("process batch - timeout was reached", "peer", batch.p.id)
+			fs.pm.releaseBatch(batch)
 			if batch.p.addTimeout() {
 				fs.pm.BanPeer(batch.p.id, BanReasonTimeout)
 			}
@@ -306,6 +308,9 @@ func (fs *fastSync) validateHeader(block *block) error {
 	if len(fs.deferredHeaders) > 0 {
 		prevBlock = fs.deferredHeaders[len(fs.deferredHeaders)-1].Header
 	}
+	if err := fs.chain.ValidateCheckpoint(block.Header); err != nil {
+		return err
+	}
 	err := fs.chain.ValidateHeader(block.Header, prevBlock)
 	if err != nil {
 		return err
@@ -325,6 +330,9 @@ func (fs *fastSync) validateHeader(block *block) error {
 }
 
 func (fs *fastSync) postConsuming() error {
+	if fs.headersOnly {
+		return nil
+	}
 	if fs.chain.PreliminaryHead.Height() != fs.manifest.Height {
 		return errors.New("preliminary head is lower than manifest's head")
 	}
@@ -154,6 +154,7 @@ func (fs *fullSync) processBatch(batch *batch, attemptNum int) error {
 			}
 		case <-timeout:
 			fs.log.Warn("process batch - timeout was reached", "peer", batch.p.id)
+			fs.pm.releaseBatch(batch)
 			if batch.p.addTimeout() {
 				fs.pm.BanPeer(batch.p.id, BanReasonTimeout)
 			}
@@ -170,6 +171,9 @@ func (fs *fullSync) validateHeader(block *block, p *protoPeer) error {
 		prevBlock = fs.deferredHeaders[len(fs.deferredHeaders)-1].Header
 	}
 
+	if err := fs.chain.ValidateCheckpoint(block.Header); err != nil {
+		return err
+	}
 	err := fs.chain.ValidateHeader(block.Header, prevBlock)
 	if err != nil {
 		return err
@@ -224,6 +228,7 @@ func (fs *fullSync) SeekBlocks(fromBlock, toBlock uint64, peers []peer.ID) chan
 
 	go func() {
 		for _, batch := range batches {
+			fs.pm.releaseBatch(batch)
 			for i := batch.from; i <= batch.to; i++ {
 				timeout := time.After(time.Second * 10)
 				select {
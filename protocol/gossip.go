@@ -39,7 +39,8 @@ var IdenaProtocol = core.ProtocolID(IdenaProtocolPath + "/1.1.0")
 const MempoolSyncDelay = time.Second * 5
 
 var (
-	batchId = uint32(1)
+	batchId             = uint32(1)
+	stateProofRequestId = uint32(0)
 )
 
 type IdenaGossipHandler struct {
@@ -61,6 +62,8 @@ type IdenaGossipHandler struct {
 	flipKeysPackageChan chan *events.NewFlipKeysPackageEvent
 	incomeBatches       *sync.Map
 	batchedLock         sync.Mutex
+	rangeStats          *rangeStats
+	stateProofRequests  *sync.Map
 	bus                 eventbus.Bus
 	wrongTime           bool
 	appVersion          string
@@ -92,6 +95,8 @@ func NewIdenaGossipHandler(host core.Host, pubsub *pubsub.PubSub, cfg config.P2P
 		peers:               newPeerSet(),
 		incomeBlocks:        make(chan *types.Block, 1000),
 		incomeBatches:       &sync.Map{},
+		rangeStats:          newRangeStats(),
+		stateProofRequests:  &sync.Map{},
 		proposals:           proposals,
 		votes:               votes,
 		pushPullManager:     NewPushPullManager(),
@@ -221,6 +226,12 @@ func (h *IdenaGossipHandler) handle(p *protoPeer) error {
 					p.setHeight(b.Header.Height())
 				}
 				close(batch.headers)
+				if batch.loaded != nil {
+					close(batch.loaded)
+					if batch.settle() {
+						h.rangeStats.loaded(p.id, len(response.Blocks), time.Since(batch.requestTime))
+					}
+				}
 				h.batchedLock.Lock()
 				peerBatches.Delete(response.BatchId)
 				if maputil.IsSyncMapEmpty(peerBatches) {
@@ -468,6 +479,18 @@ func (h *IdenaGossipHandler) handle(p *protoPeer) error {
 			return errResp(DecodeErr, "%v: %v", msg, err)
 		}
 		p.disconnectReason = dc.Reason
+	case GetStateProof:
+		query := new(models.ProtoGetStateProofRequest)
+		if err := proto.Unmarshal(msg.Payload, query); err != nil {
+			return errResp(DecodeErr, "%v: %v", msg, err)
+		}
+		h.provideStateProof(p, query)
+	case StateProof:
+		response := new(models.ProtoStateProof)
+		if err := proto.Unmarshal(msg.Payload, response); err != nil {
+			return errResp(DecodeErr, "%v: %v", msg, err)
+		}
+		h.handleStateProof(p, response)
 	}
 
 	return nil
@@ -581,6 +604,7 @@ func (h *IdenaGossipHandler) unregisterPeer(peerId peer.ID) {
 	if err := h.peers.Unregister(peerId); err != nil {
 		return
 	}
+	h.rangeStats.remove(peerId)
 	peer.closed = true
 	close(peer.term)
 	peer.disconnect("")
@@ -759,10 +783,12 @@ func (h *IdenaGossipHandler) GetBlocksRange(peerId peer.ID, from uint64, to uint
 	}
 
 	b := &batch{
-		from:    from,
-		to:      to,
-		p:       peer,
-		headers: make(chan *block, to-from+1),
+		from:        from,
+		to:          to,
+		p:           peer,
+		headers:     make(chan *block, to-from+1),
+		loaded:      make(chan struct{}),
+		requestTime: time.Now(),
 	}
 	h.batchedLock.Lock()
 	peerBatches, ok := h.incomeBatches.Load(peerId)
@@ -773,14 +799,22 @@ func (h *IdenaGossipHandler) GetBlocksRange(peerId peer.ID, from uint64, to uint
 	id := atomic.AddUint32(&batchId, 1)
 	peerBatches.(*sync.Map).Store(id, b)
 	h.batchedLock.Unlock()
+	h.rangeStats.requested(peerId)
 	peer.sendMsg(GetBlocksRange, &models.ProtoGetBlocksRangeRequest{
-		BatchId: batchId,
+		BatchId: id,
 		From:    from,
 		To:      to,
 	}, common.MultiShard, false)
 	return b, nil
 }
 
+// releaseBatch stops tracking of the requested batch which is not needed anymore
+func (h *IdenaGossipHandler) releaseBatch(b *batch) {
+	if b.loaded != nil && b.settle() {
+		h.rangeStats.cancel(b.p.id)
+	}
+}
+
 func (h *IdenaGossipHandler) GetForkBlockRange(peerId peer.ID, ownBlocks []common.Hash) (*batch, error) {
 	peer := h.peers.Peer(peerId)
 	if peer == nil {
@@ -804,7 +838,7 @@ func (h *IdenaGossipHandler) GetForkBlockRange(peerId peer.ID, ownBlocks []commo
 		data = append(data, ownBlocks[idx][:])
 	}
 	peer.sendMsg(GetForkBlockRange, &models.ProtoGetForkBlockRangeRequest{
-		BatchId: batchId,
+		BatchId: id,
 		Blocks:  data,
 	}, common.MultiShard, false)
 	return b, nil
@@ -1014,6 +1048,21 @@ func (h *IdenaGossipHandler) PeersCount() int {
 func (h *IdenaGossipHandler) OwnShardPeersCount() int {
 	return h.peers.FromShard(h.OwnPeeringShardId())
 }
+
+// OwnShardConnections returns number of connections with peers from own shard and minimal required number of them
+func (h *IdenaGossipHandler) OwnShardConnections() (count int, required int) {
+	required = h.connManager.minimalNumberOfPeersFromShard()
+	shardId := h.OwnPeeringShardId()
+	if shardId == common.MultiShard {
+		return h.PeersCount(), required
+	}
+	return h.connManager.PeersFromShard(shardId), required
+}
+
+func (h *IdenaGossipHandler) PeersCountByShard() map[common.ShardId]int {
+	return h.peers.CountByShard()
+}
+
 func (h *IdenaGossipHandler) Peers() []*protoPeer {
 	return h.peers.Peers()
 }
@@ -0,0 +1,223 @@
+package protocol
+
+import (
+	"github.com/deckarep/golang-set"
+	"github.com/idena-network/idena-go/blockchain"
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/eventbus"
+	"github.com/idena-network/idena-go/core/appstate"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/core/upgrade"
+	"github.com/idena-network/idena-go/ipfs"
+	"github.com/idena-network/idena-go/keystore"
+	"github.com/idena-network/idena-go/log"
+	models "github.com/idena-network/idena-go/protobuf"
+	"github.com/idena-network/idena-go/subscriptions"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"sync/atomic"
+	"time"
+)
+
+const StateProofTimeout = time.Second * 10
+
+var (
+	StateIsNotAvailable = errors.New("state is not available from peers")
+)
+
+// NewLightSync creates a block applier which syncs block headers, certificates and identity state diffs only,
+// block certificates are verified by the committee derived from the synced identity state
+func NewLightSync(pm *IdenaGossipHandler, log log.Logger,
+	chain *blockchain.Blockchain,
+	ipfs ipfs.Proxy,
+	appState *appstate.AppState,
+	potentialForkedPeers mapset.Set,
+	sm *state.SnapshotManager, bus eventbus.Bus, coinbase common.Address, keyStore *keystore.KeyStore,
+	subManager *subscriptions.Manager, upgrader *upgrade.Upgrader) *fastSync {
+	fs := NewFastSync(pm, log, chain, ipfs, appState, potentialForkedPeers, nil, sm, bus, coinbase, keyStore, subManager, upgrader)
+	fs.headersOnly = true
+	return fs
+}
+
+type stateProofRequest struct {
+	peerId peer.ID
+	result chan *models.ProtoStateProof
+}
+
+// provideStateProof builds the proof outside of the peer handling loop, requests which exceed the limit of proofs
+// being built for the peer are rejected
+func (h *IdenaGossipHandler) provideStateProof(p *protoPeer, query *models.ProtoGetStateProofRequest) {
+	response := &models.ProtoStateProof{
+		ReqId:  query.ReqId,
+		Height: query.Height,
+	}
+	if err := h.bcn.CheckStateProofHeight(query.Height); err != nil {
+		response.Error = err.Error()
+		p.sendMsg(StateProof, response, common.MultiShard, false)
+		return
+	}
+	select {
+	case p.servedStateProofs <- struct{}{}:
+	default:
+		response.Error = "too many state proof requests"
+		p.sendMsg(StateProof, response, common.MultiShard, false)
+		return
+	}
+	go func() {
+		defer func() {
+			<-p.servedStateProofs
+		}()
+		if proof, absenceProof, err := h.bcn.GetStateValueWithProof(query.Height, query.Key); err != nil {
+			response.Error = err.Error()
+		} else {
+			response.Proof = proof
+			response.AbsenceProof = absenceProof
+		}
+		p.sendMsg(StateProof, response, common.MultiShard, false)
+	}()
+}
+
+func (h *IdenaGossipHandler) handleStateProof(p *protoPeer, response *models.ProtoStateProof) {
+	value, ok := h.stateProofRequests.Load(response.ReqId)
+	if !ok {
+		return
+	}
+	request := value.(*stateProofRequest)
+	if request.peerId != p.id {
+		return
+	}
+	select {
+	case request.result <- response:
+	default:
+	}
+}
+
+// RequestStateProof requests the raw state value of the key at the height with a merkle proof from the peer,
+// if the peer has no value for the key it responds with a proof of the key absence
+func (h *IdenaGossipHandler) RequestStateProof(peerId peer.ID, height uint64, key []byte) (proof []byte, absenceProof []byte, err error) {
+	p := h.peers.Peer(peerId)
+	if p == nil {
+		return nil, nil, errors.New("peer is not found")
+	}
+	id := atomic.AddUint32(&stateProofRequestId, 1)
+	request := &stateProofRequest{
+		peerId: peerId,
+		result: make(chan *models.ProtoStateProof, 1),
+	}
+	h.stateProofRequests.Store(id, request)
+	defer h.stateProofRequests.Delete(id)
+
+	p.sendMsg(GetStateProof, &models.ProtoGetStateProofRequest{
+		ReqId:  id,
+		Height: height,
+		Key:    key,
+	}, common.MultiShard, false)
+
+	timer := time.NewTimer(StateProofTimeout)
+	defer timer.Stop()
+	select {
+	case response := <-request.result:
+		if response.Error != "" {
+			return nil, nil, errors.New(response.Error)
+		}
+		return response.Proof, response.AbsenceProof, nil
+	case <-timer.C:
+		return nil, nil, errors.New("state proof request timeout")
+	}
+}
+
+// LightState fetches state values from full peers on demand and verifies them against the state root of the synced head
+type LightState struct {
+	pm    *IdenaGossipHandler
+	chain *blockchain.Blockchain
+	log   log.Logger
+}
+
+func NewLightState(pm *IdenaGossipHandler, chain *blockchain.Blockchain) *LightState {
+	return &LightState{
+		pm:    pm,
+		chain: chain,
+		log:   log.New("component", "light"),
+	}
+}
+
+// Height returns the height of the header which state values are verified against
+func (s *LightState) Height() uint64 {
+	if s.chain.PreliminaryHead != nil {
+		return s.chain.PreliminaryHead.Height()
+	}
+	return s.chain.Head.Height()
+}
+
+func (s *LightState) get(key []byte) ([]byte, error) {
+	head := s.chain.Head
+	if s.chain.PreliminaryHead != nil {
+		head = s.chain.PreliminaryHead
+	}
+	for peerId, height := range s.pm.GetKnownHeights() {
+		if height < head.Height() {
+			continue
+		}
+		data, absenceProof, err := s.pm.RequestStateProof(peerId, head.Height(), key)
+		if err != nil {
+			s.log.Debug("Failed to get state proof", "peer", peerId, "err", err)
+			continue
+		}
+		value, err := verifyStateProof(head.Root(), key, data, absenceProof)
+		if err != nil {
+			s.log.Warn("Invalid state proof", "peer", peerId, "err", err)
+			s.pm.BanPeer(peerId, err)
+			continue
+		}
+		return value, nil
+	}
+	return nil, StateIsNotAvailable
+}
+
+// verifyStateProof returns the value proved by the peer response, nil value is returned only if the absence of the key is proved
+func verifyStateProof(root common.Hash, key []byte, proof []byte, absenceProof []byte) ([]byte, error) {
+	if len(proof) > 0 {
+		return state.VerifyValueWithProof(root, key, proof)
+	}
+	if len(absenceProof) == 0 {
+		return nil, errors.New("neither value nor absence is proved")
+	}
+	if err := state.VerifyAbsenceProof(root, key, absenceProof); err != nil {
+		return nil, errors.Wrap(err, "invalid absence proof")
+	}
+	return nil, nil
+}
+
+func (s *LightState) GetAccount(addr common.Address) (state.Account, error) {
+	var account state.Account
+	data, err := s.get(state.StateDbKeys.AddressKey(addr))
+	if err != nil || data == nil {
+		return account, err
+	}
+	err = account.FromBytes(data)
+	return account, err
+}
+
+func (s *LightState) GetIdentity(addr common.Address) (state.Identity, error) {
+	var identity state.Identity
+	data, err := s.get(state.StateDbKeys.IdentityKey(addr))
+	if err != nil || data == nil {
+		return identity, err
+	}
+	err = identity.FromBytes(data)
+	return identity, err
+}
+
+func (s *LightState) GetGlobal() (state.Global, error) {
+	var global state.Global
+	data, err := s.get(state.StateDbKeys.GlobalKey())
+	if err != nil || data == nil {
+		return global, err
+	}
+	err = global.FromBytes(data)
+	return global, err
+}
+
+func (s *LightState) GetContractValue(contract common.Address, key []byte) ([]byte, error) {
+	return s.get(state.StateDbKeys.ContractStoreKey(contract, key))
+}
@@ -0,0 +1,54 @@
+package protocol
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/core/state"
+	"github.com/idena-network/idena-go/crypto"
+	"github.com/stretchr/testify/require"
+	db "github.com/tendermint/tm-db"
+	"math/big"
+	"testing"
+)
+
+func TestVerifyStateProof(t *testing.T) {
+	stateDb, err := state.NewLazy(db.NewMemDB())
+	require.NoError(t, err)
+	var addrs []common.Address
+	for i := 0; i < 10; i++ {
+		key, _ := crypto.GenerateKey()
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		stateDb.SetBalance(addr, big.NewInt(int64(i+1)))
+		addrs = append(addrs, addr)
+	}
+	stateDb.Commit(false)
+	root := stateDb.Root()
+
+	existing := state.StateDbKeys.AddressKey(addrs[2])
+	missing := state.StateDbKeys.AddressKey(common.Address{0x1})
+
+	proof, err := stateDb.GetWithProof(existing)
+	require.NoError(t, err)
+	value, err := verifyStateProof(root, existing, proof, nil)
+	require.NoError(t, err)
+	var account state.Account
+	require.NoError(t, account.FromBytes(value))
+	require.Equal(t, big.NewInt(3), account.Balance)
+
+	absenceProof, err := stateDb.GetAbsenceProof(missing)
+	require.NoError(t, err)
+	value, err = verifyStateProof(root, missing, nil, absenceProof)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// peer returns empty data for the existing key
+	_, err = verifyStateProof(root, existing, nil, nil)
+	require.Error(t, err)
+
+	// peer proves absence of another key instead of the existing one
+	_, err = verifyStateProof(root, existing, nil, absenceProof)
+	require.Error(t, err)
+
+	// peer returns empty data for the missing key without proof
+	_, err = verifyStateProof(root, missing, nil, nil)
+	require.Error(t, err)
+}
@@ -39,6 +39,8 @@ const (
 
 	queuedRequestsSize             = 15000
 	queuedHighPriorityRequestsSize = 4000
+
+	maxServedStateProofs = 4
 )
 
 type compression = byte
@@ -103,6 +105,8 @@ type protoPeer struct {
 	closed               bool
 	supportedFeatures    map[PeerFeature]struct{}
 	disconnectReason     string
+	// limits state proofs which are built for the peer concurrently
+	servedStateProofs chan struct{}
 }
 
 func newPeer(stream network.Stream, maxDelayMs int, metrics *metricCollector) *protoPeer {
@@ -142,6 +146,7 @@ func newPeer(stream network.Stream, maxDelayMs int, metrics *metricCollector) *p
 		potentialHeight:      &syncHeight{},
 		version:              vers,
 		supportedFeatures:    map[PeerFeature]struct{}{},
+		servedStateProofs:    make(chan struct{}, maxServedStateProofs),
 	}
 	SetSupportedFeatures(p)
 	return p
@@ -385,6 +390,10 @@ func toBytes(msgcode uint64, payload interface{}) ([]byte, error) {
 		return payload.(*msgBatch).ToBytes()
 	case Disconnect:
 		return payload.(*disconnect).ToBytes()
+	case GetStateProof:
+		return proto.Marshal(payload.(*models.ProtoGetStateProofRequest))
+	case StateProof:
+		return proto.Marshal(payload.(*models.ProtoStateProof))
 	}
 	return nil, errors.Errorf("type %T is not serializable", payload)
 }
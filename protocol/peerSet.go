@@ -166,3 +166,11 @@ func (ps *peerSet) FromShard(shardId common.ShardId) int {
 	}
 	return cnt
 }
+
+func (ps *peerSet) CountByShard() map[common.ShardId]int {
+	result := make(map[common.ShardId]int)
+	for _, p := range ps.Peers() {
+		result[p.shardId]++
+	}
+	return result
+}
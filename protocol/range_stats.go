@@ -0,0 +1,144 @@
+package protocol
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	"sync"
+	"time"
+)
+
+const (
+	// max number of batches which may be requested from one peer and not loaded yet
+	MaxInFlightBatchesPerPeer = 2
+	// expected time of loading one batch, used to shrink batches for slow peers
+	targetBatchLoadingTime = 10 * time.Second
+	// speed which is assumed for peers without loaded batches, it makes new peers to be tried early
+	defaultPeerSpeed = 100.0
+	speedSmoothing   = 0.5
+	minBatchSize     = 20
+)
+
+type peerRangeStats struct {
+	inFlight int
+	// smoothed blocks per second, 0 means unknown
+	speed float64
+}
+
+// rangeStats tracks blocks range requests per peer to spread them between peers according to their speed
+type rangeStats struct {
+	mutex sync.Mutex
+	peers map[peer.ID]*peerRangeStats
+}
+
+func newRangeStats() *rangeStats {
+	return &rangeStats{
+		peers: make(map[peer.ID]*peerRangeStats),
+	}
+}
+
+func (s *rangeStats) get(id peer.ID) *peerRangeStats {
+	stats, ok := s.peers[id]
+	if !ok {
+		stats = &peerRangeStats{}
+		s.peers[id] = stats
+	}
+	return stats
+}
+
+func (s *rangeStats) requested(id peer.ID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.get(id).inFlight++
+}
+
+func (s *rangeStats) loaded(id peer.ID, blocks int, duration time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats := s.get(id)
+	if stats.inFlight > 0 {
+		stats.inFlight--
+	}
+	seconds := duration.Seconds()
+	if seconds <= 0 || blocks == 0 {
+		return
+	}
+	speed := float64(blocks) / seconds
+	if stats.speed == 0 {
+		stats.speed = speed
+	} else {
+		stats.speed = stats.speed*(1-speedSmoothing) + speed*speedSmoothing
+	}
+}
+
+// failed is called when a batch has not been loaded in time, the peer speed is reduced to make it less preferable
+func (s *rangeStats) failed(id peer.ID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats := s.get(id)
+	if stats.inFlight > 0 {
+		stats.inFlight--
+	}
+	if stats.speed == 0 {
+		stats.speed = defaultPeerSpeed
+	}
+	stats.speed /= 4
+}
+
+// cancel is called for requested batches which are not needed anymore
+func (s *rangeStats) cancel(id peer.ID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats := s.get(id)
+	if stats.inFlight > 0 {
+		stats.inFlight--
+	}
+}
+
+func (s *rangeStats) remove(id peer.ID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.peers, id)
+}
+
+// bestPeer selects a peer which is expected to load the next batch faster than others,
+// peers with too many in-flight batches are skipped
+func (s *rangeStats) bestPeer(heights map[peer.ID]uint64, minHeight uint64, ignored peer.ID) (best peer.ID, ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var bestScore float64
+	for id, height := range heights {
+		if height < minHeight || id == ignored && len(heights) > 1 {
+			continue
+		}
+		stats := s.get(id)
+		if stats.inFlight >= MaxInFlightBatchesPerPeer {
+			continue
+		}
+		speed := stats.speed
+		if speed == 0 {
+			speed = defaultPeerSpeed
+		}
+		score := float64(stats.inFlight+1) / speed
+		if !ok || score < bestScore {
+			best, bestScore, ok = id, score, true
+		}
+	}
+	return best, ok
+}
+
+// batchSize shrinks max batch size for peers which are not able to load it in targetBatchLoadingTime
+func (s *rangeStats) batchSize(id peer.ID, maxSize uint64) uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats := s.get(id)
+	if stats.speed == 0 {
+		return maxSize
+	}
+	size := uint64(stats.speed * targetBatchLoadingTime.Seconds())
+	if size < minBatchSize {
+		size = minBatchSize
+	}
+	if size > maxSize {
+		size = maxSize
+	}
+	return size
+}
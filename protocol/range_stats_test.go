@@ -0,0 +1,113 @@
+package protocol
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRangeStats_batchSize(t *testing.T) {
+	stats := newRangeStats()
+	const maxSize = 1000
+
+	require.Equal(t, uint64(maxSize), stats.batchSize("unknown", maxSize))
+
+	stats.requested("slow")
+	stats.loaded("slow", 10, 10*time.Second)
+	require.Equal(t, uint64(minBatchSize), stats.batchSize("slow", maxSize))
+
+	stats.requested("medium")
+	stats.loaded("medium", 100, 20*time.Second)
+	require.Equal(t, uint64(5*targetBatchLoadingTime.Seconds()), stats.batchSize("medium", maxSize))
+
+	stats.requested("fast")
+	stats.loaded("fast", 1000, time.Second)
+	require.Equal(t, uint64(maxSize), stats.batchSize("fast", maxSize))
+
+	// empty and instant loadings don't change the speed
+	stats.loaded("medium", 0, time.Second)
+	stats.loaded("medium", 100, 0)
+	require.Equal(t, uint64(5*targetBatchLoadingTime.Seconds()), stats.batchSize("medium", maxSize))
+
+	// speed is smoothed
+	stats.loaded("medium", 300, 20*time.Second)
+	require.Equal(t, uint64(10*targetBatchLoadingTime.Seconds()), stats.batchSize("medium", maxSize))
+}
+
+func TestRangeStats_bestPeer(t *testing.T) {
+	stats := newRangeStats()
+	heights := map[peer.ID]uint64{
+		"a": 100,
+		"b": 100,
+		"c": 50,
+	}
+
+	stats.loaded("a", 10, time.Second)
+	stats.loaded("b", 1000, time.Second)
+
+	best, ok := stats.bestPeer(heights, 60, "")
+	require.True(t, ok)
+	require.Equal(t, peer.ID("b"), best)
+
+	// ignored peer is skipped while others exist
+	best, ok = stats.bestPeer(heights, 60, "b")
+	require.True(t, ok)
+	require.Equal(t, peer.ID("a"), best)
+
+	// peer with max in-flight batches is skipped
+	for i := 0; i < MaxInFlightBatchesPerPeer; i++ {
+		stats.requested("b")
+	}
+	best, ok = stats.bestPeer(heights, 60, "")
+	require.True(t, ok)
+	require.Equal(t, peer.ID("a"), best)
+
+	for i := 0; i < MaxInFlightBatchesPerPeer; i++ {
+		stats.requested("a")
+	}
+	_, ok = stats.bestPeer(heights, 60, "")
+	require.False(t, ok)
+
+	// low peer is selected when the range is below its height
+	best, ok = stats.bestPeer(heights, 40, "")
+	require.True(t, ok)
+	require.Equal(t, peer.ID("c"), best)
+
+	// single peer is used even if it is ignored
+	best, ok = stats.bestPeer(map[peer.ID]uint64{"c": 50}, 40, "c")
+	require.True(t, ok)
+	require.Equal(t, peer.ID("c"), best)
+}
+
+func TestRangeStats_reset(t *testing.T) {
+	stats := newRangeStats()
+	heights := map[peer.ID]uint64{"a": 100}
+
+	for i := 0; i < MaxInFlightBatchesPerPeer; i++ {
+		stats.requested("a")
+	}
+	_, ok := stats.bestPeer(heights, 1, "")
+	require.False(t, ok)
+
+	stats.cancel("a")
+	_, ok = stats.bestPeer(heights, 1, "")
+	require.True(t, ok)
+
+	stats.cancel("a")
+	stats.cancel("a")
+	require.Equal(t, 0, stats.peers["a"].inFlight)
+
+	// failed batch frees the slot and slows the peer down
+	stats.requested("a")
+	stats.failed("a")
+	require.Equal(t, 0, stats.peers["a"].inFlight)
+	require.Equal(t, defaultPeerSpeed/4, stats.peers["a"].speed)
+
+	stats.requested("a")
+	stats.loaded("a", 100, time.Second)
+	stats.remove("a")
+	require.NotContains(t, stats.peers, peer.ID("a"))
+	require.Equal(t, uint64(1000), stats.batchSize("a", 1000))
+	require.Equal(t, 0, stats.peers["a"].inFlight)
+}
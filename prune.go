@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var (
+	pruneEpochsFlag = cli.UintFlag{
+		Name:  "epochs",
+		Usage: "Number of finished epochs to keep, Blockchain.RetainEpochs of the config by default",
+	}
+
+	pruneCommand = cli.Command{
+		Name:   "prune",
+		Usage:  "Drop block bodies, receipts and tx indexes of old epochs of the stopped node, headers and certificates are kept",
+		Flags:  []cli.Flag{pruneEpochsFlag},
+		Action: prune,
+	}
+)
+
+func prune(context *cli.Context) error {
+	cfg, err := makeCommandConfig(context)
+	if err != nil {
+		return err
+	}
+	epochs := cfg.Blockchain.RetainEpochs
+	if context.IsSet(pruneEpochsFlag.Name) {
+		epochs = uint32(context.Uint(pruneEpochsFlag.Name))
+	}
+	if epochs == 0 {
+		return errors.New("pruning is disabled, set epochs option or Blockchain.RetainEpochs in the config")
+	}
+	result, err := node.Prune(cfg, epochs)
+	if err != nil {
+		return err
+	}
+	log.Info("Pruning completed", "horizon", result.Horizon, "unpinned", result.Unpinned, "txIndexes", result.TxIndexes)
+	return nil
+}
@@ -2,10 +2,12 @@ package resources
 
 import (
 	"embed"
+	"encoding/json"
 	"io"
 )
 
 
+//go:embed genesis.json
 //go:embed header.tar
 //go:embed identitystatedb.tar
 //go:embed statedb.tar
@@ -28,6 +30,21 @@ func PredefinedState() ([]byte,error) {
 	return content.ReadFile("stategen.out")
 }
 
+// GenesisNetwork returns the network which the genesis files are generated for
+func GenesisNetwork() (uint32, error) {
+	data, err := content.ReadFile("genesis.json")
+	if err != nil {
+		return 0, err
+	}
+	var info struct {
+		Network uint32 `json:"network"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return 0, err
+	}
+	return info.Network, nil
+}
+
 
 
 
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"github.com/idena-network/idena-go/common/hexutil"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"os"
+)
+
+var (
+	rewindHeightFlag = cli.Uint64Flag{
+		Name:  "height",
+		Usage: "Height to roll the chain back to",
+	}
+	rewindTxsFlag = cli.StringFlag{
+		Name:  "txs",
+		Usage: "File to write raw reverted transactions of node accounts to, one per line for bcn_sendRawTransaction",
+	}
+	rewindCrossEpochFlag = cli.BoolFlag{
+		Name:  "cross-epoch",
+		Usage: "Allow rolling back to the previous epoch, flip keys and answers of that epoch are cleared and not restored",
+	}
+
+	rewindCommand = cli.Command{
+		Name:   "rewind",
+		Usage:  "Roll the chain of the stopped node back to the height, the state of the height should be kept by the node",
+		Flags:  []cli.Flag{rewindHeightFlag, rewindTxsFlag, rewindCrossEpochFlag},
+		Action: rewind,
+	}
+)
+
+func rewind(context *cli.Context) error {
+	if !context.IsSet(rewindHeightFlag.Name) {
+		return errors.New("height is required")
+	}
+	cfg, err := makeCommandConfig(context)
+	if err != nil {
+		return err
+	}
+	result, err := node.Rewind(cfg, context.Uint64(rewindHeightFlag.Name), context.Bool(rewindCrossEpochFlag.Name))
+	if err != nil {
+		return err
+	}
+	for _, tx := range result.LocalTxs {
+		log.Info("Reverted local transaction", "hash", tx.Hash().Hex(), "nonce", tx.AccountNonce, "epoch", tx.Epoch)
+	}
+	if txsFile := context.String(rewindTxsFlag.Name); txsFile != "" && len(result.LocalTxs) > 0 {
+		if err := writeRawTxs(txsFile, result); err != nil {
+			return errors.Wrap(err, "cannot write reverted transactions")
+		}
+	}
+	log.Info("Rewind completed", "head", result.Head.Height(), "hash", result.Head.Hash().Hex(),
+		"removedBlocks", result.RemovedBlocks, "localTxs", len(result.LocalTxs), "clearedEpochs", result.ClearedEpochs)
+	return nil
+}
+
+func writeRawTxs(fileName string, result *node.RewindResult) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	for _, tx := range result.LocalTxs {
+		data, err := tx.ToBytes()
+		if err != nil {
+			return err
+		}
+		if _, err := w.WriteString(hexutil.Encode(data) + "\n"); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
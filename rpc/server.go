@@ -24,6 +24,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/idena-network/idena-go/log"
@@ -307,20 +308,40 @@ func (s *Server) handle(ctx context.Context, codec ServerCodec, req *serverReque
 	}
 
 	// execute RPC method and return result
+	start := time.Now()
 	reply := req.callb.method.Func.Call(arguments)
 	if len(reply) == 0 {
+		s.observeCall(req, time.Since(start), false)
 		return codec.CreateResponse(req.id, nil), nil
 	}
 	if req.callb.errPos >= 0 { // test if method returned an error
 		if !reply[req.callb.errPos].IsNil() {
+			s.observeCall(req, time.Since(start), true)
 			e := reply[req.callb.errPos].Interface().(error)
+			if dataErr, ok := e.(DataError); ok {
+				return codec.CreateErrorResponseWithInfo(&req.id, &callbackError{e.Error()}, dataErr.ErrorData()), nil
+			}
 			res := codec.CreateErrorResponse(&req.id, &callbackError{e.Error()})
 			return res, nil
 		}
 	}
+	s.observeCall(req, time.Since(start), false)
 	return codec.CreateResponse(req.id, reply[0].Interface()), nil
 }
 
+// SetCallObserver sets the observer which is notified about every executed RPC method call
+func (s *Server) SetCallObserver(observer CallObserver) {
+	s.callObserver.Store(observer)
+}
+
+func (s *Server) observeCall(req *serverRequest, duration time.Duration, failed bool) {
+	observer, ok := s.callObserver.Load().(CallObserver)
+	if !ok || observer == nil {
+		return
+	}
+	observer(req.svcname+serviceMethodSeparator+formatName(req.callb.method.Name), duration, failed)
+}
+
 // exec executes the given request and writes the result back using the codec.
 func (s *Server) exec(ctx context.Context, codec ServerCodec, req *serverRequest) {
 	var response interface{}
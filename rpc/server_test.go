@@ -238,3 +238,49 @@ func testApiKey(t *testing.T, sendKey bool) {
 		}
 	}
 }
+
+type PartialService struct{}
+
+type partialError struct{ done []int }
+
+func (e *partialError) Error() string { return "failed" }
+
+func (e *partialError) ErrorData() interface{} { return e.done }
+
+func (s *PartialService) Partial() ([]int, error) {
+	done := []int{1, 2}
+	return done, &partialError{done}
+}
+
+func TestServerMethodDataError(t *testing.T) {
+	server := NewServer("")
+	if err := server.RegisterName("test", new(PartialService)); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	go server.ServeCodec(NewJSONCodec(serverConn), OptionMethodInvocation)
+
+	request := map[string]interface{}{
+		"id":      1,
+		"method":  "test_partial",
+		"version": "2.0",
+	}
+	if err := json.NewEncoder(clientConn).Encode(request); err != nil {
+		t.Fatal(err)
+	}
+	var response struct {
+		Error struct {
+			Message string `json:"message"`
+			Data    []int  `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(clientConn).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Error.Message != "failed" || !reflect.DeepEqual(response.Error.Data, []int{1, 2}) {
+		t.Fatalf("unexpected error response %+v", response.Error)
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/idena-network/idena-go/common/hexutil"
@@ -75,8 +77,13 @@ type Server struct {
 	run      int32
 	codecsMu sync.Mutex
 	codecs   mapset.Set
+
+	callObserver atomic.Value
 }
 
+// CallObserver is notified about every executed RPC method call
+type CallObserver func(method string, duration time.Duration, failed bool)
+
 // rpcRequest represents a raw incoming RPC request
 type rpcRequest struct {
 	key      string
@@ -94,6 +101,13 @@ type Error interface {
 	ErrorCode() int // returns the code
 }
 
+// DataError is returned by RPC methods which have done a part of the work before the error,
+// the data is sent in the data field of the error response.
+type DataError interface {
+	Error() string
+	ErrorData() interface{}
+}
+
 // ServerCodec implements reading, parsing and writing RPC messages for the server side of
 // a RPC session. Implementations must be go-routine safe since the codec can be called in
 // multiple go-routines concurrently.
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"github.com/idena-network/idena-go/common/hexutil"
+	"github.com/idena-network/idena-go/log"
+	"github.com/idena-network/idena-go/node"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"os"
+)
+
+var (
+	snapshotFileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "Path of the snapshot archive (tar.gz)",
+	}
+	snapshotHashFlag = cli.StringFlag{
+		Name:  "hash",
+		Usage: "Trusted hash of the snapshot header, optional if a trusted checkpoint is configured at the snapshot height",
+	}
+	snapshotGenesisHashFlag = cli.StringFlag{
+		Name:  "genesis-hash",
+		Usage: "Trusted hash of the intermediate genesis header if the snapshot has one, optional if a trusted checkpoint is configured at its height",
+	}
+
+	createSnapshotCommand = cli.Command{
+		Name:   "create-snapshot",
+		Usage:  "Write the state of the stopped node at its head to a file which can be restored without IPFS",
+		Flags:  []cli.Flag{snapshotFileFlag},
+		Action: createSnapshot,
+	}
+	restoreSnapshotCommand = cli.Command{
+		Name:   "restore-snapshot",
+		Usage:  "Bootstrap a fresh datadir from a file created by create-snapshot and a trusted header hash",
+		Flags:  []cli.Flag{snapshotFileFlag, snapshotHashFlag, snapshotGenesisHashFlag},
+		Action: restoreSnapshot,
+	}
+)
+
+func createSnapshot(context *cli.Context) error {
+	fileName := context.String(snapshotFileFlag.Name)
+	if fileName == "" {
+		return errors.New("file option is required")
+	}
+	cfg, err := makeCommandConfig(context)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	header, genesis, err := node.CreateSnapshot(cfg, file)
+	if err != nil {
+		file.Close()
+		os.Remove(fileName)
+		return err
+	}
+	log.Info("Snapshot created, pass the header hash to restore-snapshot", "height", header.Height(),
+		"hash", header.Hash().Hex(), "file", fileName)
+	if genesis != nil {
+		log.Info("Snapshot has intermediate genesis, pass its hash to restore-snapshot as well", "height", genesis.Height(),
+			"hash", genesis.Hash().Hex())
+	}
+	return nil
+}
+
+func restoreSnapshot(context *cli.Context) error {
+	fileName := context.String(snapshotFileFlag.Name)
+	if fileName == "" {
+		return errors.New("file option is required")
+	}
+	trustedHash, err := parseHashFlag(context, snapshotHashFlag.Name)
+	if err != nil {
+		return err
+	}
+	trustedGenesisHash, err := parseHashFlag(context, snapshotGenesisHashFlag.Name)
+	if err != nil {
+		return err
+	}
+	cfg, err := makeCommandConfig(context)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	header, err := node.RestoreSnapshot(cfg, file, trustedHash, trustedGenesisHash)
+	if err != nil {
+		return err
+	}
+	log.Info("Snapshot restored", "height", header.Height(), "hash", header.Hash().Hex())
+	return nil
+}
+
+func parseHashFlag(context *cli.Context, name string) (common.Hash, error) {
+	value := context.String(name)
+	if value == "" {
+		return common.Hash{}, nil
+	}
+	bytes, err := hexutil.Decode(value)
+	if err != nil || len(bytes) != common.HashLength {
+		return common.Hash{}, errors.Errorf("invalid %v option", name)
+	}
+	return common.BytesToHash(bytes), nil
+}
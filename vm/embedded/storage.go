@@ -0,0 +1,135 @@
+package embedded
+
+import (
+	"bytes"
+	"github.com/idena-network/idena-go/common"
+)
+
+// Formats of values in contract storage, they match formats of contract api
+const (
+	FormatByte    = "byte"
+	FormatUint16  = "uint16"
+	FormatUint64  = "uint64"
+	FormatDna     = "dna"
+	FormatHex     = "hex"
+	FormatAddress = "address"
+)
+
+// StorageField describes a key or a map of the contract storage. Map keys are the name followed by the map key.
+type StorageField struct {
+	Name      string
+	Map       bool
+	KeyFormat string
+	// KeyLength is the length of map keys
+	KeyLength int
+	Format    string
+}
+
+func storageValue(name, format string) StorageField {
+	return StorageField{Name: name, Format: format}
+}
+
+func storageMap(name, keyFormat string, keyLength int, format string) StorageField {
+	return StorageField{Name: name, Map: true, KeyFormat: keyFormat, KeyLength: keyLength, Format: format}
+}
+
+var storageLayouts map[EmbeddedContractType][]StorageField
+
+func init() {
+	owner := storageValue("owner", FormatAddress)
+	storageLayouts = map[EmbeddedContractType][]StorageField{
+		TimeLockContract: {
+			owner,
+			storageValue("timestamp", FormatUint64),
+		},
+		OracleVotingContract: {
+			owner,
+			storageValue(keyFact, FormatHex),
+			storageValue(keyHash, FormatHex),
+			storageValue(keyResult, FormatByte),
+			storageValue("state", FormatByte),
+			storageValue("startTime", FormatUint64),
+			storageValue("votingDuration", FormatUint64),
+			storageValue("publicVotingDuration", FormatUint64),
+			storageValue("winnerThreshold", FormatByte),
+			storageValue("quorum", FormatByte),
+			storageValue("committeeSize", FormatUint64),
+			storageValue("ownerFee", FormatByte),
+			storageValue("votingMinPayment", FormatDna),
+			storageValue("oracleRewardFund", FormatDna),
+			storageValue("ownerDeposit", FormatDna),
+			storageValue("refundRecipient", FormatAddress),
+			storageValue("dis", FormatByte),
+			storageValue("notDisP", FormatByte),
+			storageValue("notDisV", FormatByte),
+			storageValue("no-growth", FormatByte),
+			storageValue("startBlock", FormatUint64),
+			storageValue("network", FormatUint64),
+			storageValue("vrfSeed", FormatHex),
+			storageValue("epoch", FormatUint16),
+			storageValue("votedCount", FormatUint64),
+			storageValue("secretVotesCount", FormatUint64),
+			storageValue("prolongVoteCount", FormatUint64),
+			storageMap("voteHashes", FormatAddress, common.AddressLength, FormatHex),
+			storageMap("votes", FormatAddress, common.AddressLength, FormatByte),
+			storageMap("voteOptions", FormatByte, 1, FormatUint64),
+			storageMap("allVotes", FormatByte, 1, FormatUint64),
+			storageMap("poolVotes", FormatAddress, common.AddressLength, FormatByte),
+		},
+		OracleLockContract: {
+			owner,
+			storageValue("oracleVotingAddr", FormatAddress),
+			storageValue("value", FormatByte),
+			storageValue("successAddr", FormatAddress),
+			storageValue("failAddr", FormatAddress),
+			storageValue("isOracleVotingFinished", FormatByte),
+			storageValue("voted", FormatByte),
+			storageValue("hasVotedValue", FormatByte),
+		},
+		RefundableOracleLockContract: {
+			owner,
+			storageValue("oracleVoting", FormatAddress),
+			storageValue("value", FormatByte),
+			storageValue("successAddr", FormatAddress),
+			storageValue("failAddr", FormatAddress),
+			storageValue("refundDelay", FormatUint64),
+			storageValue("depositDeadline", FormatUint64),
+			storageValue("factEvidenceFee", FormatByte),
+			storageValue("state", FormatByte),
+			storageValue("sum", FormatDna),
+			storageValue("refundBlock", FormatUint64),
+			storageMap("deposits", FormatAddress, common.AddressLength, FormatDna),
+		},
+		MultisigContract: {
+			owner,
+			storageValue("maxVotes", FormatByte),
+			storageValue("minVotes", FormatByte),
+			storageValue("state", FormatByte),
+			storageValue("count", FormatByte),
+			storageMap("addr", FormatAddress, common.AddressLength, FormatAddress),
+			storageMap("amount", FormatAddress, common.AddressLength, FormatDna),
+		},
+	}
+}
+
+// StorageLayout returns known keys and maps of the embedded contract, nil for unknown code hash
+func StorageLayout(codeHash common.Hash) []StorageField {
+	return storageLayouts[codeHash]
+}
+
+// FindStorageField looks up the field of the storage key in the layout, for map fields the map key is returned too.
+// Plain keys are matched first, so keys which start with a map name aren't taken as map items.
+func FindStorageField(layout []StorageField, key []byte) (field *StorageField, mapKey []byte) {
+	for i := range layout {
+		if !layout[i].Map && string(key) == layout[i].Name {
+			return &layout[i], nil
+		}
+	}
+	for i := range layout {
+		f := &layout[i]
+		if f.Map && bytes.HasPrefix(key, []byte(f.Name)) && len(key) == len(f.Name)+f.KeyLength {
+			return f, key[len(f.Name):]
+		}
+	}
+	return nil, nil
+}
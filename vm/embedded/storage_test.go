@@ -0,0 +1,36 @@
+package embedded
+
+import (
+	"github.com/idena-network/idena-go/common"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFindStorageField(t *testing.T) {
+	layout := StorageLayout(OracleVotingContract)
+	require.NotEmpty(t, layout)
+	addr := common.Address{0x1}
+
+	field, mapKey := FindStorageField(layout, []byte("votedCount"))
+	require.NotNil(t, field)
+	require.Equal(t, "votedCount", field.Name)
+	require.Nil(t, mapKey)
+
+	field, mapKey = FindStorageField(layout, append([]byte("votes"), addr.Bytes()...))
+	require.NotNil(t, field)
+	require.Equal(t, "votes", field.Name)
+	require.Equal(t, addr.Bytes(), mapKey)
+
+	field, mapKey = FindStorageField(layout, append([]byte("voteOptions"), 1))
+	require.NotNil(t, field)
+	require.Equal(t, "voteOptions", field.Name)
+	require.Equal(t, []byte{1}, mapKey)
+
+	field, _ = FindStorageField(layout, append([]byte("votes"), 1))
+	require.Nil(t, field)
+
+	field, _ = FindStorageField(layout, []byte("unknown"))
+	require.Nil(t, field)
+
+	require.Nil(t, StorageLayout(common.Hash{0xff}))
+}